@@ -2,7 +2,10 @@ module github.com/janmarkuslanger/nuricms
 
 go 1.24.1
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/graphql-go/graphql v0.8.1
+	github.com/stretchr/testify v1.10.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -7,8 +7,9 @@ import (
 )
 
 type ErrorDetail struct {
-	Code    string `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
+	Code    string              `json:"code,omitempty"`
+	Message string              `json:"message,omitempty"`
+	Fields  map[string][]string `json:"fields,omitempty"`
 }
 
 type MetaData struct {
@@ -16,9 +17,13 @@ type MetaData struct {
 }
 
 type Pagination struct {
-	Page    int `json:"page,omitempty"`
-	PerPage int `json:"per_page,omitempty"`
-	Total   int `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ApiResponse struct {
@@ -41,18 +46,79 @@ type AssetResponse struct {
 	Path string `json:"path,omitempty"`
 }
 
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type Block struct {
+	Component string         `json:"component"`
+	Values    map[string]any `json:"values"`
+}
+
 type ContentItemResponse struct {
-	ID         uint               `json:"id"`
-	CreatedAt  time.Time          `json:"created_at"`
-	UpdatedAt  time.Time          `json:"updated_at"`
-	Values     map[string]any     `json:"values"`
-	Collection CollectionResponse `json:"collection"`
+	ID          uint               `json:"id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Status      string             `json:"status"`
+	PublishAt   *time.Time         `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time         `json:"unpublish_at,omitempty"`
+	Values      map[string]any     `json:"values"`
+	Collection  CollectionResponse `json:"collection"`
+
+	LastModified time.Time `json:"-"`
 }
 
 type ContentValueResponse struct {
-	ID         uint                `json:"id"`
-	Value      any                 `json:"value"`
-	FieldType  model.FieldType     `json:"field_type"`
-	Collection *CollectionResponse `json:"collection,omitempty"`
-	Asset      *AssetResponse      `json:"asset,omitempty"`
+	ID         uint                 `json:"id"`
+	Value      any                  `json:"value"`
+	FieldType  model.FieldType      `json:"field_type"`
+	Collection *CollectionResponse  `json:"collection,omitempty"`
+	Asset      *AssetResponse       `json:"asset,omitempty"`
+	Content    *ContentItemResponse `json:"content,omitempty"`
+}
+
+type ContentWriteRequest struct {
+	Status       string                    `json:"status,omitempty"`
+	PublishAt    *time.Time                `json:"publish_at,omitempty"`
+	UnpublishAt  *time.Time                `json:"unpublish_at,omitempty"`
+	Values       map[string]any            `json:"values"`
+	Translations map[string]map[string]any `json:"translations,omitempty"`
+}
+
+const (
+	ApiVersionLegacy = 1
+	ApiVersionTyped  = 2
+	ApiVersionLatest = ApiVersionTyped
+)
+
+const MaxIncludeDepth = 5
+
+type FilterCondition struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+type ContentFilter struct {
+	Or         bool
+	Conditions []FilterCondition
+}
+
+type ApiQuery struct {
+	Preview bool
+	Version int
+	Depth   int
+	Include []string
+	Filter  *ContentFilter
+	Sort    string
+	After   uint
+	Fields  []string
+	// Locales is the fallback chain of stored locales to read localized
+	// values from, "" stands for the default locale.
+	Locales []string
+}
+
+func (q ApiQuery) TypedValues() bool {
+	return q.Version >= ApiVersionTyped
 }
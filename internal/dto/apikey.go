@@ -1,5 +1,7 @@
 package dto
 
 type ApikeyData struct {
-	Name string
+	Name       string
+	CanPreview string
+	CanWrite   string
 }
@@ -4,4 +4,5 @@ type CollectionData struct {
 	Name        string
 	Alias       string
 	Description string
+	IsComponent string
 }
@@ -1,7 +1,38 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+)
+
+type ContentSchedule struct {
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
 type ContentWithValues struct {
 	CollectionID uint
 	ContentID    uint
+	Status       model.ContentStatus
+	Schedule     *ContentSchedule
+	UserID       uint
 	FormData     map[string][]string
+	// Translations holds the values of localized fields per locale. Nil keeps
+	// the stored translations on edit.
+	Translations map[string]map[string][]string
+}
+
+type MissingTranslation struct {
+	ContentID uint
+	Label     string
+	Locale    string
+	Fields    []string
+}
+
+type StatusChange struct {
+	ContentID    uint
+	CollectionID uint
+	From         model.ContentStatus
+	To           model.ContentStatus
 }
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type ContentEvent struct {
+	ID         uint64               `json:"id"`
+	Type       string               `json:"type"`
+	ContentID  uint                 `json:"content_id"`
+	Collection string               `json:"collection"`
+	Timestamp  time.Time            `json:"timestamp"`
+	Item       *ContentItemResponse `json:"item,omitempty"`
+}
@@ -8,4 +8,20 @@ type FieldData struct {
 	IsList       string
 	IsRequired   string
 	DisplayField string
+	Localized    string
+	IsUnique     string
+
+	MinValue      string
+	MaxValue      string
+	MinLength     string
+	MaxLength     string
+	Pattern       string
+	MinDate       string
+	MaxDate       string
+	MinItems      string
+	MaxItems      string
+	AllowedValues string
+	SlugSource    string
+
+	TargetCollections []string
 }
@@ -0,0 +1,7 @@
+package dto
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// PickerItem is an entry or asset offered by the pickers of the content
+// editor.
+type PickerItem struct {
+	ID    uint   `json:"id"`
+	Label string `json:"label"`
+}
+
+type PickerResponse struct {
+	Items   []PickerItem `json:"items"`
+	HasMore bool         `json:"has_more"`
+}
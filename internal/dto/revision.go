@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/janmarkuslanger/nuricms/internal/model"
+
+type FieldChange struct {
+	Alias  string
+	Name   string
+	Before []string
+	After  []string
+}
+
+type RevisionEntry struct {
+	Revision model.ContentRevision
+	Author   string
+	Changes  []FieldChange
+}
@@ -0,0 +1,7 @@
+package dto
+
+type SearchHit struct {
+	Score   float64             `json:"score"`
+	Snippet string              `json:"snippet"`
+	Item    ContentItemResponse `json:"item"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type SyncTombstone struct {
+	ID         uint               `json:"id"`
+	Collection CollectionResponse `json:"collection"`
+	Reason     string             `json:"reason"`
+	DeletedAt  time.Time          `json:"deleted_at"`
+}
+
+type SyncResponse struct {
+	Items     []ContentItemResponse `json:"items"`
+	Deleted   []SyncTombstone       `json:"deleted"`
+	NextToken string                `json:"next_token"`
+	HasMore   bool                  `json:"has_more"`
+}
@@ -1,12 +1,21 @@
 package globals
 
 var FieldTemplates = map[string]string{
-	"Text":       "template_fields/text",
-	"Number":     "template_fields/number",
-	"Boolean":    "template_fields/boolean",
-	"Date":       "template_fields/date",
-	"Textarea":   "template_fields/textarea",
-	"RichText":   "template_fields/richtext",
-	"Collection": "template_fields/collection",
-	"Asset":      "template_fields/asset",
+	"Text":        "template_fields/text",
+	"Number":      "template_fields/number",
+	"Boolean":     "template_fields/boolean",
+	"Date":        "template_fields/date",
+	"Textarea":    "template_fields/textarea",
+	"RichText":    "template_fields/richtext",
+	"Collection":  "template_fields/collection",
+	"Asset":       "template_fields/asset",
+	"Select":      "template_fields/select",
+	"MultiSelect": "template_fields/multiselect",
+	"JSON":        "template_fields/json",
+	"URL":         "template_fields/url",
+	"Email":       "template_fields/email",
+	"Color":       "template_fields/color",
+	"Slug":        "template_fields/slug",
+	"GeoPoint":    "template_fields/geopoint",
+	"Blocks":      "template_fields/blocks",
 }
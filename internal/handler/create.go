@@ -14,7 +14,9 @@ type CreateHandler[A any, B any] interface {
 func HandleCreate[A any, B any](ctx server.Context, s CreateHandler[A, B], dto A, ho HandlerOptions) {
 	if _, err := s.Create(dto); err != nil {
 		if ho.RenderOnFail != "" {
-			utils.RenderWithLayoutHTTP(ctx, ho.RenderOnFail, map[string]any{}, http.StatusOK)
+			utils.RenderWithLayoutHTTP(ctx, ho.RenderOnFail, map[string]any{
+				"Error": err.Error(),
+			}, http.StatusOK)
 			return
 		}
 	}
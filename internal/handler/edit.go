@@ -32,7 +32,7 @@ func HandleEdit[A any, B any](ctx server.Context, s EditHandler[A, B], idStr str
 	if err != nil {
 		if ho.RenderOnFail != "" {
 			utils.RenderWithLayoutHTTP(ctx, ho.RenderOnFail, map[string]any{
-				"Error": "Could not find item",
+				"Error": err.Error(),
 			}, http.StatusOK)
 			return
 		}
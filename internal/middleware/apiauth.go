@@ -3,29 +3,66 @@ package middleware
 import (
 	"encoding/json"
 	"net/http"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
 )
 
 type validator interface {
 	Validate(token string) error
 }
 
+type keyFinder interface {
+	FindByToken(token string) (*model.Apikey, error)
+}
+
+func unauthorized(w http.ResponseWriter, code, message string) {
+	apiError(w, http.StatusUnauthorized, code, message)
+}
+
+func apiError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(dto.ApiResponse{
+		Success: false,
+		Error: &dto.ErrorDetail{
+			Code:    code,
+			Message: message,
+		},
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+	})
+}
+
 func ApikeyAuth(keySvc validator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := r.Header.Get("X-API-Key")
 			if token == "" {
-				w.WriteHeader(http.StatusUnauthorized)
-				json.NewEncoder(w).Encode(map[string]string{
-					"error": "missing X-API-Key",
-				})
+				unauthorized(w, "missing_api_key", "missing X-API-Key")
 				return
 			}
 
 			if err := keySvc.Validate(token); err != nil {
-				w.WriteHeader(http.StatusUnauthorized)
-				json.NewEncoder(w).Encode(map[string]string{
-					"error": err.Error(),
-				})
+				unauthorized(w, "invalid_api_key", err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ApikeyWrite only lets keys with the write permission through. It runs after
+// ApikeyAuth, which already checked that the key exists and is not expired.
+func ApikeyWrite(keys keyFinder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := keys.FindByToken(r.Header.Get("X-API-Key"))
+			if err != nil || !key.CanWrite {
+				apiError(w, http.StatusForbidden, "write_forbidden", "api key is not allowed to write content")
 				return
 			}
 
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +31,7 @@ func TestApikeyAuth_MissingHeader(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	assert.Contains(t, rr.Body.String(), "missing X-API-Key")
+	assert.Contains(t, rr.Body.String(), `"code":"missing_api_key"`)
 }
 
 func TestApikeyAuth_InvalidToken(t *testing.T) {
@@ -51,6 +53,7 @@ func TestApikeyAuth_InvalidToken(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	assert.Contains(t, rr.Body.String(), "invalid token")
+	assert.Contains(t, rr.Body.String(), `"code":"invalid_api_key"`)
 }
 
 func TestApikeyAuth_ValidToken(t *testing.T) {
@@ -74,3 +77,45 @@ func TestApikeyAuth_ValidToken(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "success", rr.Body.String())
 }
+
+type apimockFinder struct {
+	key *model.Apikey
+}
+
+func (m *apimockFinder) FindByToken(token string) (*model.Apikey, error) {
+	if m.key == nil {
+		return nil, errors.New("not found")
+	}
+	return m.key, nil
+}
+
+func TestApikeyWrite(t *testing.T) {
+	cases := []struct {
+		name   string
+		key    *model.Apikey
+		status int
+	}{
+		{"read-only key", &model.Apikey{CanPreview: true}, http.StatusForbidden},
+		{"unknown key", nil, http.StatusForbidden},
+		{"write key", &model.Apikey{CanWrite: true}, http.StatusOK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			handler := ApikeyWrite(&apimockFinder{key: c.key})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("X-API-Key", "token")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, c.status, rr.Code)
+			if c.status == http.StatusForbidden {
+				assert.Contains(t, rr.Body.String(), `"code":"write_forbidden"`)
+			}
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 type Apikey struct {
 	gorm.Model
-	Name      string `gorm:"not null"`
-	Token     string `gorm:"uniqueIndex"`
-	ExpiresAt *time.Time
+	Name       string `gorm:"not null"`
+	Token      string `gorm:"uniqueIndex"`
+	ExpiresAt  *time.Time
+	CanPreview bool `gorm:"not null;default:false"`
+	CanWrite   bool `gorm:"not null;default:false"`
 }
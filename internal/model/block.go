@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Block is one component instance of a Blocks field, stored as the JSON value
+// of a content value. Its values are keyed by the aliases of the component's
+// fields, like form data.
+type Block struct {
+	Component string              `json:"component"`
+	Values    map[string][]string `json:"values"`
+}
+
+func ParseBlock(value string) (Block, error) {
+	var b Block
+	if err := json.Unmarshal([]byte(value), &b); err != nil {
+		return b, errors.New("must be a block")
+	}
+
+	if strings.TrimSpace(b.Component) == "" {
+		return b, errors.New("block needs a component")
+	}
+
+	if b.Values == nil {
+		b.Values = map[string][]string{}
+	}
+
+	return b, nil
+}
+
+func (b Block) String() string {
+	raw, _ := json.Marshal(b)
+	return string(raw)
+}
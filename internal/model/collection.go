@@ -10,4 +10,8 @@ type Collection struct {
 	Alias       string  `gorm:"size:80;not null"`
 	Description string  `gorm:"size:255"`
 	Fields      []Field `gorm:"foreignKey:CollectionID"`
+
+	// IsComponent marks a reusable field group for Blocks fields, components
+	// hold no entries of their own.
+	IsComponent bool `gorm:"not null;default:false"`
 }
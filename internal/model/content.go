@@ -1,12 +1,77 @@
 package model
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+type ContentStatus string
+
+const (
+	ContentStatusDraft     ContentStatus = "Draft"
+	ContentStatusPublished ContentStatus = "Published"
+	ContentStatusArchived  ContentStatus = "Archived"
+)
+
 type Content struct {
 	gorm.Model
 	CollectionID  uint           `gorm:"not null"`
 	Collection    Collection     `gorm:"foreignKey:CollectionID"`
+	Status        ContentStatus  `gorm:"type:varchar(20);not null;default:Published;index"`
+	PublishAt     *time.Time     `gorm:"index"`
+	UnpublishAt   *time.Time     `gorm:"index"`
 	ContentValues []ContentValue `gorm:"foreignKey:ContentID;references:ID"`
 }
+
+func GetContentStatuses() []ContentStatus {
+	return []ContentStatus{
+		ContentStatusDraft,
+		ContentStatusPublished,
+		ContentStatusArchived,
+	}
+}
+
+func IsValidContentStatus(status ContentStatus) bool {
+	for _, s := range GetContentStatuses() {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c Content) IsVisibleAt(now time.Time) bool {
+	if c.UnpublishAt != nil && !c.UnpublishAt.After(now) {
+		return false
+	}
+
+	switch c.Status {
+	case ContentStatusPublished:
+		return c.PublishAt == nil || !c.PublishAt.After(now)
+	case ContentStatusDraft:
+		return c.PublishAt != nil && !c.PublishAt.After(now)
+	default:
+		return false
+	}
+}
+
+// DisplayLabel names the entry by its collection and loaded values, or by its
+// ID when it has none.
+func (c Content) DisplayLabel() string {
+	values := make([]string, 0, len(c.ContentValues))
+	for _, cv := range c.ContentValues {
+		if v := strings.TrimSpace(cv.Value); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		return fmt.Sprintf("%s: #%d", c.Collection.Name, c.ID)
+	}
+
+	return c.Collection.Name + ": " + strings.Join(values, " ")
+}
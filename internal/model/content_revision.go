@@ -0,0 +1,44 @@
+package model
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+type RevisionValue struct {
+	FieldID   uint   `json:"field_id"`
+	Alias     string `json:"alias"`
+	SortIndex int    `json:"sort_index"`
+	Locale    string `json:"locale,omitempty"`
+	Value     string `json:"value"`
+}
+
+type ContentRevision struct {
+	gorm.Model
+	ContentID uint    `gorm:"not null;index"`
+	Content   Content `gorm:"foreignKey:ContentID"`
+	UserID    *uint
+	User      *User  `gorm:"foreignKey:UserID"`
+	Snapshot  string `gorm:"type:text;not null"`
+}
+
+func (r ContentRevision) Values() ([]RevisionValue, error) {
+	var values []RevisionValue
+	if r.Snapshot == "" {
+		return values, nil
+	}
+
+	err := json.Unmarshal([]byte(r.Snapshot), &values)
+	return values, err
+}
+
+func (r *ContentRevision) SetValues(values []RevisionValue) error {
+	snapshot, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	r.Snapshot = string(snapshot)
+	return nil
+}
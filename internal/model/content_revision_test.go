@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentRevision_Values_RoundTrip(t *testing.T) {
+	var rev ContentRevision
+	values := []RevisionValue{
+		{FieldID: 1, Alias: "title", SortIndex: 1, Value: "Hello"},
+		{FieldID: 2, Alias: "tags", SortIndex: 2, Value: "go"},
+	}
+
+	assert.NoError(t, rev.SetValues(values))
+
+	got, err := rev.Values()
+	assert.NoError(t, err)
+	assert.Equal(t, values, got)
+}
+
+func TestContentRevision_Values_Empty(t *testing.T) {
+	got, err := ContentRevision{}.Values()
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestContentRevision_Values_Invalid(t *testing.T) {
+	_, err := ContentRevision{Snapshot: "{"}.Values()
+	assert.Error(t, err)
+}
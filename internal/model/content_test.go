@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetContentStatuses(t *testing.T) {
+	statuses := GetContentStatuses()
+	assert.Len(t, statuses, 3)
+	assert.Contains(t, statuses, ContentStatusDraft)
+	assert.Contains(t, statuses, ContentStatusPublished)
+	assert.Contains(t, statuses, ContentStatusArchived)
+}
+
+func TestIsValidContentStatus(t *testing.T) {
+	assert.True(t, IsValidContentStatus(ContentStatusPublished))
+	assert.False(t, IsValidContentStatus(ContentStatus("Deleted")))
+	assert.False(t, IsValidContentStatus(ContentStatus("")))
+}
+
+func TestContent_IsVisibleAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.True(t, Content{Status: ContentStatusPublished}.IsVisibleAt(now))
+	assert.True(t, Content{Status: ContentStatusPublished, PublishAt: &past}.IsVisibleAt(now))
+	assert.False(t, Content{Status: ContentStatusPublished, PublishAt: &future}.IsVisibleAt(now))
+	assert.False(t, Content{Status: ContentStatusPublished, UnpublishAt: &past}.IsVisibleAt(now))
+	assert.True(t, Content{Status: ContentStatusDraft, PublishAt: &past, UnpublishAt: &future}.IsVisibleAt(now))
+	assert.False(t, Content{Status: ContentStatusDraft}.IsVisibleAt(now))
+	assert.False(t, Content{Status: ContentStatusArchived}.IsVisibleAt(now))
+}
+
+func TestContent_DisplayLabel(t *testing.T) {
+	c := Content{Model: gorm.Model{ID: 4}, Collection: Collection{Name: "Author"}}
+	assert.Equal(t, "Author: #4", c.DisplayLabel())
+
+	c.ContentValues = []ContentValue{{Value: "Jane"}, {Value: " "}, {Value: "Doe"}}
+	assert.Equal(t, "Author: Jane Doe", c.DisplayLabel())
+}
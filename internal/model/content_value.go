@@ -13,5 +13,8 @@ type ContentValue struct {
 	FieldID uint
 	Field   Field `gorm:"foreignKey:FieldID;references:ID"`
 
+	// Locale is empty for values of the default locale.
+	Locale string `gorm:"size:35;not null;default:'';index"`
+
 	Value string `gorm:"type:text"`
 }
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"strconv"
+	"strings"
+
 	"gorm.io/gorm"
 )
 
@@ -16,6 +19,14 @@ const (
 	FieldTypeTextarea    FieldType = "Textarea"
 	FieldTypeRichText    FieldType = "RichText"
 	FieldTypeMultiSelect FieldType = "MultiSelect"
+	FieldTypeSelect      FieldType = "Select"
+	FieldTypeJSON        FieldType = "JSON"
+	FieldTypeURL         FieldType = "URL"
+	FieldTypeEmail       FieldType = "Email"
+	FieldTypeColor       FieldType = "Color"
+	FieldTypeSlug        FieldType = "Slug"
+	FieldTypeGeoPoint    FieldType = "GeoPoint"
+	FieldTypeBlocks      FieldType = "Blocks"
 )
 
 type Field struct {
@@ -28,4 +39,90 @@ type Field struct {
 	IsList       bool       `gorm:"not null;default:false"`
 	IsRequired   bool       `gorm:"not null;default:false"`
 	DisplayField bool       `gorm:"not null;default:false"`
+	Localized    bool       `gorm:"not null;default:false"`
+	IsUnique     bool       `gorm:"not null;default:false"`
+
+	MinValue      *float64
+	MaxValue      *float64
+	MinLength     *int
+	MaxLength     *int
+	Pattern       string `gorm:"size:255"`
+	MinDate       string `gorm:"size:10"`
+	MaxDate       string `gorm:"size:10"`
+	MinItems      *int
+	MaxItems      *int
+	AllowedValues string `gorm:"type:text"`
+
+	// SlugSource is the alias of the field a Slug field is derived from.
+	SlugSource string `gorm:"size:80"`
+
+	// TargetCollections holds the IDs of the collections a Collection field
+	// may reference, one per line. Empty allows every collection.
+	TargetCollections string `gorm:"type:text"`
+
+	Options []FieldOption `gorm:"foreignKey:FieldID"`
+}
+
+// HasOptions reports whether the values of the field are picked from its
+// select options.
+func (f Field) HasOptions() bool {
+	return f.FieldType == FieldTypeSelect || f.FieldType == FieldTypeMultiSelect
+}
+
+// Unique reports whether no two entries may share a value of the field,
+// slugs are always unique.
+func (f Field) Unique() bool {
+	return f.IsUnique || f.FieldType == FieldTypeSlug
+}
+
+// AcceptsMany reports whether the field stores more than one value.
+func (f Field) AcceptsMany() bool {
+	return f.IsList || f.FieldType == FieldTypeMultiSelect
+}
+
+func (f Field) OptionValues() []string {
+	values := make([]string, 0, len(f.Options))
+	for _, o := range f.Options {
+		values = append(values, o.Value)
+	}
+	return values
+}
+
+func (f Field) AllowedValueList() []string {
+	return ParseAllowedValues(f.AllowedValues)
+}
+
+func (f Field) TargetCollectionIDs() []uint {
+	var ids []uint
+	for _, v := range ParseAllowedValues(f.TargetCollections) {
+		if id, err := strconv.ParseUint(v, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// AllowsTarget reports whether the field may reference entries of the
+// collection.
+func (f Field) AllowsTarget(collectionID uint) bool {
+	ids := f.TargetCollectionIDs()
+	if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if id == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseAllowedValues(raw string) []string {
+	var values []string
+	for _, v := range strings.Split(raw, "\n") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
 }
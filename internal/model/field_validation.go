@@ -0,0 +1,237 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const DateLayout = "2006-01-02"
+
+var (
+	colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	slugPattern  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// ParseGeoPoint parses a "lat,lng" value and checks both are in range.
+func ParseGeoPoint(value string) (lat, lng float64, err error) {
+	rawLat, rawLng, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, errors.New("must be latitude,longitude")
+	}
+
+	lat, errLat := strconv.ParseFloat(strings.TrimSpace(rawLat), 64)
+	lng, errLng := strconv.ParseFloat(strings.TrimSpace(rawLng), 64)
+	if errLat != nil || errLng != nil || !IsFinite(lat) || !IsFinite(lng) {
+		return 0, 0, errors.New("must be latitude,longitude")
+	}
+
+	if lat < -90 || lat > 90 {
+		return 0, 0, errors.New("latitude must be between -90 and 90")
+	}
+	if lng < -180 || lng > 180 {
+		return 0, 0, errors.New("longitude must be between -180 and 180")
+	}
+
+	return lat, lng, nil
+}
+
+// IsFinite reports whether n is neither NaN nor infinite, which JSON can not
+// encode.
+func IsFinite(n float64) bool {
+	return !math.IsNaN(n) && !math.IsInf(n, 0)
+}
+
+var slugReplacer = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "æ", "ae", "ø", "o", "å", "a",
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n", "ò", "o", "ó", "o", "ô", "o", "õ", "o",
+	"ù", "u", "ú", "u", "û", "u", "ý", "y", "ÿ", "y",
+)
+
+// Slugify turns a text into a lowercase, dash separated slug.
+func Slugify(text string) string {
+	text = slugReplacer.Replace(strings.ToLower(text))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range text {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	return b.String()
+}
+
+func ParseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(DateLayout, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func (f Field) Validate(values []string) []string {
+	var errs []string
+
+	filled := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			filled = append(filled, v)
+		}
+	}
+
+	if f.IsRequired && len(filled) == 0 {
+		return []string{"is required"}
+	}
+
+	if !f.AcceptsMany() && len(filled) > 1 {
+		errs = append(errs, "allows only one value")
+	}
+
+	if f.AcceptsMany() {
+		if f.MinItems != nil && len(filled) < *f.MinItems {
+			errs = append(errs, fmt.Sprintf("needs at least %d items", *f.MinItems))
+		}
+		if f.MaxItems != nil && len(filled) > *f.MaxItems {
+			errs = append(errs, fmt.Sprintf("allows at most %d items", *f.MaxItems))
+		}
+	}
+
+	for _, v := range filled {
+		for _, e := range f.validateValue(v) {
+			if !slices.Contains(errs, e) {
+				errs = append(errs, e)
+			}
+		}
+	}
+
+	return errs
+}
+
+func (f Field) validateValue(value string) []string {
+	var errs []string
+
+	switch f.FieldType {
+	case FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || !IsFinite(n) {
+			return []string{"must be a number"}
+		}
+		if f.MinValue != nil && n < *f.MinValue {
+			errs = append(errs, fmt.Sprintf("must be at least %v", *f.MinValue))
+		}
+		if f.MaxValue != nil && n > *f.MaxValue {
+			errs = append(errs, fmt.Sprintf("must be at most %v", *f.MaxValue))
+		}
+
+	case FieldTypeDate:
+		d, err := ParseDate(value)
+		if err != nil {
+			return []string{"must be a date (YYYY-MM-DD)"}
+		}
+		day := d.Format(DateLayout)
+		if f.MinDate != "" && day < f.MinDate {
+			errs = append(errs, fmt.Sprintf("must be on or after %s", f.MinDate))
+		}
+		if f.MaxDate != "" && day > f.MaxDate {
+			errs = append(errs, fmt.Sprintf("must be on or before %s", f.MaxDate))
+		}
+
+	case FieldTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return []string{"must be valid JSON"}
+		}
+
+	case FieldTypeURL:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return []string{"must be an http or https URL"}
+		}
+
+	case FieldTypeEmail:
+		addr, err := mail.ParseAddress(value)
+		if err != nil || addr.Address != value {
+			return []string{"must be an email address"}
+		}
+
+	case FieldTypeColor:
+		if !colorPattern.MatchString(value) {
+			return []string{"must be a hex color like #1a2b3c"}
+		}
+
+	case FieldTypeSlug:
+		if !slugPattern.MatchString(value) {
+			return []string{"must only contain lowercase letters, digits and dashes"}
+		}
+
+	case FieldTypeGeoPoint:
+		if _, _, err := ParseGeoPoint(value); err != nil {
+			return []string{err.Error()}
+		}
+
+	case FieldTypeBlocks:
+		if _, err := ParseBlock(value); err != nil {
+			return []string{err.Error()}
+		}
+	}
+
+	switch f.FieldType {
+	case FieldTypeText, FieldTypeTextarea, FieldTypeURL, FieldTypeEmail, FieldTypeSlug:
+		length := len([]rune(value))
+		if f.MinLength != nil && length < *f.MinLength {
+			errs = append(errs, fmt.Sprintf("must be at least %d characters", *f.MinLength))
+		}
+		if f.MaxLength != nil && length > *f.MaxLength {
+			errs = append(errs, fmt.Sprintf("must be at most %d characters", *f.MaxLength))
+		}
+		if f.Pattern != "" {
+			re, err := regexp.Compile(f.Pattern)
+			if err == nil && !re.MatchString(value) {
+				errs = append(errs, fmt.Sprintf("must match pattern %s", f.Pattern))
+			}
+		}
+	}
+
+	if f.HasOptions() {
+		options := f.OptionValues()
+		if len(options) == 0 {
+			errs = append(errs, "has no options to pick from")
+		} else if !slices.Contains(options, value) {
+			errs = append(errs, fmt.Sprintf("must be one of the options: %s", strings.Join(options, ", ")))
+		}
+	}
+
+	if f.supportsAllowedValues() {
+		if allowed := f.AllowedValueList(); len(allowed) > 0 && !slices.Contains(allowed, value) {
+			errs = append(errs, fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")))
+		}
+	}
+
+	return errs
+}
+
+func (f Field) supportsAllowedValues() bool {
+	switch f.FieldType {
+	case FieldTypeText, FieldTypeTextarea, FieldTypeNumber:
+		return true
+	default:
+		return false
+	}
+}
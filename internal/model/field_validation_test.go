@@ -0,0 +1,175 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestField_Validate_Required(t *testing.T) {
+	f := Field{FieldType: FieldTypeText, IsRequired: true}
+	assert.Equal(t, []string{"is required"}, f.Validate(nil))
+	assert.Equal(t, []string{"is required"}, f.Validate([]string{" "}))
+	assert.Empty(t, f.Validate([]string{"x"}))
+}
+
+func TestField_Validate_SingleValue(t *testing.T) {
+	f := Field{FieldType: FieldTypeText}
+	assert.Equal(t, []string{"allows only one value"}, f.Validate([]string{"a", "b"}))
+}
+
+func TestField_Validate_Number(t *testing.T) {
+	f := Field{FieldType: FieldTypeNumber, MinValue: floatPtr(1), MaxValue: floatPtr(10)}
+	assert.Empty(t, f.Validate([]string{"5.5"}))
+	assert.Equal(t, []string{"must be a number"}, f.Validate([]string{"abc"}))
+	assert.Equal(t, []string{"must be at least 1"}, f.Validate([]string{"0"}))
+	assert.Equal(t, []string{"must be at most 10"}, f.Validate([]string{"11"}))
+	assert.Empty(t, f.Validate([]string{""}))
+
+	unbounded := Field{FieldType: FieldTypeNumber}
+	for _, v := range []string{"NaN", "Inf", "-Infinity", "1e400"} {
+		assert.Equal(t, []string{"must be a number"}, unbounded.Validate([]string{v}), v)
+	}
+}
+
+func TestField_Validate_Text(t *testing.T) {
+	f := Field{FieldType: FieldTypeText, MinLength: intPtr(2), MaxLength: intPtr(4), Pattern: "^[a-z]+$"}
+	assert.Empty(t, f.Validate([]string{"abc"}))
+	assert.Equal(t, []string{"must be at least 2 characters"}, f.Validate([]string{"a"}))
+	assert.Equal(t, []string{"must be at most 4 characters"}, f.Validate([]string{"abcde"}))
+	assert.Equal(t, []string{"must match pattern ^[a-z]+$"}, f.Validate([]string{"AB"}))
+}
+
+func TestField_Validate_Date(t *testing.T) {
+	f := Field{FieldType: FieldTypeDate, MinDate: "2024-01-01", MaxDate: "2024-12-31"}
+	assert.Empty(t, f.Validate([]string{"2024-06-01"}))
+	assert.Empty(t, f.Validate([]string{"2024-06-01T10:00:00Z"}))
+	assert.Equal(t, []string{"must be a date (YYYY-MM-DD)"}, f.Validate([]string{"garbage"}))
+	assert.Equal(t, []string{"must be on or after 2024-01-01"}, f.Validate([]string{"2023-12-31"}))
+	assert.Equal(t, []string{"must be on or before 2024-12-31"}, f.Validate([]string{"2025-01-01"}))
+}
+
+func TestField_Validate_ListItems(t *testing.T) {
+	f := Field{FieldType: FieldTypeText, IsList: true, MinItems: intPtr(2), MaxItems: intPtr(3)}
+	assert.Empty(t, f.Validate([]string{"a", "b"}))
+	assert.Equal(t, []string{"needs at least 2 items"}, f.Validate([]string{"a"}))
+	assert.Equal(t, []string{"allows at most 3 items"}, f.Validate([]string{"a", "b", "c", "d"}))
+}
+
+func TestField_Validate_AllowedValues(t *testing.T) {
+	f := Field{FieldType: FieldTypeText, IsList: true, AllowedValues: "red\ngreen\n\n blue "}
+	assert.Equal(t, []string{"red", "green", "blue"}, f.AllowedValueList())
+	assert.Empty(t, f.Validate([]string{"red", "blue"}))
+	assert.Equal(t, []string{"must be one of: red, green, blue"}, f.Validate([]string{"pink", "black"}))
+
+	asset := Field{FieldType: FieldTypeAsset, AllowedValues: "1"}
+	assert.Empty(t, asset.Validate([]string{"2"}))
+}
+
+func TestField_Validate_Options(t *testing.T) {
+	options := []FieldOption{{Value: "red"}, {Value: "green"}}
+
+	single := Field{FieldType: FieldTypeSelect, Options: options}
+	assert.Empty(t, single.Validate([]string{"red"}))
+	assert.Equal(t, []string{"must be one of the options: red, green"}, single.Validate([]string{"blue"}))
+	assert.Equal(t, []string{"allows only one value"}, single.Validate([]string{"red", "green"}))
+
+	multi := Field{FieldType: FieldTypeMultiSelect, Options: options, MaxItems: intPtr(1)}
+	assert.Empty(t, multi.Validate(nil))
+	assert.Equal(t, []string{"allows at most 1 items"}, multi.Validate([]string{"red", "green"}))
+	multi.MaxItems = nil
+	assert.Empty(t, multi.Validate([]string{"red", "green"}))
+	assert.Equal(t, []string{"must be one of the options: red, green"}, multi.Validate([]string{"red", "blue"}))
+
+	empty := Field{FieldType: FieldTypeMultiSelect}
+	assert.Equal(t, []string{"has no options to pick from"}, empty.Validate([]string{"red"}))
+}
+
+func TestField_Validate_StructuredTypes(t *testing.T) {
+	tests := []struct {
+		fieldType FieldType
+		valid     []string
+		invalid   []string
+		message   string
+	}{
+		{FieldTypeJSON, []string{`{"a":[1,2]}`, `[]`, `"text"`, `42`}, []string{`{a:1}`, `[1,`}, "must be valid JSON"},
+		{FieldTypeURL, []string{"https://example.com/a?b=c", "http://localhost:8080"}, []string{"example.com", "ftp://example.com", "https://"}, "must be an http or https URL"},
+		{FieldTypeEmail, []string{"jane@example.com"}, []string{"jane", "Jane <jane@example.com>", "jane@"}, "must be an email address"},
+		{FieldTypeColor, []string{"#fff", "#1A2b3C"}, []string{"fff", "#ffff", "#12345g", "red"}, "must be a hex color like #1a2b3c"},
+		{FieldTypeSlug, []string{"hello", "hello-world-2"}, []string{"Hello", "hello--world", "-hello", "hello_world"}, "must only contain lowercase letters, digits and dashes"},
+	}
+
+	for _, tt := range tests {
+		f := Field{FieldType: tt.fieldType}
+		for _, v := range tt.valid {
+			assert.Empty(t, f.Validate([]string{v}), "%s %q", tt.fieldType, v)
+		}
+		for _, v := range tt.invalid {
+			assert.Equal(t, []string{tt.message}, f.Validate([]string{v}), "%s %q", tt.fieldType, v)
+		}
+	}
+
+	slug := Field{FieldType: FieldTypeSlug, MaxLength: intPtr(3)}
+	assert.Equal(t, []string{"must be at most 3 characters"}, slug.Validate([]string{"abcd"}))
+}
+
+func TestField_Validate_GeoPoint(t *testing.T) {
+	f := Field{FieldType: FieldTypeGeoPoint}
+	assert.Empty(t, f.Validate([]string{"52.52,13.405"}))
+	assert.Empty(t, f.Validate([]string{"-90, 180"}))
+	assert.Equal(t, []string{"must be latitude,longitude"}, f.Validate([]string{"52.52"}))
+	assert.Equal(t, []string{"must be latitude,longitude"}, f.Validate([]string{"north,east"}))
+	assert.Equal(t, []string{"latitude must be between -90 and 90"}, f.Validate([]string{"90.1,0"}))
+	assert.Equal(t, []string{"longitude must be between -180 and 180"}, f.Validate([]string{"0,-180.5"}))
+	for _, v := range []string{"NaN,0", "0,NaN", "Inf,0", "0,-Inf"} {
+		assert.Equal(t, []string{"must be latitude,longitude"}, f.Validate([]string{v}), v)
+	}
+
+	lat, lng, err := ParseGeoPoint(" 48.2 , 16.37 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 48.2, lat)
+	assert.Equal(t, 16.37, lng)
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "hello-world", Slugify("Hello, World!"))
+	assert.Equal(t, "gruesse-aus-koeln", Slugify("  Grüße aus Köln  "))
+	assert.Equal(t, "creme-brulee-2024", Slugify("Crème brûlée -- 2024"))
+	assert.Equal(t, "", Slugify("!!!"))
+}
+
+func TestParseBlock(t *testing.T) {
+	b, err := ParseBlock(`{"component":"hero"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "hero", b.Component)
+	assert.NotNil(t, b.Values)
+
+	_, err = ParseBlock(`{"values":{}}`)
+	assert.EqualError(t, err, "block needs a component")
+
+	_, err = ParseBlock(`hero`)
+	assert.EqualError(t, err, "must be a block")
+
+	b = Block{Component: "text", Values: map[string][]string{"body": {"Hi"}}}
+	parsed, err := ParseBlock(b.String())
+	assert.NoError(t, err)
+	assert.Equal(t, b, parsed)
+}
+
+func TestField_AllowsTarget(t *testing.T) {
+	any := Field{FieldType: FieldTypeCollection}
+	assert.True(t, any.AllowsTarget(7))
+
+	f := Field{FieldType: FieldTypeCollection, TargetCollections: "2\n5\n"}
+	assert.Equal(t, []uint{2, 5}, f.TargetCollectionIDs())
+	assert.True(t, f.AllowsTarget(5))
+	assert.False(t, f.AllowsTarget(7))
+}
@@ -0,0 +1,7 @@
+package model
+
+type SearchDocument struct {
+	ContentID    uint   `gorm:"primaryKey;autoIncrement:false"`
+	CollectionID uint   `gorm:"not null;index"`
+	Body         string `gorm:"type:text;not null"`
+}
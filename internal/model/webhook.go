@@ -7,9 +7,11 @@ import (
 type EventType string
 
 const (
-	EventContentCreated EventType = "ContentCreated"
-	EventContentUpdated EventType = "ContentUpdated"
-	EventContentDeleted EventType = "ContentDeleted"
+	EventContentCreated     EventType = "ContentCreated"
+	EventContentUpdated     EventType = "ContentUpdated"
+	EventContentDeleted     EventType = "ContentDeleted"
+	EventContentPublished   EventType = "ContentPublished"
+	EventContentUnpublished EventType = "ContentUnpublished"
 )
 
 type RequestType string
@@ -40,5 +42,23 @@ func GetWebhookEvents() []EventType {
 		EventContentCreated,
 		EventContentDeleted,
 		EventContentUpdated,
+		EventContentPublished,
+		EventContentUnpublished,
 	}
 }
+
+func StatusChangeEvent(from, to ContentStatus) (EventType, bool) {
+	if from == to {
+		return "", false
+	}
+
+	if to == ContentStatusPublished {
+		return EventContentPublished, true
+	}
+
+	if from == ContentStatusPublished {
+		return EventContentUnpublished, true
+	}
+
+	return "", false
+}
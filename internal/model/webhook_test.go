@@ -15,10 +15,12 @@ func TestGetRequestTypes(t *testing.T) {
 
 func TestGetWebhookEvents(t *testing.T) {
 	events := GetWebhookEvents()
-	assert.Len(t, events, 3)
+	assert.Len(t, events, 5)
 	assert.Contains(t, events, EventContentCreated)
 	assert.Contains(t, events, EventContentUpdated)
 	assert.Contains(t, events, EventContentDeleted)
+	assert.Contains(t, events, EventContentPublished)
+	assert.Contains(t, events, EventContentUnpublished)
 }
 
 func TestRequestTypeConstants(t *testing.T) {
@@ -38,3 +40,19 @@ func TestEventTypeConstants(t *testing.T) {
 	et = EventContentDeleted
 	assert.Equal(t, "ContentDeleted", string(et))
 }
+
+func TestStatusChangeEvent(t *testing.T) {
+	event, ok := StatusChangeEvent(ContentStatusDraft, ContentStatusPublished)
+	assert.True(t, ok)
+	assert.Equal(t, EventContentPublished, event)
+
+	event, ok = StatusChangeEvent(ContentStatusPublished, ContentStatusArchived)
+	assert.True(t, ok)
+	assert.Equal(t, EventContentUnpublished, event)
+
+	_, ok = StatusChangeEvent(ContentStatusDraft, ContentStatusArchived)
+	assert.False(t, ok)
+
+	_, ok = StatusChangeEvent(ContentStatusPublished, ContentStatusPublished)
+	assert.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+)
+
+const (
+	cacheControlPublic  = "public, no-cache"
+	cacheControlPrivate = "private, no-store"
+)
+
+type cacheValidators struct {
+	etag         string
+	lastModified time.Time
+}
+
+func hashItem(h hash.Hash, item dto.ContentItemResponse) {
+	fmt.Fprintf(h, "c%d:%d:%s;", item.ID, item.UpdatedAt.UnixNano(), item.Status)
+
+	aliases := make([]string, 0, len(item.Values))
+	for alias := range item.Values {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		fmt.Fprintf(h, "f%s;", alias)
+		switch v := item.Values[alias].(type) {
+		case dto.ContentValueResponse:
+			hashValue(h, v)
+		case []any:
+			for _, entry := range v {
+				if cvr, ok := entry.(dto.ContentValueResponse); ok {
+					hashValue(h, cvr)
+				}
+			}
+		}
+	}
+}
+
+func hashValue(h hash.Hash, v dto.ContentValueResponse) {
+	fmt.Fprintf(h, "v%d;", v.ID)
+	if v.Asset != nil {
+		fmt.Fprintf(h, "a%d:%s:%s;", v.Asset.ID, v.Asset.Name, v.Asset.Path)
+	}
+	if v.Content != nil {
+		hashItem(h, *v.Content)
+	}
+}
+
+func newCacheValidators(r *http.Request, q dto.ApiQuery, total int64, collectionModified time.Time, items ...dto.ContentItemResponse) cacheValidators {
+	h := sha256.New()
+	fmt.Fprintf(h, "q%s;v%d;p%t;t%d;m%d;", r.URL.Query().Encode(), q.Version, q.Preview, total, collectionModified.UnixNano())
+
+	lastModified := collectionModified
+	for _, item := range items {
+		hashItem(h, item)
+		if item.LastModified.After(lastModified) {
+			lastModified = item.LastModified
+		}
+	}
+
+	return cacheValidators{
+		etag:         `W/"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`,
+		lastModified: lastModified,
+	}
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c cacheValidators) notModified(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		return etagMatches(header, c.etag)
+	}
+
+	if c.lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !c.lastModified.Truncate(time.Second).After(since)
+}
+
+func writeCachedJSON(w http.ResponseWriter, r *http.Request, q dto.ApiQuery, c cacheValidators, payload any) {
+	header := w.Header()
+	header.Set("ETag", c.etag)
+	header.Set("Vary", "X-API-Key, X-API-Version")
+	if !c.lastModified.IsZero() {
+		header.Set("Last-Modified", c.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if q.Preview {
+		header.Set("Cache-Control", cacheControlPrivate)
+	} else {
+		header.Set("Cache-Control", cacheControlPublic)
+	}
+
+	if c.notModified(r) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
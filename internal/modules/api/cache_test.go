@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func cachedItem(valueID uint) dto.ContentItemResponse {
+	updated := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	return dto.ContentItemResponse{
+		ID:           1,
+		UpdatedAt:    updated,
+		LastModified: updated.Add(time.Minute),
+		Values: map[string]any{
+			"title": dto.ContentValueResponse{ID: valueID, Value: "Hello"},
+		},
+	}
+}
+
+func Test_findContentById_cacheHeaders(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(10), nil)
+
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/content/1", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Equal(t, "Thu, 01 May 2025 10:01:00 GMT", rec.Header().Get("Last-Modified"))
+	assert.Equal(t, cacheControlPublic, rec.Header().Get("Cache-Control"))
+	assert.Contains(t, rec.Header().Get("Vary"), "X-API-Key")
+}
+
+func Test_findContentById_ifNoneMatch(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(10), nil).Once()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/content/1", nil))
+	etag := rec.Header().Get("ETag")
+
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(10), nil).Once()
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(11), nil).Once()
+	req = httptest.NewRequest(http.MethodGet, "/api/content/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func Test_findContentById_etagDependsOnQuery(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(10), nil)
+
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/content/1", nil))
+	legacy := rec.Header().Get("ETag")
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/content/1?version=2", nil))
+
+	assert.NotEqual(t, legacy, rec.Header().Get("ETag"))
+}
+
+func Test_findContentById_ifModifiedSince(t *testing.T) {
+	srv, _, mockApi, _ := setupTestServer()
+	mockApi.On("FindContentByID", uint(1), mock.Anything).Return(cachedItem(10), nil)
+
+	for since, status := range map[string]int{
+		"Thu, 01 May 2025 10:01:00 GMT": http.StatusNotModified,
+		"Thu, 01 May 2025 11:00:00 GMT": http.StatusNotModified,
+		"Thu, 01 May 2025 10:00:59 GMT": http.StatusOK,
+		"not a date":                    http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/content/1", nil)
+		req.Header.Set("If-Modified-Since", since)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, status, rec.Code, since)
+	}
+}
+
+func Test_listContents_ifNoneMatchUsesCollectionTimestamp(t *testing.T) {
+	srv, _, mockApi, _ := setupTestServer()
+	mockApi.ExpectedCalls = nil
+
+	items := []dto.ContentItemResponse{cachedItem(10)}
+	mockApi.On("FindContentByCollectionAlias", "blog", 0, 100, mock.Anything).Return(items, int64(1), nil)
+	mockApi.On("CollectionLastModified", "blog").Return(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), nil).Once()
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/collections/blog/content", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Sun, 01 Jun 2025 00:00:00 GMT", rec.Header().Get("Last-Modified"))
+	etag := rec.Header().Get("ETag")
+
+	mockApi.On("CollectionLastModified", "blog").Return(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), nil).Once()
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/blog/content", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "a deletion elsewhere in the collection must invalidate the list")
+}
+
+func Test_listContents_previewIsNotStored(t *testing.T) {
+	srv, rec, mockApi, mockApikey := setupTestServer()
+	mockApikey.On("FindByToken", "key").Return(&model.Apikey{CanPreview: true}, nil)
+	mockApi.On("FindContentByCollectionAlias", "blog", 0, 100, mock.Anything).Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/blog/content?preview=true", nil)
+	req.Header.Set("X-API-Key", "key")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheControlPrivate, rec.Header().Get("Cache-Control"))
+}
@@ -2,23 +2,35 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/middleware"
+	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/server"
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/internal/utils"
 )
 
+const defaultPerPage = 100
+
 type Controller struct {
-	services *service.Set
+	services   *service.Set
+	maxPerPage int
 }
 
-func NewController(services *service.Set) *Controller {
-	return &Controller{services: services}
+func NewController(services *service.Set, maxPerPage int) *Controller {
+	if maxPerPage <= 0 {
+		maxPerPage = defaultPerPage
+	}
+	return &Controller{services: services, maxPerPage: maxPerPage}
 }
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
@@ -27,6 +39,76 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 	json.NewEncoder(w).Encode(payload)
 }
 
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, dto.ApiResponse{
+		Success: false,
+		Error: &dto.ErrorDetail{
+			Code:    code,
+			Message: message,
+		},
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+	})
+}
+
+func writeWriteError(w http.ResponseWriter, err error) {
+	verrs, ok := service.AsValidationErrors(err)
+	if !ok {
+		writeServiceError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusUnprocessableEntity, dto.ApiResponse{
+		Success: false,
+		Error: &dto.ErrorDetail{
+			Code:    "validation_failed",
+			Message: "one or more fields are invalid",
+			Fields:  verrs,
+		},
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+	})
+}
+
+func writeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidFilter):
+		writeError(w, http.StatusBadRequest, "invalid_filter", err.Error())
+	case errors.Is(err, service.ErrInvalidSort):
+		writeError(w, http.StatusBadRequest, "invalid_sort", err.Error())
+	case errors.Is(err, service.ErrInvalidCursor):
+		writeError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+	case errors.Is(err, service.ErrInvalidSyncToken):
+		writeError(w, http.StatusBadRequest, "invalid_token", err.Error())
+	case errors.Is(err, service.ErrMissingSearchQuery):
+		writeError(w, http.StatusBadRequest, "missing_query", err.Error())
+	case errors.Is(err, service.ErrCollectionNotFound):
+		writeError(w, http.StatusNotFound, "collection_not_found", "collection not found")
+	case errors.Is(err, service.ErrContentNotFound):
+		writeError(w, http.StatusNotFound, "content_not_found", "content not found")
+	default:
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+func decodeWriteRequest(r *http.Request) (dto.ContentWriteRequest, error) {
+	var body dto.ContentWriteRequest
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return body, err
+	}
+
+	if body.Values == nil && body.Translations == nil {
+		return body, errors.New("missing values")
+	}
+
+	return body, nil
+}
+
 func (ct Controller) RegisterRoutes(s *server.Server) {
 	s.Handle("GET /api/collections/{alias}/content", ct.listContents,
 		middleware.ApikeyAuth(ct.services.Apikey),
@@ -39,15 +121,264 @@ func (ct Controller) RegisterRoutes(s *server.Server) {
 	s.Handle("GET /api/collections/{alias}/content/filter", ct.listContentsByFieldValue,
 		middleware.ApikeyAuth(ct.services.Apikey),
 	)
+
+	s.Handle("POST /api/collections/{alias}/content", ct.createContent,
+		middleware.ApikeyAuth(ct.services.Apikey),
+		middleware.ApikeyWrite(ct.services.Apikey),
+	)
+
+	s.Handle("PUT /api/content/{id}", ct.replaceContent,
+		middleware.ApikeyAuth(ct.services.Apikey),
+		middleware.ApikeyWrite(ct.services.Apikey),
+	)
+
+	s.Handle("PATCH /api/content/{id}", ct.patchContent,
+		middleware.ApikeyAuth(ct.services.Apikey),
+		middleware.ApikeyWrite(ct.services.Apikey),
+	)
+
+	s.Handle("DELETE /api/content/{id}", ct.deleteContent,
+		middleware.ApikeyAuth(ct.services.Apikey),
+		middleware.ApikeyWrite(ct.services.Apikey),
+	)
+
+	s.Handle("GET /api/sync", ct.sync,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+
+	s.Handle("GET /api/search", ct.search,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+
+	s.Handle("GET /api/events", ct.events,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+
+	s.Handle("GET /api/openapi.json", ct.openapi,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+
+	s.Handle("GET /api/graphql", ct.graphql,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+
+	s.Handle("POST /api/graphql", ct.graphql,
+		middleware.ApikeyAuth(ct.services.Apikey),
+	)
+}
+
+func parseApiVersion(r *http.Request) (int, error) {
+	raw := r.Header.Get("X-API-Version")
+	if v := r.URL.Query().Get("version"); v != "" {
+		raw = v
+	}
+
+	if raw == "" {
+		return dto.ApiVersionLegacy, nil
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < dto.ApiVersionLegacy || version > dto.ApiVersionLatest {
+		return 0, fmt.Errorf("unsupported api version %q", raw)
+	}
+
+	return version, nil
+}
+
+func parseDepth(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("depth")
+	if raw == "" {
+		return 0, nil
+	}
+
+	depth, err := strconv.Atoi(raw)
+	if err != nil || depth < 0 || depth > dto.MaxIncludeDepth {
+		return 0, fmt.Errorf("depth must be a number between 0 and %d", dto.MaxIncludeDepth)
+	}
+
+	return depth, nil
+}
+
+func parseInclude(r *http.Request) ([]string, error) {
+	raw := r.URL.Query().Get("include")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var paths []string
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+			return nil, fmt.Errorf("invalid include path %q", path)
+		}
+		if strings.Count(path, ".")+1 > dto.MaxIncludeDepth {
+			return nil, fmt.Errorf("include path %q is nested deeper than %d", path, dto.MaxIncludeDepth)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+var filterKey = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+func parseFilter(r *http.Request) (*dto.ContentFilter, error) {
+	query := r.URL.Query()
+
+	var filter dto.ContentFilter
+	switch strings.ToLower(query.Get("match")) {
+	case "", "and":
+	case "or":
+		filter.Or = true
+	default:
+		return nil, errors.New(`match must be "and" or "or"`)
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		parts := filterKey.FindStringSubmatch(key)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid filter parameter %q", key)
+		}
+
+		op := parts[2]
+		if op == "" {
+			op = "eq"
+		}
+
+		for _, raw := range query[key] {
+			values := []string{raw}
+			if op == "in" || op == "nin" {
+				values = nil
+				for _, v := range strings.Split(raw, ",") {
+					values = append(values, strings.TrimSpace(v))
+				}
+			}
+
+			filter.Conditions = append(filter.Conditions, dto.FilterCondition{
+				Field:  parts[1],
+				Op:     op,
+				Values: values,
+			})
+		}
+	}
+
+	if len(filter.Conditions) == 0 {
+		return nil, nil
+	}
+
+	return &filter, nil
+}
+
+func parseFields(r *http.Request) ([]string, error) {
+	if !r.URL.Query().Has("fields") {
+		return nil, nil
+	}
+
+	fields := []string{}
+	for _, alias := range strings.Split(r.URL.Query().Get("fields"), ",") {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return nil, errors.New("fields must be a comma separated list of field aliases")
+		}
+		fields = append(fields, alias)
+	}
+
+	return fields, nil
+}
+
+func (ct Controller) parseApiQuery(ctx server.Context) (dto.ApiQuery, bool) {
+	var q dto.ApiQuery
+
+	version, err := parseApiVersion(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_version", err.Error())
+		return q, false
+	}
+	q.Version = version
+
+	depth, err := parseDepth(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_depth", err.Error())
+		return q, false
+	}
+	q.Depth = depth
+
+	include, err := parseInclude(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_include", err.Error())
+		return q, false
+	}
+	q.Include = include
+
+	filter, err := parseFilter(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_filter", err.Error())
+		return q, false
+	}
+	q.Filter = filter
+	q.Sort = strings.TrimSpace(ctx.Request.URL.Query().Get("sort"))
+
+	fields, err := parseFields(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_fields", err.Error())
+		return q, false
+	}
+	q.Fields = fields
+
+	locales, err := ct.services.Locales.Chain(ctx.Request.URL.Query().Get("locale"))
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_locale", err.Error())
+		return q, false
+	}
+	q.Locales = locales
+
+	if ctx.Request.URL.Query().Get("preview") != "true" {
+		return q, true
+	}
+
+	if !ct.canPreview(ctx.Request) {
+		writeError(ctx.Writer, http.StatusForbidden, "preview_forbidden", "api key is not allowed to preview drafts")
+		return q, false
+	}
+
+	q.Preview = true
+	return q, true
+}
+
+// canPreview reports whether the key of the request may see hidden entries.
+func (ct Controller) canPreview(r *http.Request) bool {
+	key, err := ct.services.Apikey.FindByToken(r.Header.Get("X-API-Key"))
+	return err == nil && key.CanPreview
 }
 
 func (ct Controller) findContentById(ctx server.Context) {
-	idStr := ctx.Request.PathValue("id")
-	id, _ := utils.StringToUint(idStr)
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
 
-	data, _ := ct.services.Api.FindContentByID(id)
+	id, ok := utils.StringToUint(ctx.Request.PathValue("id"))
+	if !ok {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_id", "invalid content id")
+		return
+	}
 
-	writeJSON(ctx.Writer, http.StatusOK, dto.ApiResponse{
+	data, err := ct.services.Api.FindContentByID(id, q)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	cache := newCacheValidators(ctx.Request, q, 1, time.Time{}, data)
+	writeCachedJSON(ctx.Writer, ctx.Request, q, cache, dto.ApiResponse{
 		Data:    data,
 		Success: true,
 		Meta: &dto.MetaData{
@@ -56,38 +387,129 @@ func (ct Controller) findContentById(ctx server.Context) {
 	})
 }
 
+type pageRequest struct {
+	page    int
+	perPage int
+	offset  int
+	cursor  bool
+}
+
+func (ct Controller) parsePageRequest(ctx server.Context, q *dto.ApiQuery) (pageRequest, bool) {
+	query := ctx.Request.URL.Query()
+	p := pageRequest{page: 1, perPage: min(defaultPerPage, ct.maxPerPage)}
+
+	if raw := query.Get("page"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			p.page = parsed
+		}
+	}
+
+	if raw := query.Get("perPage"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			p.perPage = min(parsed, ct.maxPerPage)
+		}
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		if q.Sort != "" {
+			writeError(ctx.Writer, http.StatusBadRequest, "invalid_cursor", "cursor pagination only supports the default order")
+			return p, false
+		}
+
+		after, err := service.DecodeCursor(cursor)
+		if err != nil {
+			writeError(ctx.Writer, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return p, false
+		}
+
+		q.After = after
+		p.cursor = true
+		p.page = 0
+		return p, true
+	}
+
+	p.offset = (p.page - 1) * p.perPage
+	return p, true
+}
+
+func pageLink(r *http.Request, key, value string, drop string) string {
+	query := r.URL.Query()
+	query.Set(key, value)
+	query.Del(drop)
+	return r.URL.Path + "?" + query.Encode()
+}
+
+func (p pageRequest) pagination(r *http.Request, q dto.ApiQuery, total int64, items []dto.ContentItemResponse) *dto.Pagination {
+	pagination := &dto.Pagination{
+		Page:       p.page,
+		PerPage:    p.perPage,
+		Total:      int(total),
+		TotalPages: int(utils.CalcTotalPages(total, p.perPage)),
+	}
+
+	if q.Sort == "" && len(items) > 0 && len(items) == p.perPage {
+		pagination.NextCursor = service.EncodeCursor(items[len(items)-1].ID)
+	}
+
+	if p.cursor {
+		if pagination.NextCursor != "" {
+			pagination.Next = pageLink(r, "cursor", pagination.NextCursor, "page")
+		}
+		return pagination
+	}
+
+	if p.page < pagination.TotalPages {
+		pagination.Next = pageLink(r, "page", strconv.Itoa(p.page+1), "cursor")
+	}
+	if p.page > 1 {
+		pagination.Prev = pageLink(r, "page", strconv.Itoa(p.page-1), "cursor")
+	}
+
+	return pagination
+}
+
 func (ct Controller) listContents(ctx server.Context) {
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
+
+	p, ok := ct.parsePageRequest(ctx, &q)
+	if !ok {
+		return
+	}
+
 	alias := ctx.Request.PathValue("alias")
 
-	page := 1
-	if p := ctx.Request.URL.Query().Get("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
+	data, total, err := ct.services.Api.FindContentByCollectionAlias(alias, p.offset, p.perPage, q)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
 	}
-	const perPage = 100
-	offset := (page - 1) * perPage
 
-	data, err := ct.services.Api.FindContentByCollectionAlias(alias, offset, perPage)
+	modified, err := ct.services.Api.CollectionLastModified(alias)
 	if err != nil {
-		writeJSON(ctx.Writer, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		writeServiceError(ctx.Writer, err)
 		return
 	}
 
-	writeJSON(ctx.Writer, http.StatusOK, dto.ApiResponse{
+	cache := newCacheValidators(ctx.Request, q, total, modified, data...)
+	writeCachedJSON(ctx.Writer, ctx.Request, q, cache, dto.ApiResponse{
 		Data:    data,
 		Success: true,
 		Meta: &dto.MetaData{
 			Timestamp: time.Now().UTC(),
 		},
-		Pagination: &dto.Pagination{
-			PerPage: perPage,
-			Page:    page,
-		},
+		Pagination: p.pagination(ctx.Request, q, total, data),
 	})
 }
 
 func (ct Controller) listContentsByFieldValue(ctx server.Context) {
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
+
 	req := ctx.Request
 	w := ctx.Writer
 
@@ -95,34 +517,258 @@ func (ct Controller) listContentsByFieldValue(ctx server.Context) {
 
 	fieldAlias := req.URL.Query().Get("field")
 	value := req.URL.Query().Get("value")
-	if fieldAlias == "" || value == "" {
-		writeJSON(w, http.StatusBadRequest, dto.ApiResponse{
-			Success: false,
-			Meta:    &dto.MetaData{Timestamp: time.Now().UTC()},
-		})
+	if (fieldAlias == "" || value == "") && q.Filter == nil {
+		writeError(w, http.StatusBadRequest, "missing_filter", "field and value or at least one filter parameter are required")
 		return
 	}
 
-	page := 1
-	if p := req.URL.Query().Get("page"); p != "" {
-		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
-			page = parsed
-		}
+	p, ok := ct.parsePageRequest(ctx, &q)
+	if !ok {
+		return
 	}
-	const perPage = 100
-	offset := (page - 1) * perPage
 
-	items, _ := ct.services.Api.FindContentByCollectionAndFieldValue(alias, fieldAlias, value, offset, perPage)
+	var items []dto.ContentItemResponse
+	var total int64
+	var err error
+	if fieldAlias != "" && value != "" {
+		items, total, err = ct.services.Api.FindContentByCollectionAndFieldValue(alias, fieldAlias, value, p.offset, p.perPage, q)
+	} else {
+		items, total, err = ct.services.Api.FindContentByCollectionAlias(alias, p.offset, p.perPage, q)
+	}
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
 
-	writeJSON(w, http.StatusOK, dto.ApiResponse{
+	modified, err := ct.services.Api.CollectionLastModified(alias)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	cache := newCacheValidators(req, q, total, modified, items...)
+	writeCachedJSON(w, req, q, cache, dto.ApiResponse{
 		Data:    items,
 		Success: true,
 		Meta: &dto.MetaData{
 			Timestamp: time.Now().UTC(),
 		},
-		Pagination: &dto.Pagination{
-			PerPage: perPage,
-			Page:    page,
+		Pagination: p.pagination(req, q, total, items),
+	})
+}
+
+func (ct Controller) createContent(ctx server.Context) {
+	version, err := parseApiVersion(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_version", err.Error())
+		return
+	}
+
+	alias := ctx.Request.PathValue("alias")
+
+	collection, err := ct.services.Collection.FindByAlias(alias)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+	if collection.IsComponent {
+		writeError(ctx.Writer, http.StatusNotFound, "collection_not_found", "collection not found")
+		return
+	}
+
+	body, err := decodeWriteRequest(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	formData, err := valuesToFormData(collection.Fields, body.Values)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_values", err.Error())
+		return
+	}
+
+	translations, err := translationsToFormData(collection.Fields, ct.services.Locales, body.Translations)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_values", err.Error())
+		return
+	}
+
+	content, err := ct.services.Content.CreateWithValues(dto.ContentWithValues{
+		CollectionID: collection.ID,
+		Status:       model.ContentStatus(body.Status),
+		Schedule:     &dto.ContentSchedule{PublishAt: body.PublishAt, UnpublishAt: body.UnpublishAt},
+		FormData:     formData,
+		Translations: translations,
+	})
+	if err != nil {
+		writeWriteError(ctx.Writer, err)
+		return
+	}
+
+	ct.services.Webhook.Dispatch(string(model.EventContentCreated), nil)
+	ct.services.Events.Publish(service.EventCreated, content.ID, collection.ID)
+	if event, ok := model.StatusChangeEvent(model.ContentStatusDraft, content.Status); ok {
+		ct.services.Webhook.Dispatch(string(event), nil)
+	}
+
+	ct.writeContent(ctx, http.StatusCreated, content, version)
+}
+
+func (ct Controller) replaceContent(ctx server.Context) {
+	ct.updateContent(ctx, false)
+}
+
+func (ct Controller) patchContent(ctx server.Context) {
+	ct.updateContent(ctx, true)
+}
+
+func (ct Controller) updateContent(ctx server.Context, partial bool) {
+	version, err := parseApiVersion(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_version", err.Error())
+		return
+	}
+
+	id, ok := utils.StringToUint(ctx.Request.PathValue("id"))
+	if !ok {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_id", "invalid content id")
+		return
+	}
+
+	content, err := ct.services.Content.FindByID(id)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	body, err := decodeWriteRequest(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	fields, err := ct.services.Field.FindByCollectionID(content.CollectionID)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	formData, err := valuesToFormData(fields, body.Values)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_values", err.Error())
+		return
+	}
+
+	translations, err := translationsToFormData(fields, ct.services.Locales, body.Translations)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_values", err.Error())
+		return
+	}
+
+	schedule := &dto.ContentSchedule{PublishAt: body.PublishAt, UnpublishAt: body.UnpublishAt}
+	if partial {
+		merged := contentToFormData(content)
+		for alias, values := range formData {
+			merged[alias] = values
+		}
+		formData = merged
+
+		if translations != nil {
+			stored := contentToTranslations(content)
+			for locale, data := range translations {
+				if stored[locale] == nil {
+					stored[locale] = map[string][]string{}
+				}
+				for alias, values := range data {
+					stored[locale][alias] = values
+				}
+			}
+			translations = stored
+		}
+
+		if schedule.PublishAt == nil {
+			schedule.PublishAt = content.PublishAt
+		}
+		if schedule.UnpublishAt == nil {
+			schedule.UnpublishAt = content.UnpublishAt
+		}
+	}
+
+	previousStatus := content.Status
+	updated, err := ct.services.Content.EditWithValues(dto.ContentWithValues{
+		CollectionID: content.CollectionID,
+		ContentID:    content.ID,
+		Status:       model.ContentStatus(body.Status),
+		Schedule:     schedule,
+		FormData:     formData,
+		Translations: translations,
+	})
+	if err != nil {
+		writeWriteError(ctx.Writer, err)
+		return
+	}
+
+	ct.services.Webhook.Dispatch(string(model.EventContentUpdated), nil)
+	ct.services.Events.Publish(service.EventUpdated, content.ID, content.CollectionID)
+	if event, ok := model.StatusChangeEvent(previousStatus, updated.Status); ok {
+		ct.services.Webhook.Dispatch(string(event), nil)
+	}
+
+	content.Status = updated.Status
+	ct.writeContent(ctx, http.StatusOK, content, version)
+}
+
+func (ct Controller) deleteContent(ctx server.Context) {
+	id, ok := utils.StringToUint(ctx.Request.PathValue("id"))
+	if !ok {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_id", "invalid content id")
+		return
+	}
+
+	content, err := ct.services.Content.FindByID(id)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	if err := ct.services.Content.DeleteByID(id); err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	ct.services.Webhook.Dispatch(string(model.EventContentDeleted), nil)
+	ct.services.Events.Publish(service.EventDeleted, content.ID, content.CollectionID)
+
+	writeJSON(ctx.Writer, http.StatusOK, dto.ApiResponse{
+		Success: true,
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+	})
+}
+
+// writeContent answers a write with the entry as the key would read it. Keys
+// that may not see the entry, e.g. a draft without preview, only get its ID,
+// status and collection back.
+func (ct Controller) writeContent(ctx server.Context, status int, content *model.Content, version int) {
+	data, err := ct.services.Api.FindContentByID(content.ID, dto.ApiQuery{Preview: ct.canPreview(ctx.Request), Version: version})
+	if errors.Is(err, service.ErrContentNotFound) {
+		data = dto.ContentItemResponse{
+			ID:         content.ID,
+			Status:     string(content.Status),
+			Collection: dto.CollectionResponse{ID: content.CollectionID},
+		}
+	} else if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	writeJSON(ctx.Writer, status, dto.ApiResponse{
+		Data:    data,
+		Success: true,
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
 		},
 	})
 }
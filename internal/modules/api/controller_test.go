@@ -2,14 +2,22 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/server"
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.MockApiService, *testutils.MockApikeyService) {
@@ -17,6 +25,7 @@ func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.M
 	rec := httptest.NewRecorder()
 
 	mockApi := &testutils.MockApiService{}
+	mockApi.On("CollectionLastModified", mock.Anything).Return(time.Time{}, nil).Maybe()
 	mockApikey := &testutils.MockApikeyService{}
 
 	services := &service.Set{
@@ -24,7 +33,7 @@ func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.M
 		Apikey: mockApikey,
 	}
 
-	ctrl := NewController(services)
+	ctrl := NewController(services, 0)
 
 	srv.Handle("GET /api/collections/{alias}/content", ctrl.listContents)
 	srv.Handle("GET /api/content/{id}", ctrl.findContentById)
@@ -35,7 +44,7 @@ func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.M
 
 func Test_RegisterRoutes(t *testing.T) {
 	services := &service.Set{}
-	ctrl := NewController(services)
+	ctrl := NewController(services, 0)
 
 	srv := server.NewServer()
 	rec := httptest.NewRecorder()
@@ -53,7 +62,7 @@ func Test_findContentById(t *testing.T) {
 	srv, rec, mockApi, _ := setupTestServer()
 
 	mockApi.
-		On("FindContentByID", uint(1)).
+		On("FindContentByID", uint(1), dto.ApiQuery{Version: dto.ApiVersionLegacy}).
 		Return(dto.ContentItemResponse{
 			ID: 1,
 			Collection: dto.CollectionResponse{
@@ -96,12 +105,41 @@ func Test_findContentById(t *testing.T) {
 	}
 }
 
+func Test_listContents_previewForbidden(t *testing.T) {
+	srv, rec, _, mockApikey := setupTestServer()
+
+	mockApikey.On("FindByToken", "key").Return(&model.Apikey{CanPreview: false}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?preview=true", nil)
+	req.Header.Set("X-API-Key", "key")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "preview_forbidden")
+}
+
+func Test_listContents_previewAllowed(t *testing.T) {
+	srv, rec, mockApi, mockApikey := setupTestServer()
+
+	mockApikey.On("FindByToken", "key").Return(&model.Apikey{CanPreview: true}, nil)
+	mockApi.
+		On("FindContentByCollectionAlias", "news", 0, 100, dto.ApiQuery{Preview: true, Version: dto.ApiVersionLegacy}).
+		Return([]dto.ContentItemResponse{{ID: 1}, {ID: 2}}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?preview=true", nil)
+	req.Header.Set("X-API-Key", "key")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
 func Test_listContents(t *testing.T) {
 	srv, rec, mockApi, _ := setupTestServer()
 
 	mockApi.
-		On("FindContentByCollectionAlias", "news", 0, 100).
-		Return([]dto.ContentItemResponse{{ID: 1}}, nil)
+		On("FindContentByCollectionAlias", "news", 0, 100, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return([]dto.ContentItemResponse{{ID: 1}}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content", nil)
 	srv.ServeHTTP(rec, req)
@@ -124,8 +162,8 @@ func Test_listContentsByFieldValue(t *testing.T) {
 	srv, rec, mockApi, _ := setupTestServer()
 
 	mockApi.
-		On("FindContentByCollectionAndFieldValue", "blog", "title", "Golang", 0, 100).
-		Return([]dto.ContentItemResponse{{ID: 42}}, nil)
+		On("FindContentByCollectionAndFieldValue", "blog", "title", "Golang", 0, 100, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return([]dto.ContentItemResponse{{ID: 42}}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/collections/blog/content/filter?field=title&value=Golang", nil)
 	srv.ServeHTTP(rec, req)
@@ -143,3 +181,684 @@ func Test_listContentsByFieldValue(t *testing.T) {
 		t.Errorf("unexpected response data: %+v", resp)
 	}
 }
+
+type writeMocks struct {
+	api        *testutils.MockApiService
+	apikey     *testutils.MockApikeyService
+	content    *testutils.MockContentService
+	collection *testutils.MockCollectionService
+	field      *testutils.MockFieldService
+	webhook    *testutils.MockWebhookService
+	events     *testutils.MockEventService
+}
+
+func setupWriteServer() (*server.Server, *httptest.ResponseRecorder, writeMocks) {
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	mocks := writeMocks{
+		api:        &testutils.MockApiService{},
+		apikey:     &testutils.MockApikeyService{},
+		content:    &testutils.MockContentService{},
+		collection: &testutils.MockCollectionService{},
+		field:      &testutils.MockFieldService{},
+		webhook:    &testutils.MockWebhookService{},
+		events:     &testutils.MockEventService{},
+	}
+	mocks.events.On("Publish", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mocks.apikey.On("FindByToken", "").Return(&model.Apikey{CanPreview: true, CanWrite: true}, nil).Maybe()
+
+	ctrl := NewController(&service.Set{
+		Api:        mocks.api,
+		Apikey:     mocks.apikey,
+		Content:    mocks.content,
+		Collection: mocks.collection,
+		Field:      mocks.field,
+		Webhook:    mocks.webhook,
+		Events:     mocks.events,
+	}, 0)
+
+	srv.Handle("POST /api/collections/{alias}/content", ctrl.createContent)
+	srv.Handle("PUT /api/content/{id}", ctrl.replaceContent)
+	srv.Handle("PATCH /api/content/{id}", ctrl.patchContent)
+	srv.Handle("DELETE /api/content/{id}", ctrl.deleteContent)
+
+	return srv, rec, mocks
+}
+
+func Test_createContent(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	collection := &model.Collection{
+		Model: gorm.Model{ID: 3},
+		Alias: "blog",
+		Fields: []model.Field{
+			{Alias: "title"},
+			{Alias: "tags", IsList: true},
+			{Alias: "price"},
+			{Alias: "active"},
+		},
+	}
+
+	m.collection.On("FindByAlias", "blog").Return(collection, nil)
+	m.content.On("CreateWithValues", dto.ContentWithValues{
+		CollectionID: 3,
+		Schedule:     &dto.ContentSchedule{},
+		FormData: map[string][]string{
+			"title":  {"Hello"},
+			"tags":   {"a", "b"},
+			"price":  {"9.5"},
+			"active": {"on"},
+		},
+	}).Return(&model.Content{Model: gorm.Model{ID: 7}}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentCreated), nil).Return()
+	m.api.On("FindContentByID", uint(7), dto.ApiQuery{Preview: true, Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{ID: 7}, nil)
+
+	body := `{"values":{"title":"Hello","tags":["a","b"],"price":9.5,"active":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(body))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp struct {
+		Success bool                    `json:"success"`
+		Data    dto.ContentItemResponse `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.True(t, resp.Success)
+	assert.Equal(t, uint(7), resp.Data.ID)
+	m.webhook.AssertExpectations(t)
+	m.events.AssertCalled(t, "Publish", service.EventCreated, uint(7), uint(3))
+}
+
+func Test_createContent_unknownCollection(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "nope").Return(nil, service.ErrCollectionNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/nope/content", strings.NewReader(`{"values":{}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	m.content.AssertNotCalled(t, "CreateWithValues", mock.Anything)
+}
+
+func Test_createContent_invalidBody(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(`{"values":`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_body")
+}
+
+func Test_createContent_unknownField(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{Fields: []model.Field{{Alias: "title"}}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(`{"values":{"body":"x"}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_values")
+}
+
+func Test_createContent_structuredValues(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "places").Return(&model.Collection{
+		Model: gorm.Model{ID: 4},
+		Fields: []model.Field{
+			{Alias: "meta", FieldType: model.FieldTypeJSON},
+			{Alias: "raw", FieldType: model.FieldTypeJSON},
+			{Alias: "location", FieldType: model.FieldTypeGeoPoint},
+			{Alias: "stops", FieldType: model.FieldTypeGeoPoint, IsList: true},
+		},
+	}, nil)
+	m.content.On("CreateWithValues", dto.ContentWithValues{
+		CollectionID: 4,
+		Schedule:     &dto.ContentSchedule{},
+		FormData: map[string][]string{
+			"meta":     {`{"n":1,"tags":["a","b"]}`},
+			"raw":      {`[1,2]`},
+			"location": {"52.52,13.405"},
+			"stops":    {"1,2", "3.5,4"},
+		},
+	}).Return(&model.Content{Model: gorm.Model{ID: 8}}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentCreated), nil).Return()
+	m.api.On("FindContentByID", uint(8), mock.Anything).Return(dto.ContentItemResponse{ID: 8}, nil)
+
+	body := `{"values":{"meta":{"tags":["a","b"],"n":1},"raw":"[1,2]","location":{"lat":52.52,"lng":13.405},"stops":[{"lat":1,"lng":2},"3.5,4"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/places/content", strings.NewReader(body))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	m.content.AssertExpectations(t)
+}
+
+func Test_createContent_invalidGeoPoint(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "places").Return(&model.Collection{Fields: []model.Field{{Alias: "location", FieldType: model.FieldTypeGeoPoint}}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/places/content", strings.NewReader(`{"values":{"location":{"lat":"north"}}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_values")
+}
+
+func Test_createContent_blocks(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "pages").Return(&model.Collection{
+		Model:  gorm.Model{ID: 5},
+		Fields: []model.Field{{Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true}},
+	}, nil)
+	m.content.On("CreateWithValues", dto.ContentWithValues{
+		CollectionID: 5,
+		Schedule:     &dto.ContentSchedule{},
+		FormData: map[string][]string{
+			"body": {
+				`{"component":"hero","values":{"count":["3"],"tags":["a","b"],"title":["Hi"]}}`,
+				`{"component":"text","values":{}}`,
+			},
+		},
+	}).Return(&model.Content{Model: gorm.Model{ID: 9}}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentCreated), nil).Return()
+	m.api.On("FindContentByID", uint(9), mock.Anything).Return(dto.ContentItemResponse{ID: 9}, nil)
+
+	body := `{"values":{"body":[{"component":"hero","values":{"title":"Hi","count":3,"tags":["a","b"]}},{"component":"text"}]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/pages/content", strings.NewReader(body))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	m.content.AssertExpectations(t)
+}
+
+func Test_createContent_component(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "hero").Return(&model.Collection{Alias: "hero", IsComponent: true}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/hero/content", strings.NewReader(`{"values":{}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "collection_not_found")
+}
+
+func Test_createContent_serviceError(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{Fields: []model.Field{{Alias: "title"}}}, nil)
+	m.content.On("CreateWithValues", mock.Anything).Return(nil, errors.New("UNIQUE constraint failed: contents.id"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(`{"values":{"title":"x"}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "internal_error")
+	assert.NotContains(t, rec.Body.String(), "UNIQUE constraint")
+	m.webhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_createContent_validationErrors(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{Fields: []model.Field{{Alias: "title"}}}, nil)
+	m.content.On("CreateWithValues", mock.Anything).Return(nil, service.ValidationErrors{
+		"title": {"is required"},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(`{"values":{"title":""}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var resp dto.ApiResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "validation_failed", resp.Error.Code)
+	assert.Equal(t, map[string][]string{"title": {"is required"}}, resp.Error.Fields)
+	m.webhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func existingContent() *model.Content {
+	return &model.Content{
+		Model:        gorm.Model{ID: 5},
+		CollectionID: 2,
+		ContentValues: []model.ContentValue{
+			{SortIndex: 2, Value: "second", Field: model.Field{Alias: "tags"}},
+			{SortIndex: 1, Value: "first", Field: model.Field{Alias: "tags"}},
+			{SortIndex: 1, Value: "Old title", Field: model.Field{Alias: "title"}},
+		},
+	}
+}
+
+func Test_patchContent_mergesValues(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.content.On("FindByID", uint(5)).Return(existingContent(), nil)
+	m.field.On("FindByCollectionID", uint(2)).Return([]model.Field{{Alias: "title"}, {Alias: "tags"}}, nil)
+	m.content.On("EditWithValues", dto.ContentWithValues{
+		CollectionID: 2,
+		ContentID:    5,
+		Schedule:     &dto.ContentSchedule{},
+		FormData: map[string][]string{
+			"title": {"New title"},
+			"tags":  {"first", "second"},
+		},
+	}).Return(&model.Content{}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentUpdated), nil).Return()
+	m.api.On("FindContentByID", uint(5), dto.ApiQuery{Preview: true, Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{ID: 5}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/content/5", strings.NewReader(`{"values":{"title":"New title"}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	m.content.AssertExpectations(t)
+}
+
+func Test_replaceContent_replacesValues(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.content.On("FindByID", uint(5)).Return(existingContent(), nil)
+	m.field.On("FindByCollectionID", uint(2)).Return([]model.Field{{Alias: "title"}, {Alias: "tags"}}, nil)
+	m.content.On("EditWithValues", dto.ContentWithValues{
+		CollectionID: 2,
+		ContentID:    5,
+		Schedule:     &dto.ContentSchedule{},
+		FormData: map[string][]string{
+			"title": {"New title"},
+		},
+	}).Return(&model.Content{}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentUpdated), nil).Return()
+	m.api.On("FindContentByID", uint(5), dto.ApiQuery{Preview: true, Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{ID: 5}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/content/5", strings.NewReader(`{"values":{"title":"New title"}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	m.content.AssertExpectations(t)
+}
+
+func Test_patchContent_hidesDraftFromKeyWithoutPreview(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	draft := existingContent()
+	draft.Status = model.ContentStatusDraft
+	m.apikey.On("FindByToken", "writer").Return(&model.Apikey{CanWrite: true}, nil)
+	m.content.On("FindByID", uint(5)).Return(draft, nil)
+	m.field.On("FindByCollectionID", uint(2)).Return([]model.Field{{Alias: "title"}, {Alias: "tags"}}, nil)
+	m.content.On("EditWithValues", mock.Anything).Return(&model.Content{Status: model.ContentStatusDraft}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentUpdated), nil).Return()
+	m.api.On("FindContentByID", uint(5), dto.ApiQuery{Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{}, service.ErrContentNotFound)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/content/5", strings.NewReader(`{"values":{}}`))
+	req.Header.Set("X-API-Key", "writer")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, float64(5), resp.Data["id"])
+	assert.Equal(t, string(model.ContentStatusDraft), resp.Data["status"])
+	assert.Nil(t, resp.Data["values"], "the draft values stay hidden")
+	m.api.AssertExpectations(t)
+}
+
+func Test_updateContent_notFound(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.content.On("FindByID", uint(9)).Return(nil, service.ErrContentNotFound)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/content/9", strings.NewReader(`{"values":{}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func Test_deleteContent(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.content.On("FindByID", uint(5)).Return(existingContent(), nil)
+	m.content.On("DeleteByID", uint(5)).Return(nil)
+	m.webhook.On("Dispatch", string(model.EventContentDeleted), nil).Return()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/content/5", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	m.webhook.AssertExpectations(t)
+	m.events.AssertCalled(t, "Publish", service.EventDeleted, uint(5), existingContent().CollectionID)
+}
+
+func Test_deleteContent_invalidID(t *testing.T) {
+	srv, rec, _ := setupWriteServer()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/content/abc", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_createContent_publishedDispatchesPublishEvent(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{Model: gorm.Model{ID: 3}, Fields: []model.Field{{Alias: "title"}}}, nil)
+	m.content.On("CreateWithValues", dto.ContentWithValues{
+		CollectionID: 3,
+		Status:       model.ContentStatusPublished,
+		Schedule:     &dto.ContentSchedule{},
+		FormData:     map[string][]string{"title": {"x"}},
+	}).Return(&model.Content{Model: gorm.Model{ID: 7}, Status: model.ContentStatusPublished}, nil)
+	m.webhook.On("Dispatch", string(model.EventContentCreated), nil).Return()
+	m.webhook.On("Dispatch", string(model.EventContentPublished), nil).Return()
+	m.api.On("FindContentByID", uint(7), dto.ApiQuery{Preview: true, Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{ID: 7}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(`{"status":"Published","values":{"title":"x"}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	m.webhook.AssertExpectations(t)
+}
+
+func Test_findContentById_typedVersion(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByID", uint(1), dto.ApiQuery{Version: dto.ApiVersionTyped}).
+		Return(dto.ContentItemResponse{ID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1", nil)
+	req.Header.Set("X-API-Version", "2")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_versionQueryParam(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "news", 0, 100, dto.ApiQuery{Version: dto.ApiVersionTyped}).
+		Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?version=2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_findContentById_invalidVersion(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1", nil)
+	req.Header.Set("X-API-Version", "9")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_version")
+	mockApi.AssertNotCalled(t, "FindContentByID", mock.Anything, mock.Anything)
+}
+
+func Test_createContent_invalidVersion(t *testing.T) {
+	srv, rec, m := setupWriteServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content?version=abc", strings.NewReader(`{"values":{}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	m.content.AssertNotCalled(t, "CreateWithValues", mock.Anything)
+}
+
+func Test_findContentById_depthAndInclude(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByID", uint(1), dto.ApiQuery{
+		Version: dto.ApiVersionLegacy,
+		Depth:   2,
+		Include: []string{"author", "author.company"},
+	}).Return(dto.ContentItemResponse{ID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1?depth=2&include=author,%20author.company", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_invalidDepth(t *testing.T) {
+	for _, depth := range []string{"-1", "abc", "6"} {
+		srv, rec, mockApi, _ := setupTestServer()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?depth="+depth, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, depth)
+		assert.Contains(t, rec.Body.String(), "invalid_depth")
+		mockApi.AssertNotCalled(t, "FindContentByCollectionAlias", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func Test_findContentById_invalidInclude(t *testing.T) {
+	for _, include := range []string{"author,,tags", "author.", "a.b.c.d.e.f"} {
+		srv, rec, mockApi, _ := setupTestServer()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/content/1?include="+include, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, include)
+		assert.Contains(t, rec.Body.String(), "invalid_include")
+		mockApi.AssertNotCalled(t, "FindContentByID", mock.Anything, mock.Anything)
+	}
+}
+
+func Test_listContents_filter(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "products", 0, 100, dto.ApiQuery{
+		Version: dto.ApiVersionLegacy,
+		Filter: &dto.ContentFilter{
+			Or: true,
+			Conditions: []dto.FilterCondition{
+				{Field: "price", Op: "gte", Values: []string{"10"}},
+				{Field: "tags", Op: "in", Values: []string{"a", "b"}},
+				{Field: "title", Op: "eq", Values: []string{"go"}},
+			},
+		},
+	}).Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/products/content?filter[price][gte]=10&filter[title]=go&filter[tags][in]=a,%20b&match=or", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_invalidFilterParams(t *testing.T) {
+	for _, query := range []string{"filter[price][gte][x]=1", "filter[]=1", "filter[title]=go&match=xor"} {
+		srv, rec, mockApi, _ := setupTestServer()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/collections/products/content?"+query, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.Contains(t, rec.Body.String(), "invalid_filter")
+		mockApi.AssertNotCalled(t, "FindContentByCollectionAlias", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func Test_listContents_filterRejectedByService(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "products", 0, 100, mock.Anything).
+		Return([]dto.ContentItemResponse{}, int64(0), fmt.Errorf("%w: unknown field %q", service.ErrInvalidFilter, "nope"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/products/content?filter[nope]=1", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_filter")
+}
+
+func Test_listContentsByFieldValue_filterOnly(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "products", 0, 100, dto.ApiQuery{
+		Version: dto.ApiVersionLegacy,
+		Filter: &dto.ContentFilter{Conditions: []dto.FilterCondition{
+			{Field: "title", Op: "contains", Values: []string{"go"}},
+		}},
+	}).Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/products/content/filter?filter[title][contains]=go", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_sort(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "news", 0, 100, dto.ApiQuery{Version: dto.ApiVersionLegacy, Sort: "-created_at,title"}).
+		Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?sort=-created_at,title", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_invalidSort(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "news", 0, 100, mock.Anything).
+		Return([]dto.ContentItemResponse{}, int64(0), fmt.Errorf("%w: unknown sort key %q", service.ErrInvalidSort, "nope"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?sort=nope", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_sort")
+}
+
+func decodePagination(t *testing.T, rec *httptest.ResponseRecorder) dto.Pagination {
+	t.Helper()
+	var body struct {
+		Pagination dto.Pagination `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Pagination
+}
+
+func Test_listContents_paginationMetadata(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "news", 10, 10, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return([]dto.ContentItemResponse{{ID: 11}, {ID: 12}, {ID: 13}, {ID: 14}, {ID: 15}, {ID: 16}, {ID: 17}, {ID: 18}, {ID: 19}, {ID: 20}}, int64(35), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?page=2&perPage=10", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	p := decodePagination(t, rec)
+	assert.Equal(t, 2, p.Page)
+	assert.Equal(t, 10, p.PerPage)
+	assert.Equal(t, 35, p.Total)
+	assert.Equal(t, 4, p.TotalPages)
+	assert.Equal(t, "/api/collections/news/content?page=3&perPage=10", p.Next)
+	assert.Equal(t, "/api/collections/news/content?page=1&perPage=10", p.Prev)
+	assert.NotEmpty(t, p.NextCursor)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_perPageIsCapped(t *testing.T) {
+	srv := server.NewServer()
+	mockApi := &testutils.MockApiService{}
+	mockApi.On("CollectionLastModified", "news").Return(time.Time{}, nil)
+	ctrl := NewController(&service.Set{Api: mockApi}, 25)
+	srv.Handle("GET /api/collections/{alias}/content", ctrl.listContents)
+
+	mockApi.On("FindContentByCollectionAlias", "news", 0, 25, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return([]dto.ContentItemResponse{}, int64(0), nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?perPage=500", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	p := decodePagination(t, rec)
+	assert.Equal(t, 25, p.PerPage)
+	assert.Equal(t, 0, p.Total)
+	assert.Empty(t, p.Next)
+	assert.Empty(t, p.NextCursor)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_cursor(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByCollectionAlias", "news", 0, 2, dto.ApiQuery{Version: dto.ApiVersionLegacy, After: 7}).
+		Return([]dto.ContentItemResponse{{ID: 8}, {ID: 9}}, int64(12), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?perPage=2&page=4&cursor="+service.EncodeCursor(7), nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	p := decodePagination(t, rec)
+	assert.Equal(t, 0, p.Page)
+	assert.Equal(t, 12, p.Total)
+	assert.Equal(t, service.EncodeCursor(9), p.NextCursor)
+	assert.Equal(t, "/api/collections/news/content?cursor="+service.EncodeCursor(9)+"&perPage=2", p.Next)
+	assert.Empty(t, p.Prev)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_invalidCursor(t *testing.T) {
+	for _, query := range []string{"cursor=nope", "cursor=" + service.EncodeCursor(3) + "&sort=title"} {
+		srv, rec, mockApi, _ := setupTestServer()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?"+query, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.Contains(t, rec.Body.String(), "invalid_cursor")
+		mockApi.AssertNotCalled(t, "FindContentByCollectionAlias", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func Test_findContentById_fields(t *testing.T) {
+	srv, rec, mockApi, _ := setupTestServer()
+
+	mockApi.On("FindContentByID", uint(1), dto.ApiQuery{Version: dto.ApiVersionLegacy, Fields: []string{"title", "slug"}}).
+		Return(dto.ContentItemResponse{ID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1?fields=title,%20slug", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockApi.AssertExpectations(t)
+}
+
+func Test_listContents_invalidFields(t *testing.T) {
+	for _, fields := range []string{"", "title,,slug", "title,"} {
+		srv, rec, mockApi, _ := setupTestServer()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/collections/news/content?fields="+fields, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, fields)
+		assert.Contains(t, rec.Body.String(), "invalid_fields")
+		mockApi.AssertNotCalled(t, "FindContentByCollectionAlias", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
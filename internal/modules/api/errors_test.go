@@ -0,0 +1,287 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type errorMocks struct {
+	api        *testutils.MockApiService
+	apikey     *testutils.MockApikeyService
+	content    *testutils.MockContentService
+	collection *testutils.MockCollectionService
+	openapi    *testutils.MockOpenAPIService
+}
+
+func setupErrorServer() (*server.Server, errorMocks) {
+	m := errorMocks{
+		api:        &testutils.MockApiService{},
+		apikey:     &testutils.MockApikeyService{},
+		content:    &testutils.MockContentService{},
+		collection: &testutils.MockCollectionService{},
+		openapi:    &testutils.MockOpenAPIService{},
+	}
+	m.api.On("CollectionLastModified", mock.Anything).Return(time.Time{}, nil).Maybe()
+	m.apikey.On("Validate", "valid").Return(nil)
+	m.apikey.On("Validate", "wrong").Return(errors.New("invalid api key"))
+	m.apikey.On("Validate", "readonly").Return(nil)
+	m.apikey.On("FindByToken", "valid").Return(&model.Apikey{CanWrite: true}, nil).Maybe()
+	m.apikey.On("FindByToken", "readonly").Return(&model.Apikey{}, nil).Maybe()
+
+	srv := server.NewServer()
+	NewController(&service.Set{
+		Api:        m.api,
+		Apikey:     m.apikey,
+		Content:    m.content,
+		Collection: m.collection,
+		GraphQL:    &testutils.MockGraphQLService{},
+		OpenAPI:    m.openapi,
+	}, 0).RegisterRoutes(srv)
+
+	return srv, m
+}
+
+func assertApiError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	assert.Equal(t, status, rec.Code)
+
+	var resp dto.ApiResponse
+	if !assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp)) {
+		return
+	}
+	assert.False(t, resp.Success)
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, code, resp.Error.Code)
+		assert.NotEmpty(t, resp.Error.Message)
+	}
+	assert.NotNil(t, resp.Meta)
+}
+
+var apiRoutes = []struct {
+	method string
+	path   string
+	body   string
+}{
+	{http.MethodGet, "/api/collections/blog/content", ""},
+	{http.MethodGet, "/api/collections/blog/content/filter?field=title&value=x", ""},
+	{http.MethodGet, "/api/content/1", ""},
+	{http.MethodPost, "/api/collections/blog/content", `{"values":{}}`},
+	{http.MethodPut, "/api/content/1", `{"values":{}}`},
+	{http.MethodPatch, "/api/content/1", `{"values":{}}`},
+	{http.MethodDelete, "/api/content/1", ""},
+	{http.MethodGet, "/api/sync", ""},
+	{http.MethodGet, "/api/search?q=x", ""},
+	{http.MethodGet, "/api/events", ""},
+	{http.MethodGet, "/api/openapi.json", ""},
+	{http.MethodGet, "/api/graphql?query=%7Bcollections%7Bid%7D%7D", ""},
+	{http.MethodPost, "/api/graphql", `{"query":"{collections{id}}"}`},
+}
+
+func Test_apiRoutes_Unauthorized(t *testing.T) {
+	srv, _ := setupErrorServer()
+
+	for _, route := range apiRoutes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, httptest.NewRequest(route.method, route.path, strings.NewReader(route.body)))
+			assertApiError(t, rec, http.StatusUnauthorized, "missing_api_key")
+
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader(route.body))
+			req.Header.Set("X-API-Key", "wrong")
+			rec = httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			assertApiError(t, rec, http.StatusUnauthorized, "invalid_api_key")
+		})
+	}
+}
+
+func Test_apiRoutes_ReadOnlyKeyCannotWrite(t *testing.T) {
+	srv, m := setupErrorServer()
+
+	for _, route := range apiRoutes {
+		if route.method == http.MethodGet || route.path == "/api/graphql" {
+			continue
+		}
+
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader(route.body))
+			req.Header.Set("X-API-Key", "readonly")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			assertApiError(t, rec, http.StatusForbidden, "write_forbidden")
+		})
+	}
+
+	m.content.AssertNotCalled(t, "CreateWithValues", mock.Anything)
+	m.content.AssertNotCalled(t, "EditWithValues", mock.Anything)
+	m.content.AssertNotCalled(t, "DeleteByID", mock.Anything)
+}
+
+func Test_apiRoutes_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		setup  func(m errorMocks)
+		status int
+		code   string
+	}{
+		{
+			name: "list unknown collection", method: http.MethodGet, path: "/api/collections/nope/content",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByCollectionAlias", "nope", 0, 100, mock.Anything).Return([]dto.ContentItemResponse(nil), int64(0), service.ErrCollectionNotFound)
+			},
+			status: http.StatusNotFound, code: "collection_not_found",
+		},
+		{
+			name: "list database failure", method: http.MethodGet, path: "/api/collections/blog/content",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByCollectionAlias", "blog", 0, 100, mock.Anything).Return([]dto.ContentItemResponse(nil), int64(0), errors.New("disk I/O error"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "list invalid depth", method: http.MethodGet, path: "/api/collections/blog/content?depth=x",
+			status: http.StatusBadRequest, code: "invalid_depth",
+		},
+		{
+			name: "filter unknown collection", method: http.MethodGet, path: "/api/collections/nope/content/filter?field=title&value=x",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByCollectionAndFieldValue", "nope", "title", "x", 0, 100, mock.Anything).Return([]dto.ContentItemResponse(nil), int64(0), service.ErrCollectionNotFound)
+			},
+			status: http.StatusNotFound, code: "collection_not_found",
+		},
+		{
+			name: "filter unknown field", method: http.MethodGet, path: "/api/collections/blog/content/filter?field=nope&value=x",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByCollectionAndFieldValue", "blog", "nope", "x", 0, 100, mock.Anything).Return([]dto.ContentItemResponse(nil), int64(0), service.ErrInvalidFilter)
+			},
+			status: http.StatusBadRequest, code: "invalid_filter",
+		},
+		{
+			name: "filter database failure", method: http.MethodGet, path: "/api/collections/blog/content/filter?field=title&value=x",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByCollectionAndFieldValue", "blog", "title", "x", 0, 100, mock.Anything).Return([]dto.ContentItemResponse(nil), int64(0), errors.New("disk I/O error"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "filter without parameters", method: http.MethodGet, path: "/api/collections/blog/content/filter",
+			status: http.StatusBadRequest, code: "missing_filter",
+		},
+		{
+			name: "find unknown content", method: http.MethodGet, path: "/api/content/404",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByID", uint(404), mock.Anything).Return(dto.ContentItemResponse{}, service.ErrContentNotFound)
+			},
+			status: http.StatusNotFound, code: "content_not_found",
+		},
+		{
+			name: "find invalid id", method: http.MethodGet, path: "/api/content/abc",
+			status: http.StatusBadRequest, code: "invalid_id",
+		},
+		{
+			name: "find database failure", method: http.MethodGet, path: "/api/content/1",
+			setup: func(m errorMocks) {
+				m.api.On("FindContentByID", uint(1), mock.Anything).Return(dto.ContentItemResponse{}, errors.New("disk I/O error"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "create unknown collection", method: http.MethodPost, path: "/api/collections/nope/content", body: `{"values":{}}`,
+			setup: func(m errorMocks) {
+				m.collection.On("FindByAlias", "nope").Return(nil, service.ErrCollectionNotFound)
+			},
+			status: http.StatusNotFound, code: "collection_not_found",
+		},
+		{
+			name: "replace invalid id", method: http.MethodPut, path: "/api/content/abc", body: `{"values":{}}`,
+			status: http.StatusBadRequest, code: "invalid_id",
+		},
+		{
+			name: "patch unknown content", method: http.MethodPatch, path: "/api/content/404", body: `{"values":{}}`,
+			setup: func(m errorMocks) {
+				m.content.On("FindByID", uint(404)).Return(nil, service.ErrContentNotFound)
+			},
+			status: http.StatusNotFound, code: "content_not_found",
+		},
+		{
+			name: "create collection lookup failure", method: http.MethodPost, path: "/api/collections/blog/content", body: `{"values":{}}`,
+			setup: func(m errorMocks) {
+				m.collection.On("FindByAlias", "blog").Return(nil, errors.New("SQL logic error: no such table: collections"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "patch content lookup failure", method: http.MethodPatch, path: "/api/content/5", body: `{"values":{}}`,
+			setup: func(m errorMocks) {
+				m.content.On("FindByID", uint(5)).Return(nil, errors.New("database is locked"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "delete failure", method: http.MethodDelete, path: "/api/content/5",
+			setup: func(m errorMocks) {
+				m.content.On("FindByID", uint(5)).Return(&model.Content{}, nil)
+				m.content.On("DeleteByID", uint(5)).Return(errors.New("database is locked"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "delete unknown content", method: http.MethodDelete, path: "/api/content/404",
+			setup: func(m errorMocks) {
+				m.content.On("FindByID", uint(404)).Return(nil, service.ErrContentNotFound)
+			},
+			status: http.StatusNotFound, code: "content_not_found",
+		},
+		{
+			name: "openapi failure", method: http.MethodGet, path: "/api/openapi.json",
+			setup: func(m errorMocks) {
+				m.openapi.On("Spec").Return(nil, errors.New("disk I/O error"))
+			},
+			status: http.StatusInternalServerError, code: "internal_error",
+		},
+		{
+			name: "graphql without query", method: http.MethodPost, path: "/api/graphql", body: `{}`,
+			status: http.StatusBadRequest, code: "invalid_request",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, m := setupErrorServer()
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("X-API-Key", "valid")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			assertApiError(t, rec, tc.status, tc.code)
+		})
+	}
+}
+
+func Test_writeServiceError_HidesInternalDetails(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeServiceError(rec, errors.New("near \"SELECT\": syntax error"))
+
+	assertApiError(t, rec, http.StatusInternalServerError, "internal_error")
+	assert.NotContains(t, rec.Body.String(), "SELECT")
+}
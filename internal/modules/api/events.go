@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+)
+
+const eventsRetry = 3 * time.Second
+
+var eventsHeartbeat = 25 * time.Second
+
+func parseLastEventID(r *http.Request) (uint64, bool) {
+	raw := r.Header.Get("Last-Event-ID")
+	if v := r.URL.Query().Get("lastEventId"); v != "" {
+		raw = v
+	}
+
+	if raw == "" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 64)
+	return id, err == nil
+}
+
+func writeEvent(w io.Writer, event dto.ContentEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func (ct Controller) sendEvent(w io.Writer, event dto.ContentEvent, q dto.ApiQuery, withItem bool) error {
+	if withItem && event.Type != service.EventDeleted {
+		// hidden entries are sent without item, like a tombstone in /api/sync
+		if item, err := ct.services.Api.FindContentByID(event.ContentID, q); err == nil {
+			event.Item = &item
+		}
+	}
+
+	return writeEvent(w, event)
+}
+
+func (ct Controller) events(ctx server.Context) {
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
+
+	if q.Filter != nil || q.Sort != "" {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "events do not support filter or sort")
+		return
+	}
+
+	lastEventID, ok := parseLastEventID(ctx.Request)
+	if !ok {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "invalid Last-Event-ID")
+		return
+	}
+
+	flusher, ok := ctx.Writer.(http.Flusher)
+	if !ok {
+		writeError(ctx.Writer, http.StatusInternalServerError, "internal_error", "streaming is not supported")
+		return
+	}
+
+	withItem := ctx.Request.URL.Query().Get("item") == "true"
+
+	events, missed, resumed := ct.services.Events.Subscribe(parseCollections(ctx.Request), lastEventID)
+	defer ct.services.Events.Unsubscribe(events)
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", cacheControlPrivate)
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Writer.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", eventsRetry.Milliseconds())
+	if !resumed {
+		// the missed events are gone, the client has to sync again; the empty
+		// id clears the Last-Event-ID of the browser
+		fmt.Fprint(ctx.Writer, "id:\nevent: reset\ndata: {}\n\n")
+	}
+
+	for _, event := range missed {
+		if err := ct.sendEvent(ctx.Writer, event, q, withItem); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			if err := ct.sendEvent(ctx.Writer, event, q, withItem); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
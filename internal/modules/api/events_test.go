@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupEventsServer() (*server.Server, *httptest.ResponseRecorder, *testutils.MockEventService, *testutils.MockApiService) {
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	mockEvents := &testutils.MockEventService{}
+	mockEvents.On("Unsubscribe", mock.Anything).Return()
+	mockApi := &testutils.MockApiService{}
+
+	ctrl := NewController(&service.Set{Events: mockEvents, Api: mockApi}, 0)
+	srv.Handle("GET /api/events", ctrl.events)
+
+	return srv, rec, mockEvents, mockApi
+}
+
+func Test_events_streamsMissedAndLiveEvents(t *testing.T) {
+	srv, rec, mockEvents, mockApi := setupEventsServer()
+
+	live := make(chan dto.ContentEvent, 2)
+	live <- dto.ContentEvent{ID: 12, Type: service.EventDeleted, ContentID: 4, Collection: "blog"}
+	close(live)
+
+	mockEvents.On("Subscribe", []string{"blog"}, uint64(10)).Return(live, []dto.ContentEvent{
+		{ID: 11, Type: service.EventUpdated, ContentID: 3, Collection: "blog"},
+	}, true)
+	mockApi.On("FindContentByID", uint(3), dto.ApiQuery{Version: dto.ApiVersionLegacy}).Return(dto.ContentItemResponse{ID: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events?collections=blog&item=true", nil)
+	req.Header.Set("Last-Event-ID", "10")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "retry: 3000\n\n")
+	assert.Contains(t, body, "id: 11\nevent: updated\ndata: {\"id\":11,\"type\":\"updated\",\"content_id\":3,\"collection\":\"blog\"")
+	assert.Contains(t, body, `"item":{"id":3`)
+	assert.Contains(t, body, "id: 12\nevent: deleted\n")
+	assert.NotContains(t, body, "event: reset")
+	assert.Less(t, strings.Index(body, "id: 11"), strings.Index(body, "id: 12"))
+	mockApi.AssertNumberOfCalls(t, "FindContentByID", 1)
+	mockEvents.AssertCalled(t, "Unsubscribe", mock.Anything)
+}
+
+func Test_events_resetWhenBufferExpired(t *testing.T) {
+	srv, rec, mockEvents, _ := setupEventsServer()
+
+	live := make(chan dto.ContentEvent)
+	close(live)
+	mockEvents.On("Subscribe", []string(nil), uint64(5)).Return(live, nil, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events?lastEventId=5", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "id:\nevent: reset\ndata: {}\n\n")
+}
+
+func Test_events_invalidRequest(t *testing.T) {
+	tests := []string{
+		"/api/events?lastEventId=abc",
+		"/api/events?sort=title",
+	}
+
+	for _, target := range tests {
+		srv, rec, mockEvents, _ := setupEventsServer()
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		srv.ServeHTTP(rec, req)
+
+		assertApiError(t, rec, http.StatusBadRequest, "invalid_request")
+		mockEvents.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+)
+
+func decodeGraphQLRequest(r *http.Request) (dto.GraphQLRequest, error) {
+	var req dto.GraphQLRequest
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if raw := query.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				return req, err
+			}
+		}
+		return req, nil
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	return req, err
+}
+
+func (ct Controller) graphql(ctx server.Context) {
+	req, err := decodeGraphQLRequest(ctx.Request)
+	if err != nil {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "request must be a JSON object with a query")
+		return
+	}
+
+	if req.Query == "" {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "query is required")
+		return
+	}
+
+	writeJSON(ctx.Writer, http.StatusOK, ct.services.GraphQL.Execute(req, ct.maxPerPage))
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGraphQLServer() (*server.Server, *testutils.MockGraphQLService) {
+	srv := server.NewServer()
+	mockGraphQL := &testutils.MockGraphQLService{}
+
+	ctrl := NewController(&service.Set{GraphQL: mockGraphQL}, 25)
+	srv.Handle("GET /api/graphql", ctrl.graphql)
+	srv.Handle("POST /api/graphql", ctrl.graphql)
+
+	return srv, mockGraphQL
+}
+
+func Test_graphql_Post(t *testing.T) {
+	srv, mockGraphQL := setupGraphQLServer()
+	mockGraphQL.
+		On("Execute", dto.GraphQLRequest{Query: "{ collections { alias } }", Variables: map[string]any{"id": float64(1)}}, 25).
+		Return(&graphql.Result{Data: map[string]any{"collections": []any{}}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql",
+		strings.NewReader(`{"query":"{ collections { alias } }","variables":{"id":1}}`))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Contains(t, body, "data")
+	mockGraphQL.AssertExpectations(t)
+}
+
+func Test_graphql_Get(t *testing.T) {
+	srv, mockGraphQL := setupGraphQLServer()
+	mockGraphQL.
+		On("Execute", dto.GraphQLRequest{Query: "{ collections { alias } }", OperationName: "Q"}, 25).
+		Return(&graphql.Result{Data: map[string]any{}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/graphql?query=%7B+collections+%7B+alias+%7D+%7D&operationName=Q", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func Test_graphql_InvalidRequest(t *testing.T) {
+	srv, mockGraphQL := setupGraphQLServer()
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{not json`)),
+		httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{"query":""}`)),
+		httptest.NewRequest(http.MethodGet, "/api/graphql?query=x&variables=%7Bbad", nil),
+	} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid_request")
+	}
+	mockGraphQL.AssertNotCalled(t, "Execute")
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupLocaleServer(t *testing.T) (*server.Server, *httptest.ResponseRecorder, writeMocks) {
+	locales, err := service.NewLocales([]string{"en", "de", "de-AT"})
+	assert.NoError(t, err)
+
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	mocks := writeMocks{
+		api:        &testutils.MockApiService{},
+		apikey:     &testutils.MockApikeyService{},
+		content:    &testutils.MockContentService{},
+		collection: &testutils.MockCollectionService{},
+		field:      &testutils.MockFieldService{},
+		webhook:    &testutils.MockWebhookService{},
+		events:     &testutils.MockEventService{},
+	}
+	mocks.api.On("CollectionLastModified", mock.Anything).Return(time.Time{}, nil).Maybe()
+	mocks.events.On("Publish", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mocks.apikey.On("FindByToken", "").Return(&model.Apikey{CanPreview: true, CanWrite: true}, nil).Maybe()
+	mocks.webhook.On("Dispatch", mock.Anything, nil).Return().Maybe()
+
+	ctrl := NewController(&service.Set{
+		Api:        mocks.api,
+		Apikey:     mocks.apikey,
+		Content:    mocks.content,
+		Collection: mocks.collection,
+		Field:      mocks.field,
+		Webhook:    mocks.webhook,
+		Events:     mocks.events,
+		Locales:    locales,
+	}, 0)
+
+	srv.Handle("GET /api/content/{id}", ctrl.findContentById)
+	srv.Handle("POST /api/collections/{alias}/content", ctrl.createContent)
+	srv.Handle("PATCH /api/content/{id}", ctrl.patchContent)
+
+	return srv, rec, mocks
+}
+
+func Test_findContentById_locale(t *testing.T) {
+	srv, rec, m := setupLocaleServer(t)
+
+	m.api.On("FindContentByID", uint(1), dto.ApiQuery{Version: dto.ApiVersionLegacy, Locales: []string{"de-AT", "de", ""}}).
+		Return(dto.ContentItemResponse{ID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/content/1?locale=de-AT", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	m.api.AssertExpectations(t)
+}
+
+func Test_findContentById_invalidLocale(t *testing.T) {
+	for _, locale := range []string{"fr", "de_AT"} {
+		srv, rec, m := setupLocaleServer(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/content/1?locale="+locale, nil)
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, locale)
+		assert.Contains(t, rec.Body.String(), "invalid_locale")
+		m.api.AssertNotCalled(t, "FindContentByID", mock.Anything, mock.Anything)
+	}
+}
+
+func Test_createContent_translations(t *testing.T) {
+	srv, rec, m := setupLocaleServer(t)
+
+	m.collection.On("FindByAlias", "blog").Return(&model.Collection{
+		Model:  gorm.Model{ID: 3},
+		Alias:  "blog",
+		Fields: []model.Field{{Alias: "title", Localized: true}, {Alias: "price"}},
+	}, nil)
+	m.content.On("CreateWithValues", dto.ContentWithValues{
+		CollectionID: 3,
+		Schedule:     &dto.ContentSchedule{},
+		FormData:     map[string][]string{"title": {"Hello"}},
+		Translations: map[string]map[string][]string{"de": {"title": {"Hallo"}}},
+	}).Return(&model.Content{Model: gorm.Model{ID: 7}}, nil)
+	m.api.On("FindContentByID", uint(7), mock.Anything).Return(dto.ContentItemResponse{ID: 7}, nil)
+
+	body := `{"values":{"title":"Hello"},"translations":{"de":{"title":"Hallo"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(body))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	m.content.AssertExpectations(t)
+}
+
+func Test_createContent_invalidTranslations(t *testing.T) {
+	for _, body := range []string{
+		`{"values":{},"translations":{"fr":{"title":"Bonjour"}}}`,
+		`{"values":{},"translations":{"de":{"price":1}}}`,
+	} {
+		srv, rec, m := setupLocaleServer(t)
+
+		m.collection.On("FindByAlias", "blog").Return(&model.Collection{
+			Model:  gorm.Model{ID: 3},
+			Fields: []model.Field{{Alias: "title", Localized: true}, {Alias: "price"}},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/collections/blog/content", strings.NewReader(body))
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.Contains(t, rec.Body.String(), "invalid_values")
+		m.content.AssertNotCalled(t, "CreateWithValues", mock.Anything)
+	}
+}
+
+func Test_patchContent_mergesTranslations(t *testing.T) {
+	srv, rec, m := setupLocaleServer(t)
+
+	title := model.Field{Alias: "title", Localized: true}
+	teaser := model.Field{Alias: "teaser", Localized: true}
+	m.content.On("FindByID", uint(5)).Return(&model.Content{
+		Model:        gorm.Model{ID: 5},
+		CollectionID: 2,
+		ContentValues: []model.ContentValue{
+			{Value: "Hello", Field: title},
+			{Value: "Hallo", Field: title, Locale: "de"},
+			{Value: "Lies", Field: teaser, Locale: "de"},
+		},
+	}, nil)
+	m.field.On("FindByCollectionID", uint(2)).Return([]model.Field{title, teaser}, nil)
+	m.content.On("EditWithValues", dto.ContentWithValues{
+		CollectionID: 2,
+		ContentID:    5,
+		Schedule:     &dto.ContentSchedule{},
+		FormData:     map[string][]string{"title": {"Hello"}},
+		Translations: map[string]map[string][]string{
+			"de":    {"title": {"Guten Tag"}, "teaser": {"Lies"}},
+			"de-AT": {"title": {"Servus"}},
+		},
+	}).Return(&model.Content{}, nil)
+	m.api.On("FindContentByID", uint(5), mock.Anything).Return(dto.ContentItemResponse{ID: 5}, nil)
+
+	body := `{"translations":{"de":{"title":"Guten Tag"},"de-AT":{"title":"Servus"}}}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/content/5", strings.NewReader(body))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	m.content.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/janmarkuslanger/nuricms/internal/server"
+)
+
+func (ct Controller) openapi(ctx server.Context) {
+	spec, err := ct.services.OpenAPI.Spec()
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	writeJSON(ctx.Writer, http.StatusOK, spec)
+}
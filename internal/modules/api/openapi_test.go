@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_openapi(t *testing.T) {
+	mockOpenAPI := &testutils.MockOpenAPIService{}
+	mockOpenAPI.On("Spec").Return(map[string]any{"openapi": "3.0.3"}, nil)
+
+	srv := server.NewServer()
+	srv.Handle("GET /api/openapi.json", NewController(&service.Set{OpenAPI: mockOpenAPI}, 0).openapi)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"openapi":"3.0.3"}`, rec.Body.String())
+}
+
+func Test_openapi_Error(t *testing.T) {
+	mockOpenAPI := &testutils.MockOpenAPIService{}
+	mockOpenAPI.On("Spec").Return(nil, errors.New("db down"))
+
+	srv := server.NewServer()
+	srv.Handle("GET /api/openapi.json", NewController(&service.Set{OpenAPI: mockOpenAPI}, 0).openapi)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "internal_error")
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+)
+
+func (ct Controller) search(ctx server.Context) {
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
+
+	query := ctx.Request.URL.Query()
+	if q.Filter != nil || q.Sort != "" || query.Get("cursor") != "" {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "search does not support filter, sort or cursor")
+		return
+	}
+
+	term := strings.TrimSpace(query.Get("q"))
+	if term == "" {
+		writeError(ctx.Writer, http.StatusBadRequest, "missing_query", "missing search query q")
+		return
+	}
+
+	p, ok := ct.parsePageRequest(ctx, &q)
+	if !ok {
+		return
+	}
+
+	hits, total, err := ct.services.Search.Search(term, strings.TrimSpace(query.Get("collection")), p.offset, p.perPage, q)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	writeJSON(ctx.Writer, http.StatusOK, dto.ApiResponse{
+		Data:    hits,
+		Success: true,
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+		Pagination: p.pagination(ctx.Request, q, total, nil),
+	})
+}
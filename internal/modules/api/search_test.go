@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupSearchServer() (*server.Server, *httptest.ResponseRecorder, *testutils.MockSearchService) {
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	mockSearch := &testutils.MockSearchService{}
+	ctrl := NewController(&service.Set{Search: mockSearch}, 0)
+	srv.Handle("GET /api/search", ctrl.search)
+
+	return srv, rec, mockSearch
+}
+
+func Test_search(t *testing.T) {
+	srv, rec, mockSearch := setupSearchServer()
+
+	mockSearch.On("Search", "sourdough bread", "blog", 10, 10, dto.ApiQuery{Version: dto.ApiVersionLegacy}).Return([]dto.SearchHit{
+		{Score: 1.5, Snippet: "<mark>sourdough</mark> bread", Item: dto.ContentItemResponse{ID: 4}},
+	}, int64(11), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search?q=+sourdough+bread+&collection=blog&page=2&perPage=10", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Success    bool            `json:"success"`
+		Data       []dto.SearchHit `json:"data"`
+		Pagination dto.Pagination  `json:"pagination"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.True(t, resp.Success)
+	if assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, uint(4), resp.Data[0].Item.ID)
+		assert.Equal(t, "<mark>sourdough</mark> bread", resp.Data[0].Snippet)
+	}
+	assert.Equal(t, 11, resp.Pagination.Total)
+	assert.Equal(t, 2, resp.Pagination.TotalPages)
+	assert.Empty(t, resp.Pagination.NextCursor)
+}
+
+func Test_search_errors(t *testing.T) {
+	cases := []struct {
+		target string
+		status int
+		code   string
+	}{
+		{"/api/search", http.StatusBadRequest, "missing_query"},
+		{"/api/search?q=+", http.StatusBadRequest, "missing_query"},
+		{"/api/search?q=x&sort=title", http.StatusBadRequest, "invalid_request"},
+		{"/api/search?q=x&cursor=abc", http.StatusBadRequest, "invalid_request"},
+		{"/api/search?q=x&collection=nope", http.StatusNotFound, "collection_not_found"},
+	}
+
+	for _, c := range cases {
+		srv, rec, mockSearch := setupSearchServer()
+		mockSearch.On("Search", "x", "nope", mock.Anything, mock.Anything, mock.Anything).Return(nil, int64(0), service.ErrCollectionNotFound)
+
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, c.target, nil))
+		assertApiError(t, rec, c.status, c.code)
+	}
+}
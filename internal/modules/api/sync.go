@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+)
+
+func parseCollections(r *http.Request) []string {
+	var aliases []string
+	for _, alias := range strings.Split(r.URL.Query().Get("collections"), ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+func (ct Controller) sync(ctx server.Context) {
+	q, ok := ct.parseApiQuery(ctx)
+	if !ok {
+		return
+	}
+
+	if q.Filter != nil || q.Sort != "" {
+		writeError(ctx.Writer, http.StatusBadRequest, "invalid_request", "sync does not support filter or sort")
+		return
+	}
+
+	perPage := min(defaultPerPage, ct.maxPerPage)
+	if raw := ctx.Request.URL.Query().Get("perPage"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			perPage = min(parsed, ct.maxPerPage)
+		}
+	}
+
+	changes, err := ct.services.Sync.Changes(ctx.Request.URL.Query().Get("since"), parseCollections(ctx.Request), perPage, q)
+	if err != nil {
+		writeServiceError(ctx.Writer, err)
+		return
+	}
+
+	ctx.Writer.Header().Set("Cache-Control", cacheControlPrivate)
+	writeJSON(ctx.Writer, http.StatusOK, dto.ApiResponse{
+		Data:    changes,
+		Success: true,
+		Meta: &dto.MetaData{
+			Timestamp: time.Now().UTC(),
+		},
+	})
+}
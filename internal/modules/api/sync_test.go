@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSyncServer() (*server.Server, *testutils.MockSyncService) {
+	srv := server.NewServer()
+	mockSync := &testutils.MockSyncService{}
+
+	ctrl := NewController(&service.Set{Sync: mockSync}, 50)
+	srv.Handle("GET /api/sync", ctrl.sync)
+
+	return srv, mockSync
+}
+
+func Test_sync(t *testing.T) {
+	srv, mockSync := setupSyncServer()
+	mockSync.On("Changes", "tok", []string{"blog", "news"}, 50, dto.ApiQuery{Version: dto.ApiVersionTyped}).
+		Return(dto.SyncResponse{
+			Items:     []dto.ContentItemResponse{{ID: 1}},
+			Deleted:   []dto.SyncTombstone{{ID: 2, Reason: service.SyncReasonDeleted}},
+			NextToken: "next",
+			HasMore:   true,
+		}, nil)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/sync?since=tok&collections=blog,+news&perPage=500&version=2", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheControlPrivate, rec.Header().Get("Cache-Control"))
+
+	var body struct {
+		Data dto.SyncResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "next", body.Data.NextToken)
+	assert.True(t, body.Data.HasMore)
+	assert.Len(t, body.Data.Items, 1)
+	assert.Equal(t, "deleted", body.Data.Deleted[0].Reason)
+	mockSync.AssertExpectations(t)
+}
+
+func Test_sync_errors(t *testing.T) {
+	srv, mockSync := setupSyncServer()
+	mockSync.On("Changes", "bad", []string(nil), 50, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return(dto.SyncResponse{}, service.ErrInvalidSyncToken)
+	mockSync.On("Changes", "", []string{"nope"}, 50, dto.ApiQuery{Version: dto.ApiVersionLegacy}).
+		Return(dto.SyncResponse{}, service.ErrCollectionNotFound)
+
+	for path, expected := range map[string]struct {
+		status int
+		code   string
+	}{
+		"/api/sync?since=bad":        {http.StatusBadRequest, "invalid_token"},
+		"/api/sync?collections=nope": {http.StatusNotFound, "collection_not_found"},
+		"/api/sync?sort=title":       {http.StatusBadRequest, "invalid_request"},
+	} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assertApiError(t, rec, expected.status, expected.code)
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+)
+
+func valueToStrings(v any) ([]string, error) {
+	switch val := v.(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		return []string{val}, nil
+	case json.Number:
+		return []string{val.String()}, nil
+	case bool:
+		if val {
+			return []string{"on"}, nil
+		}
+		return []string{}, nil
+	case []any:
+		values := make([]string, 0, len(val))
+		for _, item := range val {
+			if _, nested := item.([]any); nested {
+				return nil, fmt.Errorf("nested lists are not supported")
+			}
+			converted, err := valueToStrings(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted...)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// blockToString encodes a block sent as an object, its values are converted
+// like the values of an entry.
+func blockToString(v any) (any, error) {
+	item, ok := v.(map[string]any)
+	if !ok {
+		return v, nil
+	}
+
+	component, _ := item["component"].(string)
+	block := model.Block{Component: component, Values: map[string][]string{}}
+
+	values, ok := item["values"].(map[string]any)
+	if !ok && item["values"] != nil {
+		return nil, fmt.Errorf("block values must be an object")
+	}
+
+	for alias, value := range values {
+		converted, err := valueToStrings(value)
+		if err != nil {
+			return nil, fmt.Errorf("block value %q: %w", alias, err)
+		}
+		block.Values[alias] = converted
+	}
+
+	return block.String(), nil
+}
+
+// structuredToString encodes the JSON, GeoPoint and Blocks values that are
+// sent as objects instead of their string form.
+func structuredToString(f model.Field, v any) (any, error) {
+	switch f.FieldType {
+	case model.FieldTypeJSON:
+		if _, ok := v.(string); ok || v == nil {
+			return v, nil
+		}
+		raw, err := json.Marshal(v)
+		return string(raw), err
+
+	case model.FieldTypeGeoPoint:
+		point, ok := v.(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		lat, okLat := point["lat"].(json.Number)
+		lng, okLng := point["lng"].(json.Number)
+		if !okLat || !okLng {
+			return nil, fmt.Errorf("geo point needs numeric lat and lng")
+		}
+		return lat.String() + "," + lng.String(), nil
+
+	case model.FieldTypeBlocks:
+		return blockToString(v)
+	}
+
+	return v, nil
+}
+
+func fieldValueToStrings(f model.Field, v any) ([]string, error) {
+	switch f.FieldType {
+	case model.FieldTypeJSON, model.FieldTypeGeoPoint, model.FieldTypeBlocks:
+	default:
+		return valueToStrings(v)
+	}
+
+	items := []any{v}
+	if list, ok := v.([]any); ok && f.IsList {
+		items = list
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		converted, err := structuredToString(f, item)
+		if err != nil {
+			return nil, err
+		}
+		strs, err := valueToStrings(converted)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, strs...)
+	}
+	return values, nil
+}
+
+func valuesToFormData(fields []model.Field, values map[string]any) (map[string][]string, error) {
+	known := make(map[string]model.Field, len(fields))
+	for _, f := range fields {
+		known[f.Alias] = f
+	}
+
+	formData := make(map[string][]string, len(values))
+	for alias, v := range values {
+		f, ok := known[alias]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", alias)
+		}
+
+		converted, err := fieldValueToStrings(f, v)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", alias, err)
+		}
+		formData[alias] = converted
+	}
+
+	return formData, nil
+}
+
+func contentToFormData(content *model.Content) map[string][]string {
+	values := make([]model.ContentValue, len(content.ContentValues))
+	copy(values, content.ContentValues)
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].SortIndex < values[j].SortIndex
+	})
+
+	formData := make(map[string][]string)
+	for _, cv := range values {
+		if cv.Locale == "" {
+			formData[cv.Field.Alias] = append(formData[cv.Field.Alias], cv.Value)
+		}
+	}
+
+	return formData
+}
+
+func translationsToFormData(fields []model.Field, locales service.Locales, translations map[string]map[string]any) (map[string]map[string][]string, error) {
+	if translations == nil {
+		return nil, nil
+	}
+
+	localized := make([]model.Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Localized {
+			localized = append(localized, f)
+		}
+	}
+
+	result := make(map[string]map[string][]string, len(translations))
+	for locale, values := range translations {
+		if !locales.IsTranslation(locale) {
+			return nil, fmt.Errorf("unknown locale %q", locale)
+		}
+
+		formData, err := valuesToFormData(localized, values)
+		if err != nil {
+			return nil, fmt.Errorf("locale %q: %w", locale, err)
+		}
+		result[locale] = formData
+	}
+
+	return result, nil
+}
+
+func contentToTranslations(content *model.Content) map[string]map[string][]string {
+	values := make([]model.ContentValue, len(content.ContentValues))
+	copy(values, content.ContentValues)
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].SortIndex < values[j].SortIndex
+	})
+
+	translations := make(map[string]map[string][]string)
+	for _, cv := range values {
+		if cv.Locale == "" {
+			continue
+		}
+		if translations[cv.Locale] == nil {
+			translations[cv.Locale] = map[string][]string{}
+		}
+		translations[cv.Locale][cv.Field.Alias] = append(translations[cv.Locale][cv.Field.Alias], cv.Value)
+	}
+
+	return translations
+}
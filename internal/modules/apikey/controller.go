@@ -42,6 +42,12 @@ func (ct Controller) RegisterRoutes(s *server.Server) {
 		middleware.Roleauth(model.RoleAdmin),
 	)
 
+	s.Handle("POST /apikeys/edit/{id}",
+		ct.editApikey,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin),
+	)
+
 	s.Handle("POST /apikeys/delete/{id}",
 		ct.deleteApikey,
 		middleware.Userauth(ct.services.User),
@@ -61,7 +67,9 @@ func (ct Controller) showCreateApikey(ctx server.Context) {
 
 func (ct Controller) createApikey(ctx server.Context) {
 	handler.HandleCreate(ctx, ct.services.Apikey, dto.ApikeyData{
-		Name: ctx.Request.PostFormValue("name"),
+		Name:       ctx.Request.PostFormValue("name"),
+		CanPreview: ctx.Request.PostFormValue("can_preview"),
+		CanWrite:   ctx.Request.PostFormValue("can_write"),
 	}, handler.HandlerOptions{
 		RedirectOnSuccess: "/apikeys",
 		RenderOnFail:      "apikey/create_or_edit.tmpl",
@@ -75,6 +83,16 @@ func (ct Controller) showEditApikey(ctx server.Context) {
 	})
 }
 
+func (ct Controller) editApikey(ctx server.Context) {
+	handler.HandleEdit(ctx, ct.services.Apikey, ctx.Request.PathValue("id"), dto.ApikeyData{
+		CanPreview: ctx.Request.PostFormValue("can_preview"),
+		CanWrite:   ctx.Request.PostFormValue("can_write"),
+	}, handler.HandlerOptions{
+		RedirectOnSuccess: "/apikeys",
+		RedirectOnFail:    "/apikeys",
+	})
+}
+
 func (ct Controller) deleteApikey(ctx server.Context) {
 	handler.HandleDelete(ctx, ct.services.Apikey, ctx.Request.PathValue("id"), handler.HandlerOptions{
 		RedirectOnSuccess: "/apikeys",
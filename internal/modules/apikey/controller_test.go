@@ -31,6 +31,7 @@ func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.M
 	srv.Handle("GET /apikeys/create", ctrl.showCreateApikey)
 	srv.Handle("POST /apikeys/create", ctrl.createApikey)
 	srv.Handle("GET /apikeys/edit/{id}", ctrl.showEditApikey)
+	srv.Handle("POST /apikeys/edit/{id}", ctrl.editApikey)
 	srv.Handle("POST /apikeys/delete/{id}", ctrl.deleteApikey)
 
 	return srv, rec, mockApikey, mockUser
@@ -111,6 +112,24 @@ func Test_showEditApikey(t *testing.T) {
 	}
 }
 
+func Test_editApikey(t *testing.T) {
+	srv, rec, apikeyMock, _ := setupTestServer()
+
+	apikeyMock.
+		On("UpdateByID", uint(1), dto.ApikeyData{CanWrite: "on"}).
+		Return(&model.Apikey{Name: "key", CanWrite: true}, nil)
+
+	form := strings.NewReader("can_write=on")
+	req := httptest.NewRequest(http.MethodPost, "/apikeys/edit/1", form)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("expected 303 redirect, got %d", rec.Code)
+	}
+	apikeyMock.AssertExpectations(t)
+}
+
 func Test_deleteApikey(t *testing.T) {
 	srv, rec, apikeyMock, _ := setupTestServer()
 
@@ -2,7 +2,9 @@ package asset
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/handler"
 	"github.com/janmarkuslanger/nuricms/internal/middleware"
 	"github.com/janmarkuslanger/nuricms/internal/model"
@@ -26,6 +28,12 @@ func (ct Controller) RegisterRoutes(s *server.Server) {
 		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
 	)
 
+	s.Handle("GET /assets/search",
+		ct.searchAssets,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
 	s.Handle("GET /assets/create",
 		ct.showCreateAsset,
 		middleware.Userauth(ct.services.User),
@@ -64,6 +72,32 @@ func (ct Controller) showAssets(ctx server.Context) {
 	handler.HandleList(ctx, ct.services.Asset, "asset/index.tmpl")
 }
 
+const pickerPageSize = 20
+
+// searchAssets answers the asset pickers of the content editor.
+func (ct Controller) searchAssets(ctx server.Context) {
+	page, _ := utils.ParsePagination(ctx.Request)
+	if page < 1 {
+		page = 1
+	}
+
+	assets, total, err := ct.services.Asset.Search(strings.TrimSpace(ctx.Request.URL.Query().Get("q")), page, pickerPageSize)
+	if err != nil {
+		http.Error(ctx.Writer, "search failed", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]dto.PickerItem, 0, len(assets))
+	for _, a := range assets {
+		items = append(items, dto.PickerItem{ID: a.ID, Label: a.Name + ": " + a.Path})
+	}
+
+	utils.RenderJSON(ctx, dto.PickerResponse{
+		Items:   items,
+		HasMore: int64(page*pickerPageSize) < total,
+	}, http.StatusOK)
+}
+
 func (ct Controller) showCreateAsset(ctx server.Context) {
 	utils.RenderWithLayoutHTTP(ctx, "asset/create_or_edit.tmpl", map[string]any{}, http.StatusOK)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/janmarkuslanger/nuricms/testutils"
 	"github.com/janmarkuslanger/nuricms/testutils/mockservices"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupAssetTest() (*server.Server, *httptest.ResponseRecorder, *testutils.MockAssetService, *mockservices.MockUserService) {
@@ -28,6 +29,7 @@ func setupAssetTest() (*server.Server, *httptest.ResponseRecorder, *testutils.Mo
 	})
 
 	s.Handle("GET /assets", ctrl.showAssets)
+	s.Handle("GET /assets/search", ctrl.searchAssets)
 	s.Handle("GET /assets/create", ctrl.showCreateAsset)
 	s.Handle("POST /assets/create", ctrl.createAsset)
 	s.Handle("GET /assets/edit/{id}", ctrl.showEditAsset)
@@ -185,3 +187,33 @@ func Test_editAsset_withoutFile(t *testing.T) {
 		t.Errorf("expected redirect, got %d", rec.Code)
 	}
 }
+
+func Test_searchAssets(t *testing.T) {
+	srv, rec, mockAsset, _ := setupAssetTest()
+	assets := []model.Asset{{Model: gorm.Model{ID: 3}, Name: "Logo", Path: "public/assets/logo.png"}}
+	mockAsset.On("Search", "logo", 2, pickerPageSize).Return(assets, int64(21), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/search?q=+logo+&page=2", nil)
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	expected := `{"items":[{"id":3,"label":"Logo: public/assets/logo.png"}],"has_more":false}` + "\n"
+	if rec.Body.String() != expected {
+		t.Errorf("unexpected body %s", rec.Body.String())
+	}
+	mockAsset.AssertExpectations(t)
+}
+
+func Test_searchAssets_error(t *testing.T) {
+	srv, rec, mockAsset, _ := setupAssetTest()
+	mockAsset.On("Search", "", 1, pickerPageSize).Return([]model.Asset{}, int64(0), errors.New("db down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/search", nil)
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+}
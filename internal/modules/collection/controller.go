@@ -70,6 +70,7 @@ func (ct Controller) createCollection(ctx server.Context) {
 		Name:        ctx.Request.PostFormValue("name"),
 		Alias:       ctx.Request.PostFormValue("alias"),
 		Description: ctx.Request.PostFormValue("description"),
+		IsComponent: ctx.Request.PostFormValue("is_component"),
 	}, handler.HandlerOptions{
 		RedirectOnSuccess: "/collections",
 		RenderOnFail:      "collection/create_or_edit.tmpl",
@@ -88,6 +89,7 @@ func (ct Controller) editCollection(ctx server.Context) {
 		Name:        ctx.Request.PostFormValue("name"),
 		Alias:       ctx.Request.PostFormValue("alias"),
 		Description: ctx.Request.PostFormValue("description"),
+		IsComponent: ctx.Request.PostFormValue("is_component"),
 	}, handler.HandlerOptions{
 		RedirectOnSuccess: "/collections/",
 		RenderOnFail:      "collection/create_or_edit.tmpl",
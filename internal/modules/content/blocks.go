@@ -0,0 +1,124 @@
+package content
+
+import (
+	"fmt"
+	"html/template"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+)
+
+// blocksKey is posted once per block editor with the form key of its field.
+// Every block of the editor posts "token:component" under the field's key in
+// order, the values of its fields are posted under blockFieldKey.
+const blocksKey = "_blocks"
+
+// blockTokenPlaceholder stands in for the token in the empty blocks the editor
+// copies when a block is added.
+const blockTokenPlaceholder = "__block__"
+
+func blockFieldKey(key, token, alias string) string {
+	return key + "." + token + "." + alias
+}
+
+// encodeBlocks replaces the posted block editors by the JSON values stored for
+// Blocks fields. Entries without a token are kept, validation rejects them.
+func encodeBlocks(form url.Values) url.Values {
+	keys := form[blocksKey]
+	if len(keys) == 0 {
+		return form
+	}
+
+	encoded := maps.Clone(form)
+	for _, key := range keys {
+		blocks := make([]string, 0, len(form[key]))
+		for _, entry := range form[key] {
+			token, component, ok := strings.Cut(entry, ":")
+			if !ok || token == "" {
+				blocks = append(blocks, entry)
+				continue
+			}
+
+			block := model.Block{Component: component, Values: map[string][]string{}}
+			prefix := blockFieldKey(key, token, "")
+			for k, v := range form {
+				if alias, ok := strings.CutPrefix(k, prefix); ok && alias != "" {
+					block.Values[alias] = v
+				}
+			}
+			blocks = append(blocks, block.String())
+		}
+		encoded[key] = blocks
+	}
+
+	return encoded
+}
+
+type BlockItem struct {
+	Key        string
+	Token      string
+	Component  model.Collection
+	FieldsHtml []template.HTML
+}
+
+type BlocksEditor struct {
+	FieldContent
+	Blocks    []BlockItem
+	Templates []BlockItem
+}
+
+func renderBlock(content FieldContent, token string, component model.Collection, values map[string][]string) BlockItem {
+	item := BlockItem{Key: content.Field.Alias, Token: token, Component: component}
+
+	for _, f := range component.Fields {
+		fc := FieldContent{Content: content.Content, Assets: content.Assets}
+		for i, v := range values[f.Alias] {
+			fc.Values = append(fc.Values, model.ContentValue{SortIndex: i + 1, FieldID: f.ID, Value: v})
+		}
+		f.Alias = blockFieldKey(content.Field.Alias, token, f.Alias)
+		fc.Field = f
+
+		html, err := renderField(fc)
+		if err != nil {
+			fmt.Printf("error rendering block field %v: %v\n", f.Alias, err)
+			continue
+		}
+		item.FieldsHtml = append(item.FieldsHtml, html)
+	}
+
+	return item
+}
+
+// blocksEditor renders the stored blocks of a Blocks field and one empty block
+// per component to add.
+func blocksEditor(content FieldContent) BlocksEditor {
+	editor := BlocksEditor{FieldContent: content}
+
+	values := slices.Clone(content.Values)
+	slices.SortStableFunc(values, func(a, b model.ContentValue) int { return a.SortIndex - b.SortIndex })
+
+	for i, cv := range values {
+		block, err := model.ParseBlock(cv.Value)
+		if err != nil {
+			continue
+		}
+
+		component := model.Collection{Name: block.Component, Alias: block.Component}
+		for _, c := range content.Components {
+			if c.Alias == block.Component {
+				component = c
+			}
+		}
+
+		editor.Blocks = append(editor.Blocks, renderBlock(content, fmt.Sprintf("b%d", i+1), component, block.Values))
+	}
+
+	for _, c := range content.Components {
+		editor.Templates = append(editor.Templates, renderBlock(content, blockTokenPlaceholder, c, nil))
+	}
+
+	return editor
+}
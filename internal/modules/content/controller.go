@@ -1,7 +1,12 @@
 package content
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/handler"
@@ -26,6 +31,11 @@ func (ct Controller) RegisterRoutes(s *server.Server) {
 		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
 	)
 
+	s.Handle("GET /content/references", ct.searchReferences,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
 	s.Handle("GET /content/collections/{id}/show", ct.listContent,
 		middleware.Userauth(ct.services.User),
 		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
@@ -55,6 +65,105 @@ func (ct Controller) RegisterRoutes(s *server.Server) {
 		middleware.Userauth(ct.services.User),
 		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
 	)
+
+	s.Handle("POST /content/collections/{id}/publish/{contentID}", ct.publishContent,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
+	s.Handle("POST /content/collections/{id}/unpublish/{contentID}", ct.unpublishContent,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
+	s.Handle("POST /content/collections/{id}/archive/{contentID}", ct.archiveContent,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
+	s.Handle("GET /content/collections/{id}/translations", ct.showMissingTranslations,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
+	s.Handle("GET /content/collections/{id}/revisions/{contentID}", ct.showRevisions,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+
+	s.Handle("POST /content/collections/{id}/revisions/{contentID}/restore/{revisionID}", ct.restoreRevision,
+		middleware.Userauth(ct.services.User),
+		middleware.Roleauth(model.RoleAdmin, model.RoleEditor),
+	)
+}
+
+const scheduleLayout = "2006-01-02T15:04"
+
+func parseScheduleTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(scheduleLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func parseSchedule(form url.Values) (*dto.ContentSchedule, error) {
+	publishAt, err := parseScheduleTime(form.Get("_publish_at"))
+	if err != nil {
+		return nil, err
+	}
+
+	unpublishAt, err := parseScheduleTime(form.Get("_unpublish_at"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ContentSchedule{PublishAt: publishAt, UnpublishAt: unpublishAt}, nil
+}
+
+// parseTranslations collects the values posted under translation keys like
+// "title@de" for the configured locales. It is nil without locales, which
+// keeps the stored translations.
+func (ct *Controller) parseTranslations(form url.Values) map[string]map[string][]string {
+	if !ct.services.Locales.Enabled() {
+		return nil
+	}
+
+	translations := make(map[string]map[string][]string)
+	for key, values := range form {
+		alias, locale, ok := service.SplitTranslationKey(key)
+		if !ok || !ct.services.Locales.IsTranslation(locale) {
+			continue
+		}
+
+		if translations[locale] == nil {
+			translations[locale] = make(map[string][]string)
+		}
+		translations[locale][alias] = values
+	}
+
+	return translations
+}
+
+func userIDFromContext(ctx server.Context) uint {
+	userID, _ := ctx.Request.Context().Value(middleware.UserIDKey).(uint)
+	return userID
+}
+
+// components loads the components for the block editors of the fields.
+func (ct *Controller) components(fields []model.Field) []model.Collection {
+	for _, f := range fields {
+		if f.FieldType == model.FieldTypeBlocks {
+			components, _ := ct.services.Collection.FindComponents()
+			return components
+		}
+	}
+	return nil
 }
 
 func (ct Controller) showCollections(ctx server.Context) {
@@ -69,25 +178,28 @@ func (ct *Controller) showCreateContent(ctx server.Context) {
 
 	fields, errF := ct.services.Field.FindByCollectionID(collectionID)
 	collection, errCol := ct.services.Collection.FindByID(collectionID)
-	contents, errCon := ct.services.Content.FindContentsWithDisplayContentValue()
-	assets, _, errA := ct.services.Asset.List(1, 100000)
-	if errF != nil || errCol != nil || errCon != nil || errA != nil {
+	if errF != nil || errCol != nil || collection.IsComponent {
 		http.Redirect(ctx.Writer, ctx.Request, "/collections", http.StatusSeeOther)
 		return
 	}
 
+	components := ct.components(fields)
 	fieldsContent := make([]FieldContent, 0)
 	for _, field := range fields {
 		fieldsContent = append(fieldsContent, FieldContent{
-			Field:   field,
-			Content: contents,
-			Assets:  assets,
+			Field:      field,
+			Components: components,
 		})
 	}
 
 	utils.RenderWithLayoutHTTP(ctx, "content/create_or_edit.tmpl", map[string]any{
 		"FieldsHtml": RenderFields(fieldsContent),
 		"Collection": collection,
+		"Locale":     ct.services.Locales.Default(),
+		"Translations": RenderTranslations(model.Content{}, DataContext{
+			Collection: model.Collection{Fields: fields},
+			Components: components,
+		}, ct.services.Locales.Translations()),
 	}, http.StatusOK)
 }
 
@@ -98,11 +210,28 @@ func (ct *Controller) createContent(ctx server.Context) {
 		return
 	}
 
-	if _, err := ct.services.Content.CreateWithValues(dto.ContentWithValues{
+	schedule, err := parseSchedule(ctx.Request.PostForm)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	form := encodeBlocks(ctx.Request.PostForm)
+	content, err := ct.services.Content.CreateWithValues(dto.ContentWithValues{
 		CollectionID: collectionID,
-		FormData:     ctx.Request.PostForm,
-	}); err == nil {
+		Schedule:     schedule,
+		UserID:       userIDFromContext(ctx),
+		FormData:     form,
+		Translations: ct.parseTranslations(form),
+	})
+	if verrs, ok := service.AsValidationErrors(err); ok {
+		ct.renderInvalidContent(ctx, collectionID, nil, form, verrs)
+		return
+	}
+
+	if err == nil {
 		ct.services.Webhook.Dispatch(string(model.EventContentCreated), nil)
+		ct.services.Events.Publish(service.EventCreated, content.ID, collectionID)
 	}
 
 	http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
@@ -117,17 +246,123 @@ func (ct *Controller) editContent(ctx server.Context) {
 		return
 	}
 
-	if _, err := ct.services.Content.EditWithValues(dto.ContentWithValues{
+	schedule, err := parseSchedule(ctx.Request.PostForm)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	form := encodeBlocks(ctx.Request.PostForm)
+	_, err = ct.services.Content.EditWithValues(dto.ContentWithValues{
 		CollectionID: colID,
 		ContentID:    conID,
-		FormData:     ctx.Request.PostForm,
-	}); err == nil {
+		Schedule:     schedule,
+		UserID:       userIDFromContext(ctx),
+		FormData:     form,
+		Translations: ct.parseTranslations(form),
+	})
+	if verrs, ok := service.AsValidationErrors(err); ok {
+		entry, findErr := ct.services.Content.FindByID(conID)
+		if findErr != nil {
+			http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+			return
+		}
+
+		entry.PublishAt = schedule.PublishAt
+		entry.UnpublishAt = schedule.UnpublishAt
+		ct.renderInvalidContent(ctx, colID, entry, form, verrs)
+		return
+	}
+
+	if err == nil {
 		ct.services.Webhook.Dispatch(string(model.EventContentUpdated), nil)
+		ct.services.Events.Publish(service.EventUpdated, conID, colID)
 	}
 
 	http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
 }
 
+func formToContent(collection model.Collection, form url.Values, locales []string) model.Content {
+	var content model.Content
+	for _, locale := range append([]string{""}, locales...) {
+		for _, f := range collection.Fields {
+			if locale != "" && !f.Localized {
+				continue
+			}
+
+			for i, v := range form[localeKey(f.Alias, locale)] {
+				content.ContentValues = append(content.ContentValues, model.ContentValue{
+					SortIndex: i + 1,
+					FieldID:   f.ID,
+					Field:     f,
+					Locale:    locale,
+					Value:     v,
+				})
+			}
+		}
+	}
+
+	return content
+}
+
+func (ct *Controller) renderInvalidContent(ctx server.Context, collectionID uint, entry *model.Content, form url.Values, verrs service.ValidationErrors) {
+	collection, err := ct.services.Collection.FindByID(collectionID)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	locales := ct.services.Locales.Translations()
+	submitted := formToContent(*collection, form, locales)
+	components := ct.components(collection.Fields)
+	contents, assets := ct.selectedReferences(submitted.ContentValues, components)
+	dataCtx := DataContext{
+		Collection: *collection,
+		Contents:   contents,
+		Assets:     assets,
+		Components: components,
+		Errors:     verrs,
+	}
+
+	data := map[string]any{
+		"FieldsHtml":   RenderFieldsByContent(submitted, dataCtx),
+		"Collection":   collection,
+		"Errors":       verrs,
+		"Locale":       ct.services.Locales.Default(),
+		"Translations": RenderTranslations(submitted, dataCtx, locales),
+	}
+	if entry != nil {
+		data["Content"] = entry
+	}
+
+	utils.RenderWithLayoutHTTP(ctx, "content/create_or_edit.tmpl", data, http.StatusUnprocessableEntity)
+}
+
+type sortHeader struct {
+	Label     string
+	Key       string
+	Sort      string
+	Direction string
+}
+
+func sortHeaders(current string, headers []sortHeader) []sortHeader {
+	primary, _, _ := strings.Cut(current, ",")
+	primary = strings.TrimSpace(primary)
+
+	for i, h := range headers {
+		switch primary {
+		case h.Key:
+			headers[i].Sort, headers[i].Direction = "-"+h.Key, "asc"
+		case "-" + h.Key:
+			headers[i].Sort, headers[i].Direction = h.Key, "desc"
+		default:
+			headers[i].Sort = h.Key
+		}
+	}
+
+	return headers
+}
+
 func (ct *Controller) listContent(ctx server.Context) {
 	collectionID, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "id")
 	if !ok {
@@ -135,8 +370,14 @@ func (ct *Controller) listContent(ctx server.Context) {
 	}
 
 	page, pageSize := utils.ParsePagination(ctx.Request)
+	sort := strings.TrimSpace(ctx.Request.URL.Query().Get("sort"))
+	query := strings.TrimSpace(ctx.Request.URL.Query().Get("q"))
 
-	contents, totalCount, err := ct.services.Content.FindDisplayValueByCollectionID(collectionID, page, pageSize)
+	contents, totalCount, err := ct.services.Content.FindDisplayValueByCollectionID(collectionID, page, pageSize, sort, query)
+	if errors.Is(err, service.ErrInvalidSort) {
+		http.Redirect(ctx.Writer, ctx.Request, fmt.Sprintf("/content/collections/%d/show", collectionID), http.StatusSeeOther)
+		return
+	}
 	if err != nil {
 		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
 		return
@@ -152,14 +393,49 @@ func (ct *Controller) listContent(ctx server.Context) {
 
 	totalPages := utils.CalcTotalPages(totalCount, pageSize)
 
+	headers := []sortHeader{{Label: "ID", Key: "id"}, {Label: "Status", Key: "status"}}
+	for _, f := range fields {
+		headers = append(headers, sortHeader{Label: f.Name, Key: f.Alias})
+	}
+	headers = append(headers, sortHeader{Label: "Updated", Key: "updated_at"})
+
 	utils.RenderWithLayoutHTTP(ctx, "content/content_list.tmpl", map[string]any{
 		"Groups":       groups,
 		"Fields":       fields,
+		"Sort":         sort,
+		"Query":        query,
+		"SortHeaders":  sortHeaders(sort, headers),
 		"CollectionID": collectionID,
 		"TotalCount":   totalCount,
 		"TotalPages":   totalPages,
 		"CurrentPage":  page,
 		"PageSize":     pageSize,
+		"Localized":    ct.services.Locales.Enabled(),
+	}, http.StatusOK)
+}
+
+func (ct *Controller) showMissingTranslations(ctx server.Context) {
+	collectionID, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "id")
+	if !ok {
+		return
+	}
+
+	collection, err := ct.services.Collection.FindByID(collectionID)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	missing, err := ct.services.Content.MissingTranslations(collectionID, ct.services.Locales.Default(), ct.services.Locales.Translations())
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	utils.RenderWithLayoutHTTP(ctx, "content/translations.tmpl", map[string]any{
+		"Collection": collection,
+		"Locales":    ct.services.Locales.Translations(),
+		"Missing":    missing,
 	}, http.StatusOK)
 }
 
@@ -182,17 +458,21 @@ func (ct *Controller) showEditContent(ctx server.Context) {
 		return
 	}
 
-	contents, err := ct.services.Content.FindContentsWithDisplayContentValue()
-	assets, _, err := ct.services.Asset.List(1, 100000)
+	components := ct.components(collection.Fields)
+	contents, assets := ct.selectedReferences(contentEntry.ContentValues, components)
+	dataCtx := DataContext{
+		Collection: *collection,
+		Contents:   contents,
+		Assets:     assets,
+		Components: components,
+	}
 
 	utils.RenderWithLayoutHTTP(ctx, "content/create_or_edit.tmpl", map[string]any{
-		"FieldsHtml": RenderFieldsByContent(*contentEntry, DataContext{
-			Collection: *collection,
-			Contents:   contents,
-			Assets:     assets,
-		}),
-		"Collection": collection,
-		"Content":    contentEntry,
+		"FieldsHtml":   RenderFieldsByContent(*contentEntry, dataCtx),
+		"Collection":   collection,
+		"Content":      contentEntry,
+		"Locale":       ct.services.Locales.Default(),
+		"Translations": RenderTranslations(*contentEntry, dataCtx, ct.services.Locales.Translations()),
 	}, http.StatusOK)
 }
 
@@ -203,8 +483,128 @@ func (ct *Controller) deleteContent(ctx server.Context) {
 	}
 
 	if err := ct.services.Content.DeleteByID(id); err == nil {
+		collectionID, _ := utils.StringToUint(ctx.Request.PathValue("id"))
 		ct.services.Webhook.Dispatch(string(model.EventContentDeleted), nil)
+		ct.services.Events.Publish(service.EventDeleted, id, collectionID)
 	}
 
 	http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
 }
+
+func (ct *Controller) publishContent(ctx server.Context) {
+	ct.changeStatus(ctx, model.ContentStatusPublished)
+}
+
+func (ct *Controller) unpublishContent(ctx server.Context) {
+	ct.changeStatus(ctx, model.ContentStatusDraft)
+}
+
+func (ct *Controller) archiveContent(ctx server.Context) {
+	ct.changeStatus(ctx, model.ContentStatusArchived)
+}
+
+func (ct *Controller) changeStatus(ctx server.Context, status model.ContentStatus) {
+	collectionID, okCol := utils.GetParamOrRedirect(ctx, "/content/collections", "id")
+	if !okCol {
+		return
+	}
+
+	id, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "contentID")
+	if !ok {
+		return
+	}
+
+	content, err := ct.services.Content.FindByID(id)
+	if err != nil || content.CollectionID != collectionID {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	previousStatus := content.Status
+	if _, err := ct.services.Content.UpdateStatus(id, status); err == nil {
+		ct.services.Events.Publish(service.EventUpdated, id, content.CollectionID)
+		if event, ok := model.StatusChangeEvent(previousStatus, status); ok {
+			ct.services.Webhook.Dispatch(string(event), nil)
+		}
+	}
+
+	http.Redirect(ctx.Writer, ctx.Request, fmt.Sprintf("/content/collections/%d/edit/%d", collectionID, id), http.StatusSeeOther)
+}
+
+func (ct *Controller) showRevisions(ctx server.Context) {
+	collectionID, okCol := utils.GetParamOrRedirect(ctx, "/content/collections", "id")
+	if !okCol {
+		return
+	}
+
+	id, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "contentID")
+	if !ok {
+		return
+	}
+
+	ct.renderRevisions(ctx, collectionID, id, nil, http.StatusOK)
+}
+
+func (ct *Controller) renderRevisions(ctx server.Context, collectionID, contentID uint, restoreErr error, status int) {
+	collection, err := ct.services.Collection.FindByID(collectionID)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	entries, err := ct.services.Revision.History(contentID)
+	if err != nil {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	data := map[string]any{
+		"Collection": collection,
+		"ContentID":  contentID,
+		"Entries":    entries,
+	}
+	if verrs, ok := service.AsValidationErrors(restoreErr); ok {
+		data["Errors"] = verrs
+	} else if restoreErr != nil {
+		data["Error"] = restoreErr.Error()
+	}
+
+	utils.RenderWithLayoutHTTP(ctx, "content/revisions.tmpl", data, status)
+}
+
+func (ct *Controller) restoreRevision(ctx server.Context) {
+	collectionID, okCol := utils.GetParamOrRedirect(ctx, "/content/collections", "id")
+	if !okCol {
+		return
+	}
+
+	id, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "contentID")
+	if !ok {
+		return
+	}
+
+	revisionID, ok := utils.GetParamOrRedirect(ctx, "/content/collections", "revisionID")
+	if !ok {
+		return
+	}
+
+	content, err := ct.services.Content.FindByID(id)
+	if err != nil || content.CollectionID != collectionID {
+		http.Redirect(ctx.Writer, ctx.Request, "/content/collections", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := ct.services.Content.RestoreRevision(id, revisionID, userIDFromContext(ctx)); err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := service.AsValidationErrors(err); ok {
+			status = http.StatusUnprocessableEntity
+		}
+		ct.renderRevisions(ctx, collectionID, id, err, status)
+		return
+	}
+
+	ct.services.Webhook.Dispatch(string(model.EventContentUpdated), nil)
+	ct.services.Events.Publish(service.EventUpdated, id, content.CollectionID)
+
+	http.Redirect(ctx.Writer, ctx.Request, fmt.Sprintf("/content/collections/%d/revisions/%d", collectionID, id), http.StatusSeeOther)
+}
@@ -1,13 +1,17 @@
 package content
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/middleware"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/server"
 	"github.com/janmarkuslanger/nuricms/internal/service"
@@ -28,6 +32,8 @@ func setup(t *testing.T) (*server.Server, *httptest.ResponseRecorder, *testutils
 	mockAsset := &testutils.MockAssetService{}
 	mockWebhook := &testutils.MockWebhookService{}
 	mockUser := &mockservices.MockUserService{}
+	mockEvents := &testutils.MockEventService{}
+	mockEvents.On("Publish", mock.Anything, mock.Anything, mock.Anything).Maybe()
 
 	services := &service.Set{
 		Collection: mockColl,
@@ -36,17 +42,22 @@ func setup(t *testing.T) (*server.Server, *httptest.ResponseRecorder, *testutils
 		Asset:      mockAsset,
 		Webhook:    mockWebhook,
 		User:       mockUser,
+		Events:     mockEvents,
 	}
 
 	ctrl := NewController(services)
 
 	srv.Handle("GET /content/collections", ctrl.showCollections)
+	srv.Handle("GET /content/references", ctrl.searchReferences)
 	srv.Handle("GET /content/collections/{id}/show", ctrl.listContent)
 	srv.Handle("GET /content/collections/{id}/create", ctrl.showCreateContent)
 	srv.Handle("POST /content/collections/{id}/create", ctrl.createContent)
 	srv.Handle("GET /content/collections/{id}/edit/{contentID}", ctrl.showEditContent)
 	srv.Handle("POST /content/collections/{id}/edit/{contentID}", ctrl.editContent)
 	srv.Handle("POST /content/collections/{id}/delete/{contentID}", ctrl.deleteContent)
+	srv.Handle("POST /content/collections/{id}/publish/{contentID}", ctrl.publishContent)
+	srv.Handle("POST /content/collections/{id}/unpublish/{contentID}", ctrl.unpublishContent)
+	srv.Handle("POST /content/collections/{id}/archive/{contentID}", ctrl.archiveContent)
 
 	return srv, rec, mockColl, mockCont, mockField, mockAsset, mockWebhook
 }
@@ -146,7 +157,7 @@ func Test_deleteContent_paramredirect(t *testing.T) {
 }
 
 func Test_showCreateContent_success(t *testing.T) {
-	srv, rec, mockColl, mockContent, mockField, mockAsset, _ := setup(t)
+	srv, rec, mockColl, _, mockField, _, _ := setup(t)
 
 	collectionID := uint(1)
 
@@ -156,10 +167,6 @@ func Test_showCreateContent_success(t *testing.T) {
 
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{}, nil)
 
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
-
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/create", nil)
 	srv.ServeHTTP(rec, req)
 
@@ -169,7 +176,7 @@ func Test_showCreateContent_success(t *testing.T) {
 }
 
 func Test_showCreateContent_paramredirect(t *testing.T) {
-	srv, rec, mockColl, mockContent, mockField, mockAsset, _ := setup(t)
+	srv, rec, mockColl, _, mockField, _, _ := setup(t)
 
 	collectionID := uint(1)
 
@@ -179,10 +186,6 @@ func Test_showCreateContent_paramredirect(t *testing.T) {
 
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{}, nil)
 
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
-
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/asdfsadf/create", nil)
 	srv.ServeHTTP(rec, req)
 
@@ -192,7 +195,7 @@ func Test_showCreateContent_paramredirect(t *testing.T) {
 }
 
 func Test_showCreateContent_notfound(t *testing.T) {
-	srv, rec, mockColl, mockContent, mockField, mockAsset, _ := setup(t)
+	srv, rec, mockColl, _, mockField, _, _ := setup(t)
 
 	collectionID := uint(1)
 
@@ -202,10 +205,6 @@ func Test_showCreateContent_notfound(t *testing.T) {
 
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{}, errors.New("asd"))
 
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
-
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/asdfsadf/create", nil)
 	srv.ServeHTTP(rec, req)
 
@@ -221,7 +220,7 @@ func Test_listContent_success(t *testing.T) {
 	page := 1
 	pageSize := 10
 
-	mockContent.On("FindDisplayValueByCollectionID", collectionID, page, pageSize).
+	mockContent.On("FindDisplayValueByCollectionID", collectionID, page, pageSize, "", "").
 		Return([]model.Content{}, int64(0), nil)
 
 	mockField.On("FindDisplayFieldsByCollectionID", collectionID).
@@ -233,16 +232,73 @@ func Test_listContent_success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+func Test_listContent_sortHeaders(t *testing.T) {
+	srv, rec, _, mockContent, mockField, _, _ := setup(t)
+
+	mockContent.On("FindDisplayValueByCollectionID", uint(1), 1, 10, "-title,id", "").
+		Return([]model.Content{{Model: gorm.Model{ID: 3}}}, int64(1), nil)
+	mockField.On("FindDisplayFieldsByCollectionID", uint(1)).
+		Return([]model.Field{{Name: "Title", Alias: "title"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/show?sort=-title,id", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `data-sort="title"`)
+	assert.Contains(t, body, `data-sort="id"`)
+	assert.Contains(t, body, `data-sort="updated_at"`)
+	assert.Contains(t, body, "&#9660;")
+	mockContent.AssertExpectations(t)
+}
+
+func Test_listContent_search(t *testing.T) {
+	srv, rec, _, mockContent, mockField, _, _ := setup(t)
+
+	mockContent.On("FindDisplayValueByCollectionID", uint(1), 1, 10, "", "sourdough bread").
+		Return([]model.Content{{Model: gorm.Model{ID: 3}}}, int64(1), nil)
+	mockField.On("FindDisplayFieldsByCollectionID", uint(1)).Return([]model.Field{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/show?q=+sourdough+bread", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `name="q" value="sourdough bread"`)
+	assert.Contains(t, body, "q=sourdough&#43;bread")
+	mockContent.AssertExpectations(t)
+}
+
+func Test_listContent_invalidSortRedirects(t *testing.T) {
+	srv, rec, _, mockContent, _, _, _ := setup(t)
+
+	mockContent.On("FindDisplayValueByCollectionID", uint(1), 1, 10, "nope", "").
+		Return([]model.Content{}, int64(0), fmt.Errorf("%w: unknown sort key", service.ErrInvalidSort))
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/show?sort=nope", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections/1/show", rec.Header().Get("Location"))
+}
+
+func Test_sortHeaders(t *testing.T) {
+	headers := sortHeaders("-title,id", []sortHeader{{Label: "ID", Key: "id"}, {Label: "Title", Key: "title"}})
+	assert.Equal(t, sortHeader{Label: "ID", Key: "id", Sort: "id"}, headers[0])
+	assert.Equal(t, sortHeader{Label: "Title", Key: "title", Sort: "title", Direction: "desc"}, headers[1])
+
+	headers = sortHeaders("id", []sortHeader{{Label: "ID", Key: "id"}})
+	assert.Equal(t, sortHeader{Label: "ID", Key: "id", Sort: "-id", Direction: "asc"}, headers[0])
+}
+
 func Test_showEditContent_success(t *testing.T) {
-	srv, rec, mockColl, mockContent, _, mockAsset, _ := setup(t)
+	srv, rec, mockColl, mockContent, _, _, _ := setup(t)
 
 	collectionID := uint(1)
 	contentID := uint(42)
 
 	mockContent.On("FindByID", contentID).Return(&model.Content{Model: gorm.Model{ID: contentID}}, nil)
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{Model: gorm.Model{ID: collectionID}}, nil)
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/42", nil)
 	srv.ServeHTTP(rec, req)
@@ -251,15 +307,13 @@ func Test_showEditContent_success(t *testing.T) {
 }
 
 func Test_showEditContent_paramredirect(t *testing.T) {
-	srv, rec, mockColl, mockContent, _, mockAsset, _ := setup(t)
+	srv, rec, mockColl, mockContent, _, _, _ := setup(t)
 
 	collectionID := uint(1)
 	contentID := uint(42)
 
 	mockContent.On("FindByID", contentID).Return(&model.Content{Model: gorm.Model{ID: contentID}}, nil)
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{Model: gorm.Model{ID: collectionID}}, nil)
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/qwe", nil)
 	srv.ServeHTTP(rec, req)
@@ -268,15 +322,13 @@ func Test_showEditContent_paramredirect(t *testing.T) {
 }
 
 func Test_showEditContent_contentnotfound(t *testing.T) {
-	srv, rec, mockColl, mockContent, _, mockAsset, _ := setup(t)
+	srv, rec, mockColl, mockContent, _, _, _ := setup(t)
 
 	collectionID := uint(1)
 	contentID := uint(42)
 
 	mockContent.On("FindByID", contentID).Return(&model.Content{Model: gorm.Model{ID: contentID}}, errors.New("no no"))
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{Model: gorm.Model{ID: collectionID}}, nil)
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/qwe", nil)
 	srv.ServeHTTP(rec, req)
@@ -285,18 +337,439 @@ func Test_showEditContent_contentnotfound(t *testing.T) {
 }
 
 func Test_showEditContent_collectionnotfound(t *testing.T) {
-	srv, rec, mockColl, mockContent, _, mockAsset, _ := setup(t)
+	srv, rec, mockColl, mockContent, _, _, _ := setup(t)
 
 	collectionID := uint(1)
 	contentID := uint(42)
 
 	mockContent.On("FindByID", contentID).Return(&model.Content{Model: gorm.Model{ID: contentID}}, nil)
 	mockColl.On("FindByID", collectionID).Return(&model.Collection{Model: gorm.Model{ID: collectionID}}, errors.New("no col"))
-	mockContent.On("FindContentsWithDisplayContentValue").Return([]model.Content{}, nil)
-	mockAsset.On("List", 1, 100000).Return([]model.Asset{}, int64(0), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/qwe", nil)
 	srv.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusSeeOther, rec.Code)
 }
+
+func Test_publishContent_success(t *testing.T) {
+	srv, rec, _, mockCont, _, _, mockWebhook := setup(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1, Status: model.ContentStatusDraft}, nil)
+	mockCont.On("UpdateStatus", uint(2), model.ContentStatusPublished).Return(&model.Content{}, nil)
+	mockWebhook.On("Dispatch", string(model.EventContentPublished), mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/publish/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections/1/edit/2", rec.Header().Get("Location"))
+	mockWebhook.AssertExpectations(t)
+}
+
+func Test_unpublishContent_success(t *testing.T) {
+	srv, rec, _, mockCont, _, _, mockWebhook := setup(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1, Status: model.ContentStatusPublished}, nil)
+	mockCont.On("UpdateStatus", uint(2), model.ContentStatusDraft).Return(&model.Content{}, nil)
+	mockWebhook.On("Dispatch", string(model.EventContentUnpublished), mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/unpublish/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	mockWebhook.AssertExpectations(t)
+}
+
+func Test_archiveContent_draftDispatchesNothing(t *testing.T) {
+	srv, rec, _, mockCont, _, _, mockWebhook := setup(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1, Status: model.ContentStatusDraft}, nil)
+	mockCont.On("UpdateStatus", uint(2), model.ContentStatusArchived).Return(&model.Content{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/archive/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_publishContent_otherCollection(t *testing.T) {
+	srv, rec, _, mockCont, _, _, mockWebhook := setup(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 7, Status: model.ContentStatusDraft}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/publish/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections", rec.Header().Get("Location"))
+	mockCont.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_publishContent_notfound(t *testing.T) {
+	srv, rec, _, mockCont, _, _, _ := setup(t)
+
+	mockCont.On("FindByID", uint(2)).Return(nil, errors.New("not found"))
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/publish/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections", rec.Header().Get("Location"))
+	mockCont.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func setupRevisions(t *testing.T) (*server.Server, *httptest.ResponseRecorder, *testutils.MockCollectionService, *testutils.MockContentService, *testutils.MockContentRevisionService, *testutils.MockWebhookService) {
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	mockColl := &testutils.MockCollectionService{}
+	mockCont := &testutils.MockContentService{}
+	mockRev := &testutils.MockContentRevisionService{}
+	mockWebhook := &testutils.MockWebhookService{}
+	mockEvents := &testutils.MockEventService{}
+	mockEvents.On("Publish", service.EventUpdated, uint(2), uint(1)).Maybe()
+
+	ctrl := NewController(&service.Set{
+		Collection: mockColl,
+		Content:    mockCont,
+		Revision:   mockRev,
+		Webhook:    mockWebhook,
+		Events:     mockEvents,
+	})
+
+	srv.Handle("GET /content/collections/{id}/revisions/{contentID}", ctrl.showRevisions)
+	srv.Handle("POST /content/collections/{id}/revisions/{contentID}/restore/{revisionID}", ctrl.restoreRevision)
+
+	return srv, rec, mockColl, mockCont, mockRev, mockWebhook
+}
+
+func Test_showRevisions_success(t *testing.T) {
+	srv, rec, mockColl, _, mockRev, _ := setupRevisions(t)
+
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Name: "Posts"}, nil)
+	mockRev.On("History", uint(2)).Return([]dto.RevisionEntry{
+		{
+			Revision: model.ContentRevision{Model: gorm.Model{ID: 4}, ContentID: 2},
+			Author:   "editor@example.com",
+			Changes:  []dto.FieldChange{{Alias: "title", Name: "Title", Before: []string{"Old"}, After: []string{"New"}}},
+		},
+		{
+			Revision: model.ContentRevision{Model: gorm.Model{ID: 3}, ContentID: 2},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/revisions/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "editor@example.com")
+	assert.Contains(t, body, "Old")
+	assert.Contains(t, body, "/content/collections/1/revisions/2/restore/3")
+	assert.NotContains(t, body, "/content/collections/1/revisions/2/restore/4")
+}
+
+func Test_showRevisions_notfound(t *testing.T) {
+	srv, rec, mockColl, _, mockRev, _ := setupRevisions(t)
+
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}}, nil)
+	mockRev.On("History", uint(2)).Return(nil, errors.New("not found"))
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/revisions/2", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections", rec.Header().Get("Location"))
+}
+
+func Test_restoreRevision_success(t *testing.T) {
+	srv, rec, _, mockCont, _, mockWebhook := setupRevisions(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1}, nil)
+	mockCont.On("RestoreRevision", uint(2), uint(3), uint(5)).Return(&model.Content{}, nil)
+	mockWebhook.On("Dispatch", string(model.EventContentUpdated), mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/revisions/2/restore/3", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uint(5)))
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections/1/revisions/2", rec.Header().Get("Location"))
+	mockCont.AssertExpectations(t)
+	mockWebhook.AssertExpectations(t)
+}
+
+func Test_restoreRevision_failed(t *testing.T) {
+	srv, rec, mockColl, mockCont, mockRev, mockWebhook := setupRevisions(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1}, nil)
+	mockCont.On("RestoreRevision", uint(2), uint(3), uint(0)).Return(nil, errors.New("revision doesnt relate to content"))
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Name: "Posts"}, nil)
+	mockRev.On("History", uint(2)).Return([]dto.RevisionEntry{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/revisions/2/restore/3", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "revision doesnt relate to content")
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_restoreRevision_otherCollection(t *testing.T) {
+	srv, rec, _, mockCont, _, mockWebhook := setupRevisions(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 7}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/revisions/2/restore/3", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/content/collections", rec.Header().Get("Location"))
+	mockCont.AssertNotCalled(t, "RestoreRevision", mock.Anything, mock.Anything, mock.Anything)
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_restoreRevision_invalid(t *testing.T) {
+	srv, rec, mockColl, mockCont, mockRev, mockWebhook := setupRevisions(t)
+
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1}, nil)
+	mockCont.On("RestoreRevision", uint(2), uint(3), uint(0)).Return(nil, service.ValidationErrors{
+		"price": {"must be a number"},
+	})
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Name: "Posts"}, nil)
+	mockRev.On("History", uint(2)).Return([]dto.RevisionEntry{
+		{Revision: model.ContentRevision{Model: gorm.Model{ID: 4}, ContentID: 2}},
+		{Revision: model.ContentRevision{Model: gorm.Model{ID: 3}, ContentID: 2}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/revisions/2/restore/3", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "price: must be a number")
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_editContent_passesSchedule(t *testing.T) {
+	srv, rec, _, mockCont, _, _, mockWebhook := setup(t)
+
+	form := url.Values{}
+	form.Add("_publish_at", "2030-01-02T15:04")
+
+	mockCont.On("EditWithValues", mock.MatchedBy(func(cwv dto.ContentWithValues) bool {
+		return cwv.Schedule != nil &&
+			cwv.Schedule.PublishAt != nil &&
+			cwv.Schedule.PublishAt.Year() == 2030 &&
+			cwv.Schedule.UnpublishAt == nil
+	})).Return(&model.Content{}, nil)
+	mockWebhook.On("Dispatch", string(model.EventContentUpdated), mock.Anything).Return()
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/edit/2", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	mockCont.AssertExpectations(t)
+}
+
+func Test_editContent_invalidSchedule(t *testing.T) {
+	srv, rec, _, mockCont, _, _, _ := setup(t)
+
+	form := url.Values{}
+	form.Add("_publish_at", "tomorrow")
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/edit/2", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	mockCont.AssertNotCalled(t, "EditWithValues", mock.Anything)
+}
+
+func Test_createContent_validationErrors(t *testing.T) {
+	srv, rec, mockColl, mockCont, _, _, mockWebhook := setup(t)
+
+	form := url.Values{}
+	form.Add("title", "x")
+
+	mockCont.On("CreateWithValues", mock.Anything).Return(nil, service.ValidationErrors{
+		"title": {"must be at least 3 characters"},
+	})
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{
+		Model: gorm.Model{ID: 1},
+		Fields: []model.Field{
+			{Model: gorm.Model{ID: 1}, Name: "Title", Alias: "title", FieldType: model.FieldTypeText},
+		},
+	}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/create", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "must be at least 3 characters")
+	assert.Contains(t, body, `value="x"`)
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func Test_createContent_blocks(t *testing.T) {
+	srv, rec, mockColl, mockCont, _, _, _ := setup(t)
+
+	form := url.Values{}
+	form.Add("_blocks", "body")
+	form.Add("body", "n1:hero")
+	form.Add("body", "b1:text")
+	form.Add("body.n1.title", "")
+	form.Add("body.b1.text", "Hello")
+
+	mockCont.On("CreateWithValues", mock.MatchedBy(func(data dto.ContentWithValues) bool {
+		return assert.ObjectsAreEqual([]string{
+			`{"component":"hero","values":{"title":[""]}}`,
+			`{"component":"text","values":{"text":["Hello"]}}`,
+		}, data.FormData["body"])
+	})).Return(nil, service.ValidationErrors{
+		"body": {"block 1 (Hero), Title: is required"},
+	})
+
+	hero := model.Collection{Name: "Hero", Alias: "hero", IsComponent: true, Fields: []model.Field{
+		{Name: "Title", Alias: "title", FieldType: model.FieldTypeText},
+	}}
+	text := model.Collection{Name: "Text", Alias: "text", IsComponent: true, Fields: []model.Field{
+		{Name: "Text", Alias: "text", FieldType: model.FieldTypeTextarea},
+	}}
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{
+		Model:  gorm.Model{ID: 1},
+		Fields: []model.Field{{Model: gorm.Model{ID: 1}, Name: "Body", Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true}},
+	}, nil)
+	mockColl.On("FindComponents").Return([]model.Collection{hero, text}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/create", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "block 1 (Hero), Title: is required")
+	assert.Contains(t, body, `value="b1:hero"`)
+	assert.Contains(t, body, `name="body.b2.text"`)
+	assert.Contains(t, body, "Hello")
+	assert.Contains(t, body, `data-block-template="hero"`)
+	mockCont.AssertExpectations(t)
+}
+
+func Test_editContent_validationErrors(t *testing.T) {
+	srv, rec, mockColl, mockCont, _, _, mockWebhook := setup(t)
+
+	form := url.Values{}
+	form.Add("price", "abc")
+
+	mockCont.On("EditWithValues", mock.Anything).Return(nil, service.ValidationErrors{
+		"price": {"must be a number"},
+	})
+	mockCont.On("FindByID", uint(2)).Return(&model.Content{Model: gorm.Model{ID: 2}, CollectionID: 1, Status: model.ContentStatusDraft}, nil)
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{
+		Model: gorm.Model{ID: 1},
+		Fields: []model.Field{
+			{Model: gorm.Model{ID: 1}, Name: "Price", Alias: "price", FieldType: model.FieldTypeNumber},
+		},
+	}, nil)
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/edit/2", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "must be a number")
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func setupLocales(t *testing.T) (*server.Server, *httptest.ResponseRecorder, *testutils.MockCollectionService, *testutils.MockContentService, *testutils.MockAssetService) {
+	srv := server.NewServer()
+	rec := httptest.NewRecorder()
+
+	locales, err := service.NewLocales([]string{"en", "de"})
+	assert.NoError(t, err)
+
+	mockColl := &testutils.MockCollectionService{}
+	mockCont := &testutils.MockContentService{}
+	mockAsset := &testutils.MockAssetService{}
+	mockWebhook := &testutils.MockWebhookService{}
+	mockWebhook.On("Dispatch", mock.Anything, mock.Anything).Return().Maybe()
+	mockEvents := &testutils.MockEventService{}
+	mockEvents.On("Publish", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	ctrl := NewController(&service.Set{
+		Collection: mockColl,
+		Content:    mockCont,
+		Asset:      mockAsset,
+		Webhook:    mockWebhook,
+		Events:     mockEvents,
+		Locales:    locales,
+	})
+
+	srv.Handle("POST /content/collections/{id}/create", ctrl.createContent)
+	srv.Handle("GET /content/collections/{id}/edit/{contentID}", ctrl.showEditContent)
+	srv.Handle("GET /content/collections/{id}/translations", ctrl.showMissingTranslations)
+
+	return srv, rec, mockColl, mockCont, mockAsset
+}
+
+func Test_createContent_translations(t *testing.T) {
+	srv, rec, _, mockCont, _ := setupLocales(t)
+
+	form := url.Values{}
+	form.Add("title", "Hello")
+	form.Add("title@de", "Hallo")
+	form.Add("title@fr", "Bonjour")
+
+	mockCont.On("CreateWithValues", mock.MatchedBy(func(data dto.ContentWithValues) bool {
+		return assert.ObjectsAreEqual(map[string]map[string][]string{"de": {"title": {"Hallo"}}}, data.Translations)
+	})).Return(&model.Content{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/content/collections/1/create", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	mockCont.AssertExpectations(t)
+}
+
+func Test_showEditContent_translations(t *testing.T) {
+	srv, rec, mockColl, mockCont, _ := setupLocales(t)
+
+	title := model.Field{Model: gorm.Model{ID: 1}, Name: "Title", Alias: "title", FieldType: model.FieldTypeText, Localized: true}
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Fields: []model.Field{title}}, nil)
+	mockCont.On("FindByID", uint(42)).Return(&model.Content{
+		Model: gorm.Model{ID: 42},
+		ContentValues: []model.ContentValue{
+			{Value: "Hello", FieldID: 1, Field: title},
+			{Value: "Hallo", FieldID: 1, Field: title, Locale: "de"},
+		},
+	}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/42", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `data-locale-panel="de"`)
+	assert.Contains(t, body, `name="title@de"`)
+	assert.Contains(t, body, "Hallo")
+}
+
+func Test_showMissingTranslations(t *testing.T) {
+	srv, rec, mockColl, mockCont, _ := setupLocales(t)
+
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Name: "Posts"}, nil)
+	mockCont.On("MissingTranslations", uint(1), "en", []string{"de"}).Return([]dto.MissingTranslation{
+		{ContentID: 7, Label: "Hello", Locale: "de", Fields: []string{"Title", "Teaser"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/translations", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "Title, Teaser")
+	assert.Contains(t, body, "/content/collections/1/edit/7")
+}
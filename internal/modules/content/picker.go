@@ -0,0 +1,100 @@
+package content
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/utils"
+)
+
+const pickerPageSize = 20
+
+// searchReferences answers the entry pickers of the editor, optionally
+// limited to the target collections posted as "collection".
+func (ct *Controller) searchReferences(ctx server.Context) {
+	query := ctx.Request.URL.Query()
+	page, _ := utils.ParsePagination(ctx.Request)
+	if page < 1 {
+		page = 1
+	}
+
+	var collectionIDs []uint
+	for _, v := range query["collection"] {
+		if id, ok := utils.StringToUint(v); ok {
+			collectionIDs = append(collectionIDs, id)
+		}
+	}
+
+	contents, total, err := ct.services.Content.SearchReferences(strings.TrimSpace(query.Get("q")), collectionIDs, page, pickerPageSize)
+	if err != nil {
+		http.Error(ctx.Writer, "search failed", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]dto.PickerItem, 0, len(contents))
+	for _, c := range contents {
+		items = append(items, dto.PickerItem{ID: c.ID, Label: c.DisplayLabel()})
+	}
+
+	utils.RenderJSON(ctx, dto.PickerResponse{
+		Items:   items,
+		HasMore: int64(page*pickerPageSize) < total,
+	}, http.StatusOK)
+}
+
+// selectedReferences loads the entries and assets the values point to, also
+// from within blocks, so the pickers can name what is selected.
+func (ct *Controller) selectedReferences(values []model.ContentValue, components []model.Collection) ([]model.Content, []model.Asset) {
+	var contentIDs, assetIDs []uint
+	collect := func(fieldType model.FieldType, value string) {
+		id, ok := utils.StringToUint(value)
+		if !ok {
+			return
+		}
+
+		switch fieldType {
+		case model.FieldTypeCollection:
+			contentIDs = append(contentIDs, id)
+		case model.FieldTypeAsset:
+			assetIDs = append(assetIDs, id)
+		}
+	}
+
+	for _, cv := range values {
+		if cv.Field.FieldType != model.FieldTypeBlocks {
+			collect(cv.Field.FieldType, cv.Value)
+			continue
+		}
+
+		block, err := model.ParseBlock(cv.Value)
+		if err != nil {
+			continue
+		}
+
+		for _, c := range components {
+			if c.Alias != block.Component {
+				continue
+			}
+			for _, f := range c.Fields {
+				for _, v := range block.Values[f.Alias] {
+					collect(f.FieldType, v)
+				}
+			}
+		}
+	}
+
+	var contents []model.Content
+	if len(contentIDs) > 0 {
+		contents, _ = ct.services.Content.FindReferences(contentIDs)
+	}
+
+	var assets []model.Asset
+	if len(assetIDs) > 0 {
+		assets, _ = ct.services.Asset.FindByIDs(assetIDs)
+	}
+
+	return contents, assets
+}
@@ -0,0 +1,77 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_searchReferences(t *testing.T) {
+	srv, rec, _, mockCont, _, _, _ := setup(t)
+
+	jane := model.Content{
+		Model:         gorm.Model{ID: 7},
+		Collection:    model.Collection{Name: "Author"},
+		ContentValues: []model.ContentValue{{Value: "Jane"}},
+	}
+	mockCont.On("SearchReferences", "jane", []uint{2, 3}, 1, pickerPageSize).Return([]model.Content{jane}, int64(21), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/references?q=jane&collection=2&collection=3&collection=x", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"items":[{"id":7,"label":"Author: Jane"}],"has_more":true}`, rec.Body.String())
+	mockCont.AssertExpectations(t)
+}
+
+func Test_searchReferences_error(t *testing.T) {
+	srv, rec, _, mockCont, _, _, _ := setup(t)
+	mockCont.On("SearchReferences", "", []uint(nil), 1, pickerPageSize).Return([]model.Content{}, int64(0), errors.New("db down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/content/references", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func Test_showEditContent_selectedReferences(t *testing.T) {
+	srv, rec, mockColl, mockCont, _, mockAsset, _ := setup(t)
+
+	author := model.Field{Model: gorm.Model{ID: 1}, Name: "Author", Alias: "author", FieldType: model.FieldTypeCollection}
+	image := model.Field{Model: gorm.Model{ID: 2}, Name: "Image", Alias: "image", FieldType: model.FieldTypeAsset}
+	body := model.Field{Model: gorm.Model{ID: 3}, Name: "Body", Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true}
+	teaser := model.Collection{Name: "Teaser", Alias: "teaser", IsComponent: true, Fields: []model.Field{
+		{Name: "Link", Alias: "link", FieldType: model.FieldTypeCollection},
+	}}
+
+	entry := &model.Content{Model: gorm.Model{ID: 42}, ContentValues: []model.ContentValue{
+		{Field: author, Value: "7"},
+		{Field: image, Value: "4"},
+		{Field: body, Value: `{"component":"teaser","values":{"link":["8"]}}`},
+	}}
+	mockCont.On("FindByID", uint(42)).Return(entry, nil)
+	mockColl.On("FindByID", uint(1)).Return(&model.Collection{Model: gorm.Model{ID: 1}, Fields: []model.Field{author, image, body}}, nil)
+	mockColl.On("FindComponents").Return([]model.Collection{teaser}, nil)
+	mockCont.On("FindReferences", []uint{7, 8}).Return([]model.Content{
+		{Model: gorm.Model{ID: 7}, Collection: model.Collection{Name: "Author"}, ContentValues: []model.ContentValue{{Value: "Jane"}}},
+		{Model: gorm.Model{ID: 8}, Collection: model.Collection{Name: "Page"}, ContentValues: []model.ContentValue{{Value: "About"}}},
+	}, nil)
+	mockAsset.On("FindByIDs", []uint{4}).Return([]model.Asset{{Model: gorm.Model{ID: 4}, Name: "Logo", Path: "logo.png"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/content/collections/1/edit/42", nil)
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	html := rec.Body.String()
+	assert.Contains(t, html, `value="Author: Jane"`)
+	assert.Contains(t, html, `value="Page: About"`)
+	assert.Contains(t, html, `value="Logo: logo.png"`)
+	mockCont.AssertExpectations(t)
+	mockAsset.AssertExpectations(t)
+}
@@ -8,21 +8,30 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/embedfs"
 	"github.com/janmarkuslanger/nuricms/internal/globals"
 	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/service"
 	utilstemplate "github.com/janmarkuslanger/nuricms/internal/template"
 )
 
 type FieldContent struct {
-	Field   model.Field
-	Values  []model.ContentValue
-	Content []model.Content
-	Assets  []model.Asset
+	Field      model.Field
+	Values     []model.ContentValue
+	Content    []model.Content
+	Assets     []model.Asset
+	Errors     []string
+	Components []model.Collection
 }
 
 func renderField(content FieldContent) (template.HTML, error) {
 	var html template.HTML
 	templateName := globals.FieldTemplates[string(content.Field.FieldType)]
 	templatePath := filepath.Join("templates", templateName+".tmpl")
-	templateContent, err := utilstemplate.RenderTemplate(embedfs.TemplatesFS, templatePath, content)
+
+	var data any = content
+	if content.Field.FieldType == model.FieldTypeBlocks {
+		data = blocksEditor(content)
+	}
+
+	templateContent, err := utilstemplate.RenderTemplate(embedfs.TemplatesFS, templatePath, data)
 	if err != nil {
 		return html, err
 	}
@@ -47,34 +56,100 @@ type DataContext struct {
 	Collection model.Collection
 	Contents   []model.Content
 	Assets     []model.Asset
+	Components []model.Collection
+	Errors     map[string][]string
 }
 
 func ContentToFieldContent(content model.Content, ctx DataContext) map[string]FieldContent {
+	return contentToLocaleFields(content, ctx, "")
+}
+
+func localeKey(alias, locale string) string {
+	if locale == "" {
+		return alias
+	}
+	return service.TranslationKey(alias, locale)
+}
+
+// contentToLocaleFields groups the values of one locale by field. Fields of a
+// translation are keyed and named by their translation key and never required.
+func contentToLocaleFields(content model.Content, ctx DataContext, locale string) map[string]FieldContent {
 	fields := make(map[string]FieldContent)
 
 	for _, field := range ctx.Collection.Fields {
+		if locale != "" && !field.Localized {
+			continue
+		}
+
+		key := localeKey(field.Alias, locale)
+		if locale != "" {
+			field.Alias = key
+			field.IsRequired = false
+		}
+
 		values := make([]model.ContentValue, 0)
-		fields[field.Alias] = FieldContent{
-			Field:   field,
-			Values:  values,
-			Content: ctx.Contents,
-			Assets:  ctx.Assets,
+		fields[key] = FieldContent{
+			Field:      field,
+			Values:     values,
+			Content:    ctx.Contents,
+			Assets:     ctx.Assets,
+			Errors:     ctx.Errors[key],
+			Components: ctx.Components,
 		}
 	}
 
 	for _, contentValue := range content.ContentValues {
-		fieldAlias := contentValue.Field.Alias
+		if contentValue.Locale != locale {
+			continue
+		}
+
+		key := localeKey(contentValue.Field.Alias, locale)
 
-		v, ok := fields[fieldAlias]
+		v, ok := fields[key]
 		if ok {
 			v.Values = append(v.Values, contentValue)
-			fields[fieldAlias] = v
+			fields[key] = v
 		}
 	}
 
 	return fields
 }
 
+type LocaleFields struct {
+	Locale     string
+	FieldsHtml []template.HTML
+	HasErrors  bool
+}
+
+func RenderTranslations(content model.Content, ctx DataContext, locales []string) []LocaleFields {
+	translations := make([]LocaleFields, 0, len(locales))
+
+	for _, locale := range locales {
+		fields := contentToLocaleFields(content, ctx, locale)
+		translation := LocaleFields{Locale: locale}
+
+		for _, field := range ctx.Collection.Fields {
+			fc, ok := fields[localeKey(field.Alias, locale)]
+			if !ok {
+				continue
+			}
+
+			html, err := renderField(fc)
+			if err != nil {
+				fmt.Printf("error rendering field %v: %v\n", fc, err)
+				continue
+			}
+
+			translation.FieldsHtml = append(translation.FieldsHtml, html)
+			translation.HasErrors = translation.HasErrors || len(fc.Errors) > 0
+		}
+
+		translations = append(translations, translation)
+	}
+
+	return translations
+}
+
 func RenderFieldsByContent(content model.Content, ctx DataContext) []template.HTML {
 	var htmlFields []template.HTML
 
@@ -2,11 +2,14 @@ package content
 
 import (
 	"embed"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	utilstemplate "github.com/janmarkuslanger/nuricms/internal/template"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestContentToFieldContent(t *testing.T) {
@@ -95,3 +98,124 @@ func TestRenderFieldsByContent(t *testing.T) {
 	assert.NotNil(t, htmlFields)
 	assert.Greater(t, len(htmlFields), 0)
 }
+
+func TestRenderFieldsByContent_Select(t *testing.T) {
+	options := []model.FieldOption{{Value: "red"}, {Value: "green"}, {Value: "blue"}}
+	color := model.Field{Alias: "color", Name: "Color", FieldType: model.FieldTypeSelect, Options: options}
+	tags := model.Field{Alias: "tags", Name: "Tags", FieldType: model.FieldTypeMultiSelect, Options: options}
+
+	c := model.Content{ContentValues: []model.ContentValue{
+		{Field: color, Value: "green"},
+		{Field: tags, Value: "red"},
+		{Field: tags, Value: "blue"},
+	}}
+
+	html := RenderFieldsByContent(c, DataContext{Collection: model.Collection{Fields: []model.Field{color, tags}}})
+	assert.Len(t, html, 2)
+
+	rendered := string(html[0]) + string(html[1])
+	assert.Contains(t, rendered, `<option value="green" selected>green</option>`)
+	assert.Contains(t, rendered, `name="tags"`)
+	assert.Contains(t, rendered, "multiple")
+	assert.Contains(t, rendered, `<option value="red" selected>red</option>`)
+	assert.Contains(t, rendered, `<option value="blue" selected>blue</option>`)
+	assert.Contains(t, rendered, `<option value="red" >red</option>`)
+}
+
+func TestRenderFieldsByContent_StructuredTypes(t *testing.T) {
+	inputs := map[model.FieldType]string{
+		model.FieldTypeJSON:     `<textarea class="textarea font-mono"`,
+		model.FieldTypeURL:      `type="url"`,
+		model.FieldTypeEmail:    `type="email"`,
+		model.FieldTypeColor:    `background-color: #1a2b3c`,
+		model.FieldTypeSlug:     `placeholder="derived from title when empty"`,
+		model.FieldTypeGeoPoint: `placeholder="latitude,longitude`,
+	}
+
+	for fieldType, want := range inputs {
+		field := model.Field{Alias: "value", Name: "Value", FieldType: fieldType, IsRequired: true, SlugSource: "title"}
+		c := model.Content{ContentValues: []model.ContentValue{{Field: field, Value: "#1a2b3c"}}}
+
+		html := RenderFieldsByContent(c, DataContext{Collection: model.Collection{Fields: []model.Field{field}}})
+		if assert.Len(t, html, 1, fieldType) {
+			assert.Contains(t, string(html[0]), want, fieldType)
+			assert.Contains(t, string(html[0]), `name="value"`, fieldType)
+		}
+	}
+}
+
+func TestRenderFieldsByContent_Blocks(t *testing.T) {
+	field := model.Field{Alias: "body", Name: "Body", FieldType: model.FieldTypeBlocks, IsList: true}
+	hero := model.Collection{Name: "Hero", Alias: "hero", IsComponent: true, Fields: []model.Field{
+		{Name: "Title", Alias: "title", FieldType: model.FieldTypeText},
+	}}
+	c := model.Content{ContentValues: []model.ContentValue{
+		{Field: field, SortIndex: 2, Value: `{"component":"hero","values":{"title":["Second"]}}`},
+		{Field: field, SortIndex: 1, Value: `{"component":"hero","values":{"title":["First"]}}`},
+	}}
+
+	html := RenderFieldsByContent(c, DataContext{
+		Collection: model.Collection{Fields: []model.Field{field}},
+		Components: []model.Collection{hero},
+	})
+	if !assert.Len(t, html, 1) {
+		return
+	}
+
+	out := string(html[0])
+	assert.Contains(t, out, `name="_blocks" value="body"`)
+	assert.Contains(t, out, `value="b1:hero"`)
+	assert.Contains(t, out, `name="body.b1.title"`)
+	assert.Less(t, strings.Index(out, "First"), strings.Index(out, "Second"), "blocks keep their order")
+	assert.Contains(t, out, `name="body.__block__.title"`)
+	assert.Contains(t, out, `data-action-block-add="hero"`)
+}
+
+func TestEncodeBlocks(t *testing.T) {
+	form := url.Values{
+		"_blocks":          {"body", "body@de"},
+		"body":             {"b1:hero", "not-a-block"},
+		"body.b1.title":    {"Hi"},
+		"body.b1.tags":     {"a", "b"},
+		"body.b10.title":   {"other block"},
+		"body@de":          {"n1:hero"},
+		"body@de.n1.title": {"Hallo"},
+		"title":            {"Page"},
+	}
+
+	encoded := encodeBlocks(form)
+	assert.Equal(t, []string{`{"component":"hero","values":{"tags":["a","b"],"title":["Hi"]}}`, "not-a-block"}, encoded["body"])
+	assert.Equal(t, []string{`{"component":"hero","values":{"title":["Hallo"]}}`}, encoded["body@de"])
+	assert.Equal(t, []string{"Page"}, encoded["title"])
+	assert.Equal(t, []string{"b1:hero", "not-a-block"}, form["body"], "the posted form is left alone")
+
+	plain := url.Values{"title": {"Page"}}
+	assert.Equal(t, plain, encodeBlocks(plain))
+}
+
+func TestRenderFieldsByContent_Pickers(t *testing.T) {
+	author := model.Field{Alias: "author", Name: "Author", FieldType: model.FieldTypeCollection, TargetCollections: "2\n3"}
+	image := model.Field{Alias: "image", Name: "Image", FieldType: model.FieldTypeAsset}
+	c := model.Content{ContentValues: []model.ContentValue{
+		{Field: author, Value: "10"},
+		{Field: image, Value: "4"},
+	}}
+
+	html := RenderFieldsByContent(c, DataContext{
+		Collection: model.Collection{Fields: []model.Field{author, image}},
+		Contents: []model.Content{{Model: gorm.Model{ID: 10}, Collection: model.Collection{Name: "Authors"},
+			ContentValues: []model.ContentValue{{Value: "Jane"}}}},
+		Assets: []model.Asset{{Model: gorm.Model{ID: 4}, Name: "Logo", Path: "logo.png"}},
+	})
+	if !assert.Len(t, html, 2) {
+		return
+	}
+
+	rendered := string(html[0]) + string(html[1])
+	assert.Contains(t, rendered, `data-picker="/content/references?collection=2&amp;collection=3"`)
+	assert.Contains(t, rendered, `name="author" value="10"`)
+	assert.Contains(t, rendered, `value="Authors: Jane"`)
+	assert.Contains(t, rendered, `data-picker="/assets/search"`)
+	assert.Contains(t, rendered, `value="Logo: logo.png"`)
+	assert.NotContains(t, rendered, "<option", "only the selected items are rendered")
+}
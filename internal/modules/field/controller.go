@@ -72,11 +72,21 @@ func loadFieldData(s service.CollectionService) (map[string]any, error) {
 		model.FieldTypeText, model.FieldTypeNumber, model.FieldTypeBoolean,
 		model.FieldTypeDate, model.FieldTypeAsset, model.FieldTypeCollection,
 		model.FieldTypeTextarea, model.FieldTypeRichText, model.FieldTypeMultiSelect,
+		model.FieldTypeSelect, model.FieldTypeJSON, model.FieldTypeURL,
+		model.FieldTypeEmail, model.FieldTypeColor, model.FieldTypeSlug,
+		model.FieldTypeGeoPoint, model.FieldTypeBlocks,
 	}
 
 	return data, nil
 }
 
+func targetCollections(ctx server.Context) []string {
+	if err := ctx.Request.ParseForm(); err != nil {
+		return nil
+	}
+	return ctx.Request.PostForm["target_collections"]
+}
+
 func (ct Controller) showCreateField(ctx server.Context) {
 	handler.HandleShowCreate(ctx, handler.HandlerOptions{
 		RenderOnSuccess: "field/create_or_edit.tmpl",
@@ -95,6 +105,22 @@ func (ct Controller) createField(ctx server.Context) {
 		IsList:       ctx.Request.PostFormValue("is_list"),
 		IsRequired:   ctx.Request.PostFormValue("is_required"),
 		DisplayField: ctx.Request.PostFormValue("display_field"),
+		Localized:    ctx.Request.PostFormValue("localized"),
+		IsUnique:     ctx.Request.PostFormValue("is_unique"),
+
+		MinValue:      ctx.Request.PostFormValue("min_value"),
+		MaxValue:      ctx.Request.PostFormValue("max_value"),
+		MinLength:     ctx.Request.PostFormValue("min_length"),
+		MaxLength:     ctx.Request.PostFormValue("max_length"),
+		Pattern:       ctx.Request.PostFormValue("pattern"),
+		MinDate:       ctx.Request.PostFormValue("min_date"),
+		MaxDate:       ctx.Request.PostFormValue("max_date"),
+		MinItems:      ctx.Request.PostFormValue("min_items"),
+		MaxItems:      ctx.Request.PostFormValue("max_items"),
+		AllowedValues: ctx.Request.PostFormValue("allowed_values"),
+		SlugSource:    ctx.Request.PostFormValue("slug_source"),
+
+		TargetCollections: targetCollections(ctx),
 	}, handler.HandlerOptions{
 		RenderOnFail:      "field/create_or_edit.tmpl",
 		RedirectOnSuccess: "/fields/",
@@ -120,6 +146,22 @@ func (ct *Controller) editField(ctx server.Context) {
 		IsList:       ctx.Request.PostFormValue("is_list"),
 		IsRequired:   ctx.Request.PostFormValue("is_required"),
 		DisplayField: ctx.Request.PostFormValue("display_field"),
+		Localized:    ctx.Request.PostFormValue("localized"),
+		IsUnique:     ctx.Request.PostFormValue("is_unique"),
+
+		MinValue:      ctx.Request.PostFormValue("min_value"),
+		MaxValue:      ctx.Request.PostFormValue("max_value"),
+		MinLength:     ctx.Request.PostFormValue("min_length"),
+		MaxLength:     ctx.Request.PostFormValue("max_length"),
+		Pattern:       ctx.Request.PostFormValue("pattern"),
+		MinDate:       ctx.Request.PostFormValue("min_date"),
+		MaxDate:       ctx.Request.PostFormValue("max_date"),
+		MinItems:      ctx.Request.PostFormValue("min_items"),
+		MaxItems:      ctx.Request.PostFormValue("max_items"),
+		AllowedValues: ctx.Request.PostFormValue("allowed_values"),
+		SlugSource:    ctx.Request.PostFormValue("slug_source"),
+
+		TargetCollections: targetCollections(ctx),
 	}, handler.HandlerOptions{
 		RedirectOnSuccess: "/fields/",
 		RenderOnFail:      "field/create_or_edit.tmpl",
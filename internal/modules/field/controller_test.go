@@ -12,6 +12,7 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/testutils"
 	"github.com/janmarkuslanger/nuricms/testutils/mockservices"
+	"gorm.io/gorm"
 )
 
 func setupTestServer() (*server.Server, *httptest.ResponseRecorder, *testutils.MockFieldService, *testutils.MockCollectionService, *mockservices.MockUserService) {
@@ -166,3 +167,76 @@ func Test_deleteField(t *testing.T) {
 		t.Errorf("expected redirect to /fields/, got %s", loc)
 	}
 }
+
+func Test_showEditField_validationRules(t *testing.T) {
+	srv, rec, fieldMock, collectionMock, _ := setupTestServer()
+
+	minValue := 2.5
+	maxItems := 3
+	fieldMock.On("FindByID", uint(1)).Return(&model.Field{Name: "price", MinValue: &minValue, MaxItems: &maxItems, AllowedValues: "1\n2"}, nil)
+	collectionMock.On("List", 1, 999999999999999999).Return([]model.Collection{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/fields/edit/1", nil)
+	srv.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `name="min_value" value="2.5"`) {
+		t.Errorf("expected min value to be rendered")
+	}
+	if !strings.Contains(body, `name="max_items" value="3"`) {
+		t.Errorf("expected max items to be rendered")
+	}
+	if !strings.Contains(body, `name="max_value" value=""`) {
+		t.Errorf("expected empty max value")
+	}
+}
+
+func Test_createField_targetCollections(t *testing.T) {
+	srv, rec, fieldMock, _, _ := setupTestServer()
+
+	data := dto.FieldData{
+		Name:              "author",
+		Alias:             "author",
+		CollectionID:      "1",
+		FieldType:         "Collection",
+		TargetCollections: []string{"2", "3"},
+	}
+
+	fieldMock.On("Create", data).Return(&model.Field{Name: "author"}, nil)
+
+	form := strings.NewReader("name=author&alias=author&collection_id=1&field_type=Collection&target_collections=2&target_collections=3")
+	req := httptest.NewRequest(http.MethodPost, "/fields/create", form)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	srv.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("expected 303, got %d", rec.Code)
+	}
+	fieldMock.AssertExpectations(t)
+}
+
+func Test_showEditField_targetCollections(t *testing.T) {
+	srv, rec, fieldMock, collectionMock, _ := setupTestServer()
+
+	fieldMock.On("FindByID", uint(1)).Return(&model.Field{Name: "author", FieldType: model.FieldTypeCollection, TargetCollections: "2"}, nil)
+	collections := []model.Collection{
+		{Model: gorm.Model{ID: 2}, Name: "Author"},
+		{Model: gorm.Model{ID: 3}, Name: "Page"},
+		{Model: gorm.Model{ID: 4}, Name: "Hero", IsComponent: true},
+	}
+	collectionMock.On("List", 1, 999999999999999999).Return(collections, int64(3), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/fields/edit/1", nil)
+	srv.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.Contains(body, `<option value="2" selected>Author</option>`) {
+		t.Errorf("expected the target collection to be selected")
+	}
+	if !strings.Contains(body, `<option value="3" >Page</option>`) {
+		t.Errorf("expected other collections to be offered")
+	}
+	if strings.Count(body, ">Hero</option>") != 1 {
+		t.Errorf("expected components to be offered as collection only, not as target")
+	}
+}
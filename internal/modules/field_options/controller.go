@@ -77,6 +77,7 @@ func (ct Controller) indexFieldOptions(ctx server.Context) {
 func (ct Controller) showCreateFieldOption(ctx server.Context) {
 	fields, _ := ct.services.Field.FindByFieldTypes([]model.FieldType{
 		model.FieldTypeMultiSelect,
+		model.FieldTypeSelect,
 	})
 
 	utils.RenderWithLayoutHTTP(ctx, "field_option/create_or_edit.tmpl", map[string]any{
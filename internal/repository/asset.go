@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"gorm.io/gorm"
@@ -21,3 +23,14 @@ func NewAssetRepository(db *gorm.DB) *AssetRepository {
 		db:             db,
 	}
 }
+
+// NameMatching keeps the assets whose name contains the query.
+func NameMatching(query string) base.QueryOption {
+	query = strings.TrimSpace(query)
+	return func(db *gorm.DB) *gorm.DB {
+		if query == "" {
+			return db
+		}
+		return db.Where(`LOWER(name) LIKE ? ESCAPE '\'`, likePattern(strings.ToLower(query)))
+	}
+}
@@ -7,3 +7,15 @@ func Preload(field string, args ...interface{}) QueryOption {
 		return db.Preload(field, args...)
 	}
 }
+
+func WithIDs(ids ...uint) QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN ?", ids)
+	}
+}
+
+func Order(order string) QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(order)
+	}
+}
@@ -9,6 +9,8 @@ import (
 type CollectionRepo interface {
 	base.CRUDRepository[model.Collection]
 	FindByAlias(alias string) (*model.Collection, error)
+	FindComponents() ([]model.Collection, error)
+	WithTx(tx *gorm.DB) CollectionRepo
 }
 
 type collectionRepository struct {
@@ -23,13 +25,29 @@ func NewCollectionRepository(db *gorm.DB) CollectionRepo {
 	}
 }
 
+func (r *collectionRepository) WithTx(tx *gorm.DB) CollectionRepo {
+	return NewCollectionRepository(tx)
+}
+
 func (r *collectionRepository) FindByAlias(alias string) (*model.Collection, error) {
 	var c model.Collection
-	err := r.db.Preload("Fields").Where("alias = ?", alias).First(&c).Error
+	err := r.db.Preload("Fields").Preload("Fields.Options", preloadOptions).Where("alias = ?", alias).First(&c).Error
 	return &c, err
 }
 
 func (r *collectionRepository) FindByID(id uint, opts ...base.QueryOption) (*model.Collection, error) {
-	opts = append([]base.QueryOption{base.Preload("Fields")}, opts...)
+	opts = append([]base.QueryOption{base.Preload("Fields"), base.Preload("Fields.Options", preloadOptions)}, opts...)
 	return r.BaseRepository.FindByID(id, opts...)
 }
+
+func (r *collectionRepository) FindComponents() ([]model.Collection, error) {
+	var components []model.Collection
+	err := r.db.
+		Preload("Fields").
+		Preload("Fields.Options", preloadOptions).
+		Where("is_component = ?", true).
+		Order("name").
+		Find(&components).
+		Error
+	return components, err
+}
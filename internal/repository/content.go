@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"gorm.io/gorm"
@@ -9,10 +13,18 @@ import (
 type ContentRepo interface {
 	base.CRUDRepository[model.Content]
 	DeleteByID(id uint) error
-	FindByCollectionID(collectionID uint, offset, limit int) ([]model.Content, error)
-	FindDisplayValueByCollectionID(collectionID uint, page, pageSize int) ([]model.Content, int64, error)
+	UpdateStatus(id uint, status model.ContentStatus) error
+	UpdateSchedule(id uint, publishAt, unpublishAt *time.Time) error
+	FindDueForPublish(now time.Time) ([]model.Content, error)
+	FindDueForUnpublish(now time.Time) ([]model.Content, error)
+	FindByCollectionID(collectionID uint, offset, limit int, opts ...base.QueryOption) ([]model.Content, error)
+	CountByCollectionID(collectionID uint, opts ...base.QueryOption) (int64, error)
+	LastModifiedByCollectionID(collectionID uint, now time.Time) (time.Time, error)
+	FindChangedBetween(since, until time.Time, collectionIDs []uint, afterID uint, limit int, opts ...base.QueryOption) ([]model.Content, error)
+	FindDisplayValueByCollectionID(collectionID uint, page, pageSize int, opts ...base.QueryOption) ([]model.Content, int64, error)
 	ListWithDisplayContentValue() ([]model.Content, error)
-	FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int) ([]model.Content, int, error)
+	CollectionIDsByIDs(ids []uint) (map[uint]uint, error)
+	FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int, opts ...base.QueryOption) ([]model.Content, int, error)
 	WithTx(tx *gorm.DB) ContentRepo
 }
 
@@ -21,6 +33,96 @@ type contentRepository struct {
 	db *gorm.DB
 }
 
+func WithStatus(statuses ...model.ContentStatus) base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("contents.status IN ?", statuses)
+	}
+}
+
+func VisibleAt(now time.Time) base.QueryOption {
+	now = now.UTC()
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("((contents.status = ? AND (contents.publish_at IS NULL OR contents.publish_at <= ?)) OR (contents.status = ? AND contents.publish_at <= ?))",
+				model.ContentStatusPublished, now, model.ContentStatusDraft, now).
+			Where("(contents.unpublish_at IS NULL OR contents.unpublish_at > ?)", now)
+	}
+}
+
+func WithValueFields(aliases ...string) base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
+			return liveValues(db).
+				Where("content_values.field_id IN (SELECT id FROM fields WHERE fields.deleted_at IS NULL AND fields.alias IN ?)", aliases).
+				Preload("Field", liveFields)
+		})
+	}
+}
+
+// liveValues and liveFields keep deleted rows out of preloads, which GORM
+// would otherwise include when the outer query is unscoped.
+func liveValues(db *gorm.DB) *gorm.DB {
+	return db.Where("content_values.deleted_at IS NULL")
+}
+
+func liveFields(db *gorm.DB) *gorm.DB {
+	return db.Where("fields.deleted_at IS NULL")
+}
+
+func AfterID(id uint) base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("contents.id > ?", id)
+	}
+}
+
+// WithDisplayValues preloads the collection and the default locale values of
+// the display fields, which is what the editor shows for an entry.
+func WithDisplayValues() base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
+				return db.Joins("Field").Where("field.display_field = ? AND content_values.locale = ''", true)
+			}).
+			Preload("ContentValues.Field").
+			Preload("Collection")
+	}
+}
+
+// InCollections limits the entries to the collections, no collections leave
+// the query untouched.
+func InCollections(collectionIDs ...uint) base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(collectionIDs) == 0 {
+			return db
+		}
+		return db.Where("contents.collection_id IN ?", collectionIDs)
+	}
+}
+
+// DisplayValueMatching keeps the entries whose ID is the query or one of
+// whose display values contains it.
+func DisplayValueMatching(query string) base.QueryOption {
+	query = strings.TrimSpace(query)
+	return func(db *gorm.DB) *gorm.DB {
+		if query == "" {
+			return db
+		}
+
+		id, _ := strconv.ParseUint(query, 10, 64)
+		return db.Where(`contents.id = ? OR contents.id IN (SELECT cv.content_id FROM content_values cv
+			JOIN fields f ON f.id = cv.field_id AND f.deleted_at IS NULL
+			WHERE f.display_field = ? AND cv.locale = '' AND cv.deleted_at IS NULL AND LOWER(cv.value) LIKE ? ESCAPE '\')`,
+			id, true, likePattern(strings.ToLower(query)))
+	}
+}
+
+func applyOptions(db *gorm.DB, opts []base.QueryOption) *gorm.DB {
+	for _, o := range opts {
+		db = o(db)
+	}
+	return db
+}
+
 func NewContentRepository(db *gorm.DB) ContentRepo {
 	return &contentRepository{
 		BaseRepository: base.NewBaseRepository[model.Content](db),
@@ -40,6 +142,36 @@ func (r *contentRepository) DeleteByID(id uint) error {
 	return r.db.Delete(&model.Content{}, id).Error
 }
 
+func (r *contentRepository) UpdateStatus(id uint, status model.ContentStatus) error {
+	return r.db.Model(&model.Content{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *contentRepository) UpdateSchedule(id uint, publishAt, unpublishAt *time.Time) error {
+	return r.db.Model(&model.Content{}).Where("id = ?", id).Updates(map[string]any{
+		"publish_at":   publishAt,
+		"unpublish_at": unpublishAt,
+	}).Error
+}
+
+func (r *contentRepository) FindDueForPublish(now time.Time) ([]model.Content, error) {
+	var contents []model.Content
+	err := r.db.
+		Where("publish_at IS NOT NULL AND publish_at <= ?", now.UTC()).
+		Where("status <> ?", model.ContentStatusArchived).
+		Find(&contents).
+		Error
+	return contents, err
+}
+
+func (r *contentRepository) FindDueForUnpublish(now time.Time) ([]model.Content, error) {
+	var contents []model.Content
+	err := r.db.
+		Where("unpublish_at IS NOT NULL AND unpublish_at <= ?", now.UTC()).
+		Find(&contents).
+		Error
+	return contents, err
+}
+
 func (r *contentRepository) FindByID(id uint, opts ...base.QueryOption) (*model.Content, error) {
 	opts = append([]base.QueryOption{
 		base.Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
@@ -50,10 +182,9 @@ func (r *contentRepository) FindByID(id uint, opts ...base.QueryOption) (*model.
 	return r.BaseRepository.FindByID(id, opts...)
 }
 
-func (r *contentRepository) FindByCollectionID(collectionID uint, offset, limit int) ([]model.Content, error) {
-	db := r.db.Where("collection_id = ?", collectionID).
-		Preload("ContentValues").
-		Preload("ContentValues.Field")
+func (r *contentRepository) FindByCollectionID(collectionID uint, offset, limit int, opts ...base.QueryOption) ([]model.Content, error) {
+	db := applyOptions(r.db.Preload("ContentValues.Field").Preload("ContentValues"), opts).
+		Where("collection_id = ?", collectionID)
 	if offset > 0 {
 		db = db.Offset(offset)
 	}
@@ -64,13 +195,115 @@ func (r *contentRepository) FindByCollectionID(collectionID uint, offset, limit
 	return contents, db.Find(&contents).Error
 }
 
+func (r *contentRepository) CountByCollectionID(collectionID uint, opts ...base.QueryOption) (int64, error) {
+	var total int64
+	err := applyOptions(r.db, opts).
+		Model(&model.Content{}).
+		Where("collection_id = ?", collectionID).
+		Count(&total).
+		Error
+	return total, err
+}
+
+func latestTimestamp(db *gorm.DB, column string) (time.Time, error) {
+	var stamps []time.Time
+	err := db.
+		Where(column+" IS NOT NULL").
+		Order(column+" DESC").
+		Limit(1).
+		Pluck(column, &stamps).
+		Error
+	if err != nil || len(stamps) == 0 {
+		return time.Time{}, err
+	}
+	return stamps[0], nil
+}
+
+func (r *contentRepository) LastModifiedByCollectionID(collectionID uint, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	contents := func() *gorm.DB {
+		return r.db.Unscoped().Model(&model.Content{}).Where("collection_id = ?", collectionID)
+	}
+	values := func() *gorm.DB {
+		return r.db.Unscoped().Model(&model.ContentValue{}).
+			Where("content_id IN (?)", r.db.Unscoped().Model(&model.Content{}).Select("id").Where("collection_id = ?", collectionID))
+	}
+
+	queries := []struct {
+		db     *gorm.DB
+		column string
+	}{
+		{contents(), "updated_at"},
+		{contents(), "deleted_at"},
+		{contents().Where("publish_at <= ?", now), "publish_at"},
+		{contents().Where("unpublish_at <= ?", now), "unpublish_at"},
+		{values(), "updated_at"},
+		{values(), "deleted_at"},
+	}
+
+	var latest time.Time
+	for _, q := range queries {
+		stamp, err := latestTimestamp(q.db, q.column)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if stamp.After(latest) {
+			latest = stamp
+		}
+	}
+
+	return latest, nil
+}
+
+func (r *contentRepository) FindChangedBetween(since, until time.Time, collectionIDs []uint, afterID uint, limit int, opts ...base.QueryOption) ([]model.Content, error) {
+	since, until = since.UTC(), until.UTC()
+	// The delta query is unscoped to find deleted entries, the preloads must
+	// still only see live values.
+	db := r.db.
+		Preload("Collection").
+		Preload("ContentValues", liveValues).
+		Preload("ContentValues.Field", liveFields)
+
+	if since.IsZero() {
+		db = db.Where("contents.created_at <= ?", until)
+	} else {
+		between := func(column string) string {
+			return "(" + column + " > @since AND " + column + " <= @until)"
+		}
+		db = db.Unscoped().Where(
+			between("contents.updated_at")+" OR "+
+				between("contents.deleted_at")+" OR "+
+				between("contents.publish_at")+" OR "+
+				between("contents.unpublish_at")+" OR "+
+				"contents.id IN (SELECT content_id FROM content_values WHERE "+
+				between("content_values.updated_at")+" OR "+between("content_values.deleted_at")+")",
+			map[string]any{"since": since, "until": until},
+		)
+	}
+
+	if len(collectionIDs) > 0 {
+		db = db.Where("contents.collection_id IN ?", collectionIDs)
+	}
+	if afterID > 0 {
+		db = db.Where("contents.id > ?", afterID)
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	var contents []model.Content
+	err := applyOptions(db, opts).Order("contents.id ASC").Find(&contents).Error
+	return contents, err
+}
+
 func (r *contentRepository) FindDisplayValueByCollectionID(
 	collectionID uint,
 	page, pageSize int,
+	opts ...base.QueryOption,
 ) ([]model.Content, int64, error) {
 	var totalCount int64
 
-	err := r.db.
+	err := applyOptions(r.db, opts).
 		Model(&model.Content{}).
 		Where("collection_id = ?", collectionID).
 		Count(&totalCount).
@@ -82,12 +315,12 @@ func (r *contentRepository) FindDisplayValueByCollectionID(
 	offset := (page - 1) * pageSize
 	var contents []model.Content
 
-	err = r.db.
+	err = applyOptions(r.db, opts).
 		Where("collection_id = ?", collectionID).
 		Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
 			return db.
 				Joins("Field").
-				Where("field.display_field = ?", true)
+				Where("field.display_field = ? AND content_values.locale = ''", true)
 		}).
 		Preload("ContentValues.Field").
 		Offset(offset).
@@ -100,33 +333,49 @@ func (r *contentRepository) FindDisplayValueByCollectionID(
 
 func (r *contentRepository) ListWithDisplayContentValue() ([]model.Content, error) {
 	var contents []model.Content
-	err := r.db.
-		Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
-			return db.Joins("Field").Where("field.display_field = ?", true)
-		}).
-		Preload("ContentValues.Field").
-		Preload("Collection").
-		Find(&contents).Error
+	err := WithDisplayValues()(r.db).Find(&contents).Error
 	return contents, err
 }
 
-func (r *contentRepository) FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int) ([]model.Content, int, error) {
+// CollectionIDsByIDs maps the IDs of existing entries to their collection.
+func (r *contentRepository) CollectionIDsByIDs(ids []uint) (map[uint]uint, error) {
+	var rows []struct {
+		ID           uint
+		CollectionID uint
+	}
+	err := r.db.
+		Model(&model.Content{}).
+		Select("id", "collection_id").
+		Where("id IN ?", ids).
+		Find(&rows).
+		Error
+
+	collections := make(map[uint]uint, len(rows))
+	for _, row := range rows {
+		collections[row.ID] = row.CollectionID
+	}
+	return collections, err
+}
+
+func (r *contentRepository) FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int, opts ...base.QueryOption) ([]model.Content, int, error) {
 	var totalCount int64
-	countDB := r.db.Model(&model.Content{}).
+	countDB := applyOptions(r.db, opts).
+		Model(&model.Content{}).
 		Joins("JOIN content_values cv ON cv.content_id = contents.id").
 		Joins("JOIN fields f ON f.id = cv.field_id").
 		Where("contents.collection_id = ?", collectionID).
-		Where("f.alias = ? AND cv.value = ?", fieldAlias, value)
+		Where("f.alias = ? AND cv.value = ? AND cv.locale = ''", fieldAlias, value)
 	if err := countDB.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
 	var contents []model.Content
-	queryDB := r.db.Model(&model.Content{}).
+	queryDB := applyOptions(r.db, opts).
+		Model(&model.Content{}).
 		Distinct("contents.*").
 		Joins("JOIN content_values cv ON cv.content_id = contents.id").
 		Joins("JOIN fields f ON f.id = cv.field_id").
 		Where("contents.collection_id = ?", collectionID).
-		Where("f.alias = ? AND cv.value = ?", fieldAlias, value)
+		Where("f.alias = ? AND cv.value = ? AND cv.locale = ''", fieldAlias, value)
 	if offset > 0 {
 		queryDB = queryDB.Offset(offset)
 	}
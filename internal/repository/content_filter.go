@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"gorm.io/gorm"
+)
+
+type FilterOp string
+
+const (
+	FilterEq       FilterOp = "eq"
+	FilterNe       FilterOp = "ne"
+	FilterGt       FilterOp = "gt"
+	FilterGte      FilterOp = "gte"
+	FilterLt       FilterOp = "lt"
+	FilterLte      FilterOp = "lte"
+	FilterContains FilterOp = "contains"
+	FilterIn       FilterOp = "in"
+	FilterNin      FilterOp = "nin"
+)
+
+var comparisonOps = map[FilterOp]string{
+	FilterGt:  ">",
+	FilterGte: ">=",
+	FilterLt:  "<",
+	FilterLte: "<=",
+}
+
+var truthyValues = []string{"on", "true", "1"}
+
+type FieldCondition struct {
+	Field  model.Field
+	Op     FilterOp
+	Values []string
+}
+
+type compiledCondition struct {
+	negate bool
+	sql    string
+	args   []any
+}
+
+func castFilterValue(fieldType model.FieldType, raw string) (any, error) {
+	switch fieldType {
+	case model.FieldTypeNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return n, nil
+	case model.FieldTypeDate:
+		d, err := model.ParseDate(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", raw)
+		}
+		return d.Format(model.DateLayout), nil
+	case model.FieldTypeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", raw)
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+func valueColumn(fieldType model.FieldType) string {
+	switch fieldType {
+	case model.FieldTypeNumber:
+		return "CAST(cv.value AS REAL)"
+	case model.FieldTypeDate:
+		return "substr(cv.value, 1, 10)"
+	default:
+		return "cv.value"
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func (c FieldCondition) compile() (compiledCondition, error) {
+	fieldType := c.Field.FieldType
+	alias := c.Field.Alias
+
+	if len(c.Values) == 0 {
+		return compiledCondition{}, fmt.Errorf("%s: missing value", alias)
+	}
+	if c.Op != FilterIn && c.Op != FilterNin && len(c.Values) > 1 {
+		return compiledCondition{}, fmt.Errorf("%s: operator %s takes a single value", alias, c.Op)
+	}
+
+	args := make([]any, 0, len(c.Values))
+	for _, raw := range c.Values {
+		v, err := castFilterValue(fieldType, raw)
+		if err != nil {
+			return compiledCondition{}, fmt.Errorf("%s: %w", alias, err)
+		}
+		args = append(args, v)
+	}
+
+	if fieldType == model.FieldTypeBoolean {
+		if c.Op != FilterEq && c.Op != FilterNe {
+			return compiledCondition{}, fmt.Errorf("%s: operator %s is not supported for %s fields", alias, c.Op, fieldType)
+		}
+		want := args[0].(bool)
+		return compiledCondition{
+			negate: want == (c.Op == FilterNe),
+			sql:    "cv.value IN ?",
+			args:   []any{truthyValues},
+		}, nil
+	}
+
+	column := valueColumn(fieldType)
+	if fieldType == model.FieldTypeNumber {
+		column = "cv.value <> '' AND " + column
+	}
+
+	switch c.Op {
+	case FilterEq, FilterNe:
+		return compiledCondition{negate: c.Op == FilterNe, sql: column + " = ?", args: args}, nil
+	case FilterIn, FilterNin:
+		return compiledCondition{negate: c.Op == FilterNin, sql: column + " IN ?", args: []any{args}}, nil
+	case FilterGt, FilterGte, FilterLt, FilterLte:
+		if fieldType != model.FieldTypeNumber && fieldType != model.FieldTypeDate {
+			return compiledCondition{}, fmt.Errorf("%s: operator %s is not supported for %s fields", alias, c.Op, fieldType)
+		}
+		return compiledCondition{sql: column + " " + comparisonOps[c.Op] + " ?", args: args}, nil
+	case FilterContains:
+		if fieldType == model.FieldTypeNumber || fieldType == model.FieldTypeDate {
+			return compiledCondition{}, fmt.Errorf("%s: operator %s is not supported for %s fields", alias, c.Op, fieldType)
+		}
+		return compiledCondition{sql: `cv.value LIKE ? ESCAPE '\'`, args: []any{"%" + escapeLike(args[0].(string)) + "%"}}, nil
+	default:
+		return compiledCondition{}, fmt.Errorf("%s: unknown operator %q", alias, c.Op)
+	}
+}
+
+func FieldValueEquals(fieldAlias, value string) base.QueryOption {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("contents.id IN (SELECT cv.content_id FROM content_values cv "+
+			"JOIN fields f ON f.id = cv.field_id "+
+			"WHERE cv.deleted_at IS NULL AND cv.locale = '' AND f.collection_id = contents.collection_id AND f.alias = ? AND cv.value = ?)",
+			fieldAlias, value)
+	}
+}
+
+func MatchConditions(or bool, conditions ...FieldCondition) (base.QueryOption, error) {
+	var clauses []string
+	var args []any
+
+	for _, c := range conditions {
+		compiled, err := c.compile()
+		if err != nil {
+			return nil, err
+		}
+
+		membership := "IN"
+		if compiled.negate {
+			membership = "NOT IN"
+		}
+
+		clauses = append(clauses, "contents.id "+membership+" (SELECT cv.content_id FROM content_values cv "+
+			"WHERE cv.deleted_at IS NULL AND cv.locale = '' AND cv.field_id = ? AND "+compiled.sql+")")
+		args = append(args, c.Field.ID)
+		args = append(args, compiled.args...)
+	}
+
+	glue := " AND "
+	if or {
+		glue = " OR "
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if len(clauses) == 0 {
+			return db
+		}
+		return db.Where("("+strings.Join(clauses, glue)+")", args...)
+	}, nil
+}
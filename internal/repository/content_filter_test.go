@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	productFields = []model.Field{
+		{Name: "Title", Alias: "title", FieldType: model.FieldTypeText},
+		{Name: "Price", Alias: "price", FieldType: model.FieldTypeNumber},
+		{Name: "Day", Alias: "day", FieldType: model.FieldTypeDate},
+		{Name: "Active", Alias: "active", FieldType: model.FieldTypeBoolean},
+		{Name: "Tags", Alias: "tags", FieldType: model.FieldTypeText, IsList: true},
+	}
+	products = []map[string][]string{
+		{"title": {"Go in Action"}, "price": {"9.5"}, "day": {"2024-01-10"}, "active": {"on"}, "tags": {"go", "books"}},
+		{"title": {"Learning Go"}, "price": {"25"}, "day": {"2024-03-01"}, "active": {""}, "tags": {"go"}},
+		{"title": {"Rust 100%"}, "price": {"40"}, "day": {"2023-12-24"}, "active": {"on"}, "tags": {"rust"}},
+	}
+)
+
+// seedProducts seeds the products shared by the filter, sort and projection
+// tests and returns the entry IDs in the order of products.
+func seedProducts(t *testing.T, db *gorm.DB) (*model.Collection, []uint) {
+	col := testutils.SeedCollection(t, db, &model.Collection{Name: "Products", Alias: "products"}, productFields...)
+	ids := make([]uint, 0, len(products))
+	for _, values := range products {
+		ids = append(ids, testutils.SeedContent(t, db, col, model.ContentStatusPublished, values).ID)
+	}
+	return col, ids
+}
+
+func matchedIDs(t *testing.T, repo ContentRepo, collectionID uint, or bool, conditions ...FieldCondition) []uint {
+	t.Helper()
+	opt, err := MatchConditions(or, conditions...)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	list, err := repo.FindByCollectionID(collectionID, 0, 0, opt)
+	assert.NoError(t, err)
+
+	ids := []uint{}
+	for _, c := range list {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func TestMatchConditions_Operators(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col, ids := seedProducts(t, db)
+	goBook, learning, rust := ids[0], ids[1], ids[2]
+	title, price, day := testutils.SeedField(t, col, "title"), testutils.SeedField(t, col, "price"), testutils.SeedField(t, col, "day")
+	active, tags := testutils.SeedField(t, col, "active"), testutils.SeedField(t, col, "tags")
+
+	tests := []struct {
+		name string
+		cond FieldCondition
+		want []uint
+	}{
+		{"eq text", FieldCondition{Field: title, Op: FilterEq, Values: []string{"Learning Go"}}, []uint{learning}},
+		{"ne text", FieldCondition{Field: title, Op: FilterNe, Values: []string{"Learning Go"}}, []uint{goBook, rust}},
+		{"contains is case insensitive", FieldCondition{Field: title, Op: FilterContains, Values: []string{"go"}}, []uint{goBook, learning}},
+		{"contains escapes wildcards", FieldCondition{Field: title, Op: FilterContains, Values: []string{"%"}}, []uint{rust}},
+		{"gte number casts", FieldCondition{Field: price, Op: FilterGte, Values: []string{"10"}}, []uint{learning, rust}},
+		{"lt number", FieldCondition{Field: price, Op: FilterLt, Values: []string{"25"}}, []uint{goBook}},
+		{"eq number", FieldCondition{Field: price, Op: FilterEq, Values: []string{"25.0"}}, []uint{learning}},
+		{"gt date", FieldCondition{Field: day, Op: FilterGt, Values: []string{"2024-01-01"}}, []uint{goBook, learning}},
+		{"lte date", FieldCondition{Field: day, Op: FilterLte, Values: []string{"2023-12-24"}}, []uint{rust}},
+		{"boolean true", FieldCondition{Field: active, Op: FilterEq, Values: []string{"true"}}, []uint{goBook, rust}},
+		{"boolean false", FieldCondition{Field: active, Op: FilterEq, Values: []string{"false"}}, []uint{learning}},
+		{"in list field", FieldCondition{Field: tags, Op: FilterIn, Values: []string{"books", "rust"}}, []uint{goBook, rust}},
+		{"nin list field", FieldCondition{Field: tags, Op: FilterNin, Values: []string{"go"}}, []uint{rust}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, matchedIDs(t, repo, col.ID, false, tt.cond))
+		})
+	}
+}
+
+func TestMatchConditions_AndOr(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col, ids := seedProducts(t, db)
+	goBook, learning, rust := ids[0], ids[1], ids[2]
+
+	cheap := FieldCondition{Field: testutils.SeedField(t, col, "price"), Op: FilterLt, Values: []string{"30"}}
+	tagged := FieldCondition{Field: testutils.SeedField(t, col, "tags"), Op: FilterIn, Values: []string{"rust", "books"}}
+
+	assert.ElementsMatch(t, []uint{goBook}, matchedIDs(t, repo, col.ID, false, cheap, tagged))
+	assert.ElementsMatch(t, []uint{goBook, learning, rust}, matchedIDs(t, repo, col.ID, true, cheap, tagged))
+	assert.ElementsMatch(t, []uint{goBook, learning, rust}, matchedIDs(t, repo, col.ID, false))
+}
+
+func TestMatchConditions_InvalidConditions(t *testing.T) {
+	title, price, day, active := productFields[0], productFields[1], productFields[2], productFields[3]
+
+	tests := []FieldCondition{
+		{Field: price, Op: FilterGte, Values: []string{"cheap"}},
+		{Field: day, Op: FilterEq, Values: []string{"tomorrow"}},
+		{Field: active, Op: FilterEq, Values: []string{"maybe"}},
+		{Field: active, Op: FilterIn, Values: []string{"true"}},
+		{Field: title, Op: FilterGt, Values: []string{"a"}},
+		{Field: price, Op: FilterContains, Values: []string{"1"}},
+		{Field: title, Op: FilterEq, Values: []string{"a", "b"}},
+		{Field: title, Op: FilterEq},
+		{Field: title, Op: "like", Values: []string{"a"}},
+	}
+
+	for _, cond := range tests {
+		_, err := MatchConditions(false, cond)
+		assert.Error(t, err, "%s %s %v", cond.Field.Alias, cond.Op, cond.Values)
+	}
+}
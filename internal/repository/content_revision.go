@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"gorm.io/gorm"
+)
+
+type ContentRevisionRepo interface {
+	Create(revision *model.ContentRevision) error
+	FindByID(id uint, opts ...base.QueryOption) (*model.ContentRevision, error)
+	FindByContentID(contentID uint) ([]model.ContentRevision, error)
+	WithTx(tx *gorm.DB) ContentRevisionRepo
+}
+
+type contentRevisionRepository struct {
+	*base.BaseRepository[model.ContentRevision]
+	db *gorm.DB
+}
+
+func NewContentRevisionRepository(db *gorm.DB) ContentRevisionRepo {
+	return &contentRevisionRepository{
+		BaseRepository: base.NewBaseRepository[model.ContentRevision](db),
+		db:             db,
+	}
+}
+
+func (r *contentRevisionRepository) WithTx(tx *gorm.DB) ContentRevisionRepo {
+	return NewContentRevisionRepository(tx)
+}
+
+func (r *contentRevisionRepository) FindByContentID(contentID uint) ([]model.ContentRevision, error) {
+	var revisions []model.ContentRevision
+	err := r.db.
+		Preload("User").
+		Where("content_id = ?", contentID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&revisions).
+		Error
+	return revisions, err
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentRevisionRepository_FindByContentID(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRevisionRepository(db)
+
+	user := &model.User{Email: "rev@example.com", Password: "x", Role: model.RoleEditor}
+	db.Create(user)
+
+	first := &model.ContentRevision{ContentID: 1, UserID: &user.ID, Snapshot: "[]"}
+	second := &model.ContentRevision{ContentID: 1, Snapshot: "[]"}
+	other := &model.ContentRevision{ContentID: 2, Snapshot: "[]"}
+	assert.NoError(t, repo.Create(first))
+	assert.NoError(t, repo.Create(second))
+	assert.NoError(t, repo.Create(other))
+
+	revisions, err := repo.FindByContentID(1)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, second.ID, revisions[0].ID)
+	assert.Equal(t, first.ID, revisions[1].ID)
+	assert.NotNil(t, revisions[1].User)
+	assert.Equal(t, "rev@example.com", revisions[1].User.Email)
+
+	found, err := repo.WithTx(db).FindByID(other.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), found.ContentID)
+}
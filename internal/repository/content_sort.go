@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var SystemSortColumns = map[string]string{
+	"id":           "contents.id",
+	"created_at":   "contents.created_at",
+	"updated_at":   "contents.updated_at",
+	"status":       "contents.status",
+	"publish_at":   "contents.publish_at",
+	"unpublish_at": "contents.unpublish_at",
+}
+
+type SortKey struct {
+	System string
+	Field  *model.Field
+	Desc   bool
+}
+
+func sortColumn(fieldType model.FieldType) string {
+	switch fieldType {
+	case model.FieldTypeNumber:
+		return "CAST(NULLIF(cv.value, '') AS REAL)"
+	case model.FieldTypeDate:
+		return "NULLIF(substr(cv.value, 1, 10), '')"
+	default:
+		return "cv.value"
+	}
+}
+
+func (k SortKey) orderBy() (string, []any) {
+	direction := " ASC"
+	if k.Desc {
+		direction = " DESC"
+	}
+
+	if k.Field == nil {
+		return SystemSortColumns[k.System] + direction, nil
+	}
+
+	return "(SELECT " + sortColumn(k.Field.FieldType) + " FROM content_values cv " +
+		"WHERE cv.content_id = contents.id AND cv.field_id = ? AND cv.locale = '' AND cv.deleted_at IS NULL " +
+		"ORDER BY cv.sort_index LIMIT 1)" + direction, []any{k.Field.ID}
+}
+
+func OrderBy(keys ...SortKey) base.QueryOption {
+	terms := make([]string, 0, len(keys)+1)
+	var vars []any
+	for _, k := range keys {
+		term, args := k.orderBy()
+		terms = append(terms, term)
+		vars = append(vars, args...)
+	}
+	terms = append(terms, "contents.id ASC")
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                strings.Join(terms, ", "),
+			Vars:               vars,
+			WithoutParentheses: true,
+		}})
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBy(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col, ids := seedProducts(t, db)
+	goBook, learning, rust := ids[0], ids[1], ids[2]
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	db.Model(&model.Content{}).Where("id = ?", goBook).Update("created_at", base.Add(2*time.Hour))
+	db.Model(&model.Content{}).Where("id = ?", learning).Update("created_at", base)
+	db.Model(&model.Content{}).Where("id = ?", rust).Update("created_at", base.Add(time.Hour))
+
+	price := testutils.SeedField(t, col, "price")
+	day := testutils.SeedField(t, col, "day")
+	title := testutils.SeedField(t, col, "title")
+	active := testutils.SeedField(t, col, "active")
+
+	tests := []struct {
+		name string
+		keys []SortKey
+		want []uint
+	}{
+		{"default is id", nil, []uint{goBook, learning, rust}},
+		{"created_at desc", []SortKey{{System: "created_at", Desc: true}}, []uint{goBook, rust, learning}},
+		{"number is numeric", []SortKey{{Field: &price}}, []uint{goBook, learning, rust}},
+		{"number desc", []SortKey{{Field: &price, Desc: true}}, []uint{rust, learning, goBook}},
+		{"date", []SortKey{{Field: &day}}, []uint{rust, goBook, learning}},
+		{"text", []SortKey{{Field: &title}}, []uint{goBook, learning, rust}},
+		{"multiple keys", []SortKey{{Field: &active}, {Field: &price, Desc: true}}, []uint{learning, rust, goBook}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := repo.FindByCollectionID(col.ID, 0, 0, OrderBy(tt.keys...))
+			assert.NoError(t, err)
+
+			ids := []uint{}
+			for _, c := range list {
+				ids = append(ids, c.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestOrderBy_FindDisplayValueByCollectionID(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col, ids := seedProducts(t, db)
+	price := testutils.SeedField(t, col, "price")
+
+	list, total, err := repo.FindDisplayValueByCollectionID(col.ID, 1, 2, OrderBy(SortKey{Field: &price, Desc: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, ids[2], list[0].ID)
+		assert.Equal(t, ids[1], list[1].ID)
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/testutils"
@@ -100,6 +101,19 @@ func TestContentRepository_ListWithDisplayContentValue(t *testing.T) {
 	}
 }
 
+func TestContentRepository_CollectionIDsByIDs(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	a := &model.Content{CollectionID: 1}
+	b := &model.Content{CollectionID: 2}
+	repo.Create(a)
+	repo.Create(b)
+
+	ids, err := repo.CollectionIDsByIDs([]uint{a.ID, b.ID, 999})
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint]uint{a.ID: 1, b.ID: 2}, ids)
+}
+
 func TestContentRepository_FindByCollectionAndFieldValue(t *testing.T) {
 	db := testutils.SetupTestDB(t)
 	repo := NewContentRepository(db)
@@ -119,3 +133,166 @@ func TestContentRepository_FindByCollectionAndFieldValue(t *testing.T) {
 	assert.Len(t, list, 1)
 	assert.Equal(t, c1.ID, list[0].ID)
 }
+
+func TestContentRepository_WithStatusAndUpdateStatus(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+	published := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+	draft := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft}
+	repo.Create(published)
+	repo.Create(draft)
+
+	list, err := repo.FindByCollectionID(col.ID, 0, 0, WithStatus(model.ContentStatusPublished))
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, published.ID, list[0].ID)
+
+	assert.NoError(t, repo.UpdateStatus(draft.ID, model.ContentStatusPublished))
+	found, err := repo.FindByID(draft.ID, WithStatus(model.ContentStatusPublished))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ContentStatusPublished, found.Status)
+}
+
+func TestContentRepository_VisibleAtAndSchedule(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	live := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+	duePublish := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft, PublishAt: &past}
+	embargoed := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished, PublishAt: &future}
+	expired := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished, UnpublishAt: &past}
+	draft := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft}
+	for _, c := range []*model.Content{live, duePublish, embargoed, expired, draft} {
+		assert.NoError(t, repo.Create(c))
+	}
+
+	list, err := repo.FindByCollectionID(col.ID, 0, 0, VisibleAt(now))
+	assert.NoError(t, err)
+	ids := []uint{}
+	for _, c := range list {
+		ids = append(ids, c.ID)
+	}
+	assert.ElementsMatch(t, []uint{live.ID, duePublish.ID}, ids)
+
+	toPublish, err := repo.FindDueForPublish(now)
+	assert.NoError(t, err)
+	assert.Len(t, toPublish, 1)
+	assert.Equal(t, duePublish.ID, toPublish[0].ID)
+
+	toUnpublish, err := repo.FindDueForUnpublish(now)
+	assert.NoError(t, err)
+	assert.Len(t, toUnpublish, 1)
+	assert.Equal(t, expired.ID, toUnpublish[0].ID)
+
+	assert.NoError(t, repo.UpdateSchedule(duePublish.ID, nil, &future))
+	found, err := repo.FindByID(duePublish.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found.PublishAt)
+	assert.NotNil(t, found.UnpublishAt)
+}
+
+func TestContentRepository_CountByCollectionIDAndAfterID(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+	other := &model.Collection{Name: "other"}
+	db.Create(other)
+
+	var ids []uint
+	for i := 0; i < 3; i++ {
+		c := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+		repo.Create(c)
+		ids = append(ids, c.ID)
+	}
+	repo.Create(&model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft})
+	repo.Create(&model.Content{CollectionID: other.ID})
+
+	total, err := repo.CountByCollectionID(col.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	total, err = repo.CountByCollectionID(col.ID, WithStatus(model.ContentStatusPublished))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	list, err := repo.FindByCollectionID(col.ID, 0, 0, WithStatus(model.ContentStatusPublished), AfterID(ids[0]))
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func TestContentRepository_WithValueFields(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col, ids := seedProducts(t, db)
+
+	entry, err := repo.FindByID(ids[0], WithValueFields("title", "tags"))
+	assert.NoError(t, err)
+	aliases := map[string]int{}
+	for _, cv := range entry.ContentValues {
+		aliases[cv.Field.Alias]++
+	}
+	assert.Equal(t, map[string]int{"title": 1, "tags": 2}, aliases)
+
+	list, err := repo.FindByCollectionID(col.ID, 0, 0, WithValueFields("price"))
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	for _, c := range list {
+		if assert.Len(t, c.ContentValues, 1) {
+			assert.Equal(t, "price", c.ContentValues[0].Field.Alias)
+		}
+	}
+
+	list, err = repo.FindByCollectionID(col.ID, 0, 0, WithValueFields())
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	assert.Empty(t, list[0].ContentValues)
+}
+
+func TestContentRepository_LastModifiedByCollectionID(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+	other := &model.Collection{Name: "other"}
+	db.Create(other)
+
+	empty, err := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, empty.IsZero())
+
+	c := &model.Content{CollectionID: col.ID}
+	repo.Create(c)
+	created, err := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	assert.NoError(t, err)
+	assert.WithinDuration(t, c.UpdatedAt, created, time.Millisecond)
+
+	repo.Create(&model.Content{CollectionID: other.ID})
+	unchanged, _ := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	assert.True(t, unchanged.Equal(created))
+
+	time.Sleep(5 * time.Millisecond)
+	db.Create(&model.ContentValue{ContentID: c.ID, Value: "x"})
+	withValue, _ := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	assert.True(t, withValue.After(created))
+
+	future := time.Now().Add(time.Hour)
+	repo.UpdateSchedule(c.ID, &future, nil)
+	beforePublish, _ := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	published, _ := repo.LastModifiedByCollectionID(col.ID, future.Add(time.Minute))
+	assert.WithinDuration(t, future, published, time.Millisecond)
+	assert.True(t, published.After(beforePublish))
+
+	time.Sleep(5 * time.Millisecond)
+	assert.NoError(t, repo.DeleteByID(c.ID))
+	deleted, _ := repo.LastModifiedByCollectionID(col.ID, time.Now())
+	assert.True(t, deleted.After(beforePublish))
+}
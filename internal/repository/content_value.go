@@ -9,6 +9,8 @@ import (
 type ContentValueRepo interface {
 	base.CRUDRepository[model.ContentValue]
 	FindByContentID(cID uint) ([]model.ContentValue, error)
+	ValueExists(fieldID uint, locale, value string, excludeContentID uint) (bool, error)
+	HasDuplicateValues(fieldID uint) (bool, error)
 	WithTx(tx *gorm.DB) ContentValueRepo
 }
 
@@ -36,3 +38,32 @@ func (r *contentValueRepository) FindByContentID(cID uint) ([]model.ContentValue
 		Error
 	return cvs, err
 }
+
+// ValueExists reports whether another content entry stores the value for the
+// field in the given locale.
+func (r *contentValueRepository) ValueExists(fieldID uint, locale, value string, excludeContentID uint) (bool, error) {
+	var count int64
+	err := r.db.
+		Model(&model.ContentValue{}).
+		Where("field_id = ? AND locale = ? AND value = ? AND content_id <> ?", fieldID, locale, value, excludeContentID).
+		Limit(1).
+		Count(&count).
+		Error
+	return count > 0, err
+}
+
+// HasDuplicateValues reports whether two content entries share a non-empty
+// value of the field in the same locale.
+func (r *contentValueRepository) HasDuplicateValues(fieldID uint) (bool, error) {
+	var duplicates []string
+	err := r.db.
+		Model(&model.ContentValue{}).
+		Select("value").
+		Where("field_id = ? AND value <> ''", fieldID).
+		Group("locale, value").
+		Having("COUNT(DISTINCT content_id) > 1").
+		Limit(1).
+		Pluck("value", &duplicates).
+		Error
+	return len(duplicates) > 0, err
+}
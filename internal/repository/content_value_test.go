@@ -34,3 +34,58 @@ func TestContentValueRepository_FindByContentID(t *testing.T) {
 	assert.NoError(t, err2)
 	assert.Len(t, list2, 2)
 }
+
+func TestContentValueRepository_ValueExists(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentValueRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+	f := &model.Field{Name: "F", Alias: "f", FieldType: "text", CollectionID: col.ID}
+	db.Create(f)
+	c := &model.Content{CollectionID: col.ID}
+	db.Create(c)
+	repo.Create(&model.ContentValue{ContentID: c.ID, FieldID: f.ID, Value: "slug"})
+	repo.Create(&model.ContentValue{ContentID: c.ID, FieldID: f.ID, Locale: "de", Value: "pfad"})
+
+	exists, err := repo.ValueExists(f.ID, "", "slug", 0)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, _ = repo.ValueExists(f.ID, "", "slug", c.ID)
+	assert.False(t, exists, "the entry itself is excluded")
+
+	exists, _ = repo.ValueExists(f.ID, "de", "slug", 0)
+	assert.False(t, exists, "values are compared per locale")
+
+	exists, _ = repo.ValueExists(f.ID, "de", "pfad", 0)
+	assert.True(t, exists)
+}
+
+func TestContentValueRepository_HasDuplicateValues(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewContentValueRepository(db)
+	col := &model.Collection{Name: "col"}
+	db.Create(col)
+	f := &model.Field{Name: "F", Alias: "f", FieldType: "text", CollectionID: col.ID}
+	db.Create(f)
+	c1 := &model.Content{CollectionID: col.ID}
+	db.Create(c1)
+	c2 := &model.Content{CollectionID: col.ID}
+	db.Create(c2)
+
+	repo.Create(&model.ContentValue{ContentID: c1.ID, FieldID: f.ID, Value: "a"})
+	repo.Create(&model.ContentValue{ContentID: c1.ID, FieldID: f.ID, Value: "a"})
+	repo.Create(&model.ContentValue{ContentID: c1.ID, FieldID: f.ID, Locale: "de", Value: "b"})
+	repo.Create(&model.ContentValue{ContentID: c2.ID, FieldID: f.ID, Value: "b"})
+	repo.Create(&model.ContentValue{ContentID: c1.ID, FieldID: f.ID, Value: ""})
+	repo.Create(&model.ContentValue{ContentID: c2.ID, FieldID: f.ID, Value: ""})
+
+	duplicates, err := repo.HasDuplicateValues(f.ID)
+	assert.NoError(t, err)
+	assert.False(t, duplicates)
+
+	repo.Create(&model.ContentValue{ContentID: c2.ID, FieldID: f.ID, Value: "a"})
+	duplicates, err = repo.HasDuplicateValues(f.ID)
+	assert.NoError(t, err)
+	assert.True(t, duplicates)
+}
@@ -30,9 +30,14 @@ func (r *fieldRepository) WithTx(tx *gorm.DB) FieldRepo {
 	return NewFieldRepository(tx)
 }
 
+func preloadOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("field_options.id")
+}
+
 func (r *fieldRepository) FindByCollectionID(collectionID uint) ([]model.Field, error) {
 	var fields []model.Field
 	err := r.db.
+		Preload("Options", preloadOptions).
 		Where("collection_id = ?", collectionID).
 		Find(&fields).
 		Error
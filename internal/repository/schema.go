@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SchemaRepo answers questions about the content model as a whole, across
+// collections, fields and their options.
+type SchemaRepo interface {
+	Fingerprint() (string, error)
+}
+
+type schemaRepository struct {
+	db *gorm.DB
+}
+
+func NewSchemaRepository(db *gorm.DB) SchemaRepo {
+	return &schemaRepository{db: db}
+}
+
+// Fingerprint changes whenever a collection, field or field option is added,
+// edited or deleted, so schemas built from them know when to rebuild.
+func (r *schemaRepository) Fingerprint() (string, error) {
+	var row struct {
+		Fields             int64
+		Collections        int64
+		Options            int64
+		FieldsUpdated      sql.NullString
+		FieldsDeleted      sql.NullString
+		CollectionsUpdated sql.NullString
+		CollectionsDeleted sql.NullString
+		OptionsUpdated     sql.NullString
+		OptionsDeleted     sql.NullString
+	}
+
+	err := r.db.Raw(`SELECT
+		(SELECT COUNT(*) FROM fields) AS fields,
+		(SELECT COUNT(*) FROM collections) AS collections,
+		(SELECT COUNT(*) FROM field_options) AS options,
+		(SELECT MAX(updated_at) FROM fields) AS fields_updated,
+		(SELECT MAX(deleted_at) FROM fields) AS fields_deleted,
+		(SELECT MAX(updated_at) FROM collections) AS collections_updated,
+		(SELECT MAX(deleted_at) FROM collections) AS collections_deleted,
+		(SELECT MAX(updated_at) FROM field_options) AS options_updated,
+		(SELECT MAX(deleted_at) FROM field_options) AS options_deleted`).
+		Scan(&row).
+		Error
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d/%d/%d/%s/%s/%s/%s/%s/%s", row.Fields, row.Collections, row.Options,
+		row.FieldsUpdated.String, row.FieldsDeleted.String,
+		row.CollectionsUpdated.String, row.CollectionsDeleted.String,
+		row.OptionsUpdated.String, row.OptionsDeleted.String), nil
+}
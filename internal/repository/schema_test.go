@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRepository_Fingerprint(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewSchemaRepository(db)
+	fields := NewFieldRepository(db)
+
+	empty, err := repo.Fingerprint()
+	assert.NoError(t, err)
+
+	col := &model.Collection{Name: "Col", Alias: "col"}
+	db.Create(col)
+	withCollection, _ := repo.Fingerprint()
+	assert.NotEqual(t, empty, withCollection)
+
+	f := &model.Field{Name: "F", Alias: "f", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	fields.Create(f)
+	withField, _ := repo.Fingerprint()
+	assert.NotEqual(t, withCollection, withField)
+
+	again, _ := repo.Fingerprint()
+	assert.Equal(t, withField, again)
+
+	f.FieldType = model.FieldTypeNumber
+	f.UpdatedAt = f.UpdatedAt.Add(time.Second)
+	db.Model(f).UpdateColumns(map[string]any{"field_type": f.FieldType, "updated_at": f.UpdatedAt})
+	changed, _ := repo.Fingerprint()
+	assert.NotEqual(t, withField, changed)
+
+	fields.Delete(f)
+	deleted, _ := repo.Fingerprint()
+	assert.NotEqual(t, changed, deleted)
+}
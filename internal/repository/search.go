@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	SnippetStart = "\x02"
+	SnippetEnd   = "\x03"
+
+	snippetRadius = 60
+)
+
+type SearchHit struct {
+	ContentID uint
+	Rank      float64
+	Snippet   string
+}
+
+type SearchRepo interface {
+	Save(doc *model.SearchDocument) error
+	DeleteByContentID(contentID uint) error
+	Count() (int64, error)
+	Search(query string, collectionID uint, offset, limit int, opts ...base.QueryOption) ([]SearchHit, int64, error)
+	Matching(query string) base.QueryOption
+	FullText() bool
+	WithTx(tx *gorm.DB) SearchRepo
+}
+
+type searchRepository struct {
+	db  *gorm.DB
+	fts bool
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepo {
+	return &searchRepository{db: db, fts: ensureFullTextIndex(db)}
+}
+
+// ensureFullTextIndex creates an FTS5 index over search_documents. It only
+// works on SQLite builds with FTS5 (go-sqlite3 needs the sqlite_fts5 build
+// tag), everything else searches with LIKE.
+func ensureFullTextIndex(db *gorm.DB) bool {
+	if db.Dialector.Name() != "sqlite" {
+		return false
+	}
+
+	var available int64
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available).Error; err != nil || available == 0 {
+		return false
+	}
+
+	var existing int64
+	if err := db.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'search_index'").Scan(&existing).Error; err != nil {
+		return false
+	}
+	if existing > 0 {
+		return true
+	}
+
+	statements := []string{
+		"CREATE VIRTUAL TABLE search_index USING fts5(body, content='search_documents', content_rowid='content_id', tokenize='unicode61 remove_diacritics 2')",
+		"CREATE TRIGGER IF NOT EXISTS search_documents_ai AFTER INSERT ON search_documents BEGIN INSERT INTO search_index(rowid, body) VALUES (new.content_id, new.body); END",
+		"CREATE TRIGGER IF NOT EXISTS search_documents_ad AFTER DELETE ON search_documents BEGIN INSERT INTO search_index(search_index, rowid, body) VALUES ('delete', old.content_id, old.body); END",
+		"CREATE TRIGGER IF NOT EXISTS search_documents_au AFTER UPDATE ON search_documents BEGIN INSERT INTO search_index(search_index, rowid, body) VALUES ('delete', old.content_id, old.body); INSERT INTO search_index(rowid, body) VALUES (new.content_id, new.body); END",
+		"INSERT INTO search_index(search_index) VALUES ('rebuild')",
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err == nil
+}
+
+func (r *searchRepository) WithTx(tx *gorm.DB) SearchRepo {
+	return &searchRepository{db: tx, fts: r.fts}
+}
+
+func (r *searchRepository) FullText() bool {
+	return r.fts
+}
+
+func (r *searchRepository) Save(doc *model.SearchDocument) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"collection_id", "body"}),
+	}).Create(doc).Error
+}
+
+func (r *searchRepository) DeleteByContentID(contentID uint) error {
+	return r.db.Where("content_id = ?", contentID).Delete(&model.SearchDocument{}).Error
+}
+
+func (r *searchRepository) Count() (int64, error) {
+	var total int64
+	return total, r.db.Model(&model.SearchDocument{}).Count(&total).Error
+}
+
+func searchTerms(query string) []string {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		term = strings.ToLower(strings.Trim(term, `"*`))
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// matchExpression quotes every term, so user input never reaches the FTS5
+// query syntax, and matches it as a prefix.
+func matchExpression(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+	return strings.Join(quoted, " ")
+}
+
+func likePattern(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(term) + "%"
+}
+
+func (r *searchRepository) likeConditions(db *gorm.DB, terms []string) *gorm.DB {
+	for _, term := range terms {
+		db = db.Where(`LOWER(search_documents.body) LIKE ? ESCAPE '\'`, likePattern(term))
+	}
+	return db
+}
+
+func (r *searchRepository) Matching(query string) base.QueryOption {
+	terms := searchTerms(query)
+	return func(db *gorm.DB) *gorm.DB {
+		if len(terms) == 0 {
+			return db
+		}
+
+		if r.fts {
+			return db.Where("contents.id IN (SELECT rowid FROM search_index WHERE search_index MATCH ?)", matchExpression(terms))
+		}
+
+		sub := r.likeConditions(r.db.Session(&gorm.Session{NewDB: true}).Model(&model.SearchDocument{}).Select("content_id"), terms)
+		return db.Where("contents.id IN (?)", sub)
+	}
+}
+
+func (r *searchRepository) Search(query string, collectionID uint, offset, limit int, opts ...base.QueryOption) ([]SearchHit, int64, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	scoped := func() *gorm.DB {
+		var db *gorm.DB
+		if r.fts {
+			db = r.db.Table("search_index").
+				Joins("JOIN contents ON contents.id = search_index.rowid AND contents.deleted_at IS NULL").
+				Where("search_index MATCH ?", matchExpression(terms))
+		} else {
+			db = r.likeConditions(r.db.Table("search_documents").
+				Joins("JOIN contents ON contents.id = search_documents.content_id AND contents.deleted_at IS NULL"), terms)
+		}
+		if collectionID > 0 {
+			db = db.Where("contents.collection_id = ?", collectionID)
+		}
+		return applyOptions(db, opts)
+	}
+
+	var total int64
+	if err := scoped().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []SearchHit
+	if r.fts {
+		err := scoped().
+			Select("search_index.rowid AS content_id, -bm25(search_index) AS rank, snippet(search_index, 0, ?, ?, '…', 16) AS snippet", SnippetStart, SnippetEnd).
+			Order("bm25(search_index)").
+			Order("contents.id").
+			Offset(offset).
+			Limit(limit).
+			Scan(&hits).Error
+		return hits, total, err
+	}
+
+	err := scoped().
+		Select("search_documents.content_id AS content_id, 0 AS rank, search_documents.body AS snippet").
+		Order("contents.updated_at DESC").
+		Order("contents.id").
+		Offset(offset).
+		Limit(limit).
+		Scan(&hits).Error
+	for i := range hits {
+		hits[i].Snippet = likeSnippet(hits[i].Snippet, terms)
+	}
+	return hits, total, err
+}
+
+// likeSnippet cuts the text around the first match and marks every term like
+// the FTS5 snippet function does.
+func likeSnippet(body string, terms []string) string {
+	lower := strings.ToLower(body)
+	if len(lower) != len(body) {
+		// lowering changed byte offsets, fall back to the start of the text
+		lower = body
+	}
+
+	start := len(body)
+	for _, term := range terms {
+		if i := strings.Index(lower, term); i >= 0 && i < start {
+			start = i
+		}
+	}
+	if start == len(body) {
+		start = 0
+	}
+
+	from, to := max(0, start-snippetRadius), min(len(body), start+2*snippetRadius)
+	for from > 0 && !utf8.RuneStart(body[from]) {
+		from--
+	}
+	for to < len(body) && !utf8.RuneStart(body[to]) {
+		to++
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+
+	text, lowerText := body[from:to], lower[from:to]
+	for i := 0; i < len(text); {
+		matched := ""
+		for _, term := range terms {
+			if strings.HasPrefix(lowerText[i:], term) && len(term) > len(matched) {
+				matched = term
+			}
+		}
+		if matched == "" {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+		b.WriteString(SnippetStart + text[i:i+len(matched)] + SnippetEnd)
+		i += len(matched)
+	}
+
+	if to < len(body) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func indexDocument(t *testing.T, repo SearchRepo, content *model.Content, body string) {
+	t.Helper()
+	assert.NoError(t, repo.Save(&model.SearchDocument{ContentID: content.ID, CollectionID: content.CollectionID, Body: body}))
+}
+
+func hitIDs(hits []SearchHit) []uint {
+	ids := make([]uint, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ContentID)
+	}
+	return ids
+}
+
+func TestSearchRepository_Search(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewSearchRepository(db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"})
+	news := testutils.SeedCollection(t, db, &model.Collection{Name: "News", Alias: "news"})
+	first := testutils.SeedContent(t, db, blog, model.ContentStatusPublished, nil)
+	draft := testutils.SeedContent(t, db, blog, model.ContentStatusDraft, nil)
+	other := testutils.SeedContent(t, db, news, model.ContentStatusPublished, nil)
+	indexDocument(t, repo, first, "Baking sourdough bread at home")
+	indexDocument(t, repo, draft, "Draft about bread")
+	indexDocument(t, repo, other, "Bakery opens, bread for 100% of the town")
+
+	hits, total, err := repo.Search("bread", 0, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.ElementsMatch(t, []uint{first.ID, draft.ID, other.ID}, hitIDs(hits))
+
+	hits, total, err = repo.Search("BREAD sourdough", 0, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, hits, 1) {
+		assert.Equal(t, first.ID, hits[0].ContentID)
+		assert.Contains(t, hits[0].Snippet, SnippetStart+"sourdough"+SnippetEnd)
+	}
+
+	hits, _, err = repo.Search("bread", blog.ID, 0, 10, VisibleAt(time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{first.ID}, hitIDs(hits))
+
+	hits, total, err = repo.Search("bread", 0, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, hits, 1)
+
+	hits, total, err = repo.Search(`"  *`, 0, 0, 10)
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, hits)
+}
+
+func TestSearchRepository_SaveReplacesAndDeleteRemoves(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewSearchRepository(db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"})
+	first := testutils.SeedContent(t, db, blog, model.ContentStatusPublished, nil)
+	other := testutils.SeedContent(t, db, blog, model.ContentStatusPublished, nil)
+	indexDocument(t, repo, first, "Baking sourdough bread at home")
+	indexDocument(t, repo, other, "Bakery opens")
+
+	indexDocument(t, repo, first, "Pasta recipes")
+	_, total, _ := repo.Search("sourdough", 0, 0, 10)
+	assert.Zero(t, total)
+	_, total, _ = repo.Search("pasta", 0, 0, 10)
+	assert.Equal(t, int64(1), total)
+
+	assert.NoError(t, repo.DeleteByContentID(first.ID))
+	_, total, _ = repo.Search("pasta", 0, 0, 10)
+	assert.Zero(t, total)
+
+	count, err := repo.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSearchRepository_Matching(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repo := NewSearchRepository(db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"})
+	first := testutils.SeedContent(t, db, blog, model.ContentStatusPublished, nil)
+	other := testutils.SeedContent(t, db, blog, model.ContentStatusPublished, nil)
+	indexDocument(t, repo, first, "Baking sourdough bread at home")
+	indexDocument(t, repo, other, "Bakery opens, bread for 100% of the town")
+
+	var ids []uint
+	err := repo.Matching("bakery")(db.Model(&model.Content{})).Pluck("contents.id", &ids).Error
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{other.ID}, ids)
+
+	ids = nil
+	err = repo.Matching("100%")(db.Model(&model.Content{})).Pluck("contents.id", &ids).Error
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{other.ID}, ids)
+}
+
+func TestLikeSnippet(t *testing.T) {
+	assert.Equal(t, "A "+SnippetStart+"Bread"+SnippetEnd+" recipe", likeSnippet("A Bread recipe", []string{"bread"}))
+
+	long := "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Bread is here and the text goes on for a while longer than the snippet."
+	snippet := likeSnippet(long, []string{"bread"})
+	assert.Contains(t, snippet, SnippetStart+"Bread"+SnippetEnd)
+	assert.True(t, len(snippet) < len(long))
+	assert.Contains(t, snippet, "…")
+}
@@ -10,10 +10,13 @@ type Set struct {
 	FieldOption  FieldOptionRepo
 	Collection   CollectionRepo
 	ContentValue ContentValueRepo
+	Revision     ContentRevisionRepo
 	Asset        AssetRepo
 	User         UserRepo
 	Apikey       ApikeyRepo
 	Webhook      WebhookRepo
+	Search       SearchRepo
+	Schema       SchemaRepo
 }
 
 func NewSet(db *gorm.DB) *Set {
@@ -23,9 +26,12 @@ func NewSet(db *gorm.DB) *Set {
 		FieldOption:  NewFieldOptionRepository(db),
 		Collection:   NewCollectionRepository(db),
 		ContentValue: NewContentValueRepository(db),
+		Revision:     NewContentRevisionRepository(db),
 		Asset:        NewAssetRepository(db),
 		User:         NewUserRepository(db),
 		Apikey:       NewApikeyRepository(db),
 		Webhook:      NewWebhookRepository(db),
+		Search:       NewSearchRepository(db),
+		Schema:       NewSchemaRepository(db),
 	}
 }
@@ -14,8 +14,10 @@ func TestNewSet_RepositoriesNotNil(t *testing.T) {
 	assert.NotNil(t, s.Field)
 	assert.NotNil(t, s.Collection)
 	assert.NotNil(t, s.ContentValue)
+	assert.NotNil(t, s.Revision)
 	assert.NotNil(t, s.Asset)
 	assert.NotNil(t, s.User)
 	assert.NotNil(t, s.Apikey)
 	assert.NotNil(t, s.Webhook)
+	assert.NotNil(t, s.Schema)
 }
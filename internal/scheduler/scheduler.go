@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+)
+
+type Scheduler struct {
+	services *service.Set
+	interval time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	running  bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func New(services *service.Set, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		services: services,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	s.running = true
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func(stop, done chan struct{}) {
+		defer close(done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.tick()
+		for {
+			select {
+			case <-ticker.C:
+				s.tick()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop, s.done)
+}
+
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+	s.running = false
+}
+
+func (s *Scheduler) tick() {
+	if err := s.Run(s.now()); err != nil {
+		fmt.Println("Scheduler error:", err)
+	}
+}
+
+func (s *Scheduler) Run(now time.Time) error {
+	changes, err := s.services.Content.ApplySchedule(now)
+
+	for _, change := range changes {
+		s.services.Events.Publish(service.EventUpdated, change.ContentID, change.CollectionID)
+		if event, ok := model.StatusChangeEvent(change.From, change.To); ok {
+			s.services.Webhook.Dispatch(string(event), nil)
+		}
+	}
+
+	return err
+}
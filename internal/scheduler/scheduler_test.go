@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduler_Run_DispatchesEvents(t *testing.T) {
+	mockContent := &testutils.MockContentService{}
+	mockWebhook := &testutils.MockWebhookService{}
+	mockEvents := &testutils.MockEventService{}
+	s := New(&service.Set{Content: mockContent, Webhook: mockWebhook, Events: mockEvents}, time.Minute)
+
+	now := time.Now()
+	mockContent.On("ApplySchedule", now).Return([]dto.StatusChange{
+		{ContentID: 1, CollectionID: 3, From: model.ContentStatusDraft, To: model.ContentStatusPublished},
+		{ContentID: 2, CollectionID: 3, From: model.ContentStatusPublished, To: model.ContentStatusDraft},
+	}, nil)
+	mockEvents.On("Publish", service.EventUpdated, uint(1), uint(3)).Once()
+	mockEvents.On("Publish", service.EventUpdated, uint(2), uint(3)).Once()
+	mockWebhook.On("Dispatch", string(model.EventContentPublished), nil).Return(nil).Once()
+	mockWebhook.On("Dispatch", string(model.EventContentUnpublished), nil).Return(nil).Once()
+
+	assert.NoError(t, s.Run(now))
+	mockWebhook.AssertExpectations(t)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestScheduler_Run_Error(t *testing.T) {
+	mockContent := &testutils.MockContentService{}
+	mockWebhook := &testutils.MockWebhookService{}
+	s := New(&service.Set{Content: mockContent, Webhook: mockWebhook}, time.Minute)
+
+	now := time.Now()
+	mockContent.On("ApplySchedule", now).Return(nil, errors.New("db down"))
+
+	assert.Error(t, s.Run(now))
+	mockWebhook.AssertNotCalled(t, "Dispatch", mock.Anything, mock.Anything)
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	mockContent := &testutils.MockContentService{}
+	s := New(&service.Set{Content: mockContent}, time.Hour)
+
+	ran := make(chan struct{}, 1)
+	mockContent.On("ApplySchedule", mock.Anything).Return([]dto.StatusChange{}, nil).Run(func(args mock.Arguments) {
+		ran <- struct{}{}
+	})
+
+	s.Start()
+	s.Start()
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not run on start")
+	}
+
+	s.Stop()
+	s.Stop()
+}
@@ -1,17 +1,24 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
-	"github.com/janmarkuslanger/nuricms/internal/utils"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"gorm.io/gorm"
 )
 
 type ApiService interface {
-	FindContentByCollectionAlias(alias string, offset int, perPage int) ([]dto.ContentItemResponse, error)
-	FindContentByID(id uint) (dto.ContentItemResponse, error)
-	FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int) ([]dto.ContentItemResponse, error)
-	PrepareContent(ce *model.Content) (dto.ContentItemResponse, error)
+	FindContentByCollectionAlias(alias string, offset int, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error)
+	FindContentByID(id uint, q dto.ApiQuery) (dto.ContentItemResponse, error)
+	FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error)
+	PrepareContent(ce *model.Content, q dto.ApiQuery) (dto.ContentItemResponse, error)
+	CollectionLastModified(alias string) (time.Time, error)
 }
 
 type apiService struct {
@@ -24,112 +31,170 @@ func NewApiService(repos *repository.Set) ApiService {
 	}
 }
 
-func (s *apiService) PrepareContent(ce *model.Content) (dto.ContentItemResponse, error) {
-	values := make(map[string]any, len(ce.ContentValues))
+func visibilityOptions(q dto.ApiQuery) []base.QueryOption {
+	if q.Preview {
+		return nil
+	}
 
-	for _, cv := range ce.ContentValues {
+	return []base.QueryOption{repository.VisibleAt(time.Now())}
+}
 
-		alias := cv.Field.Alias
+func projectionOptions(q dto.ApiQuery) []base.QueryOption {
+	if q.Fields == nil {
+		return nil
+	}
 
-		cvr := dto.ContentValueResponse{
-			ID:        cv.ID,
-			Value:     cv.Value,
-			FieldType: cv.Field.FieldType,
-		}
+	return []base.QueryOption{repository.WithValueFields(q.Fields...)}
+}
 
-		if cv.Field.FieldType == model.FieldTypeCollection {
-			id, _ := utils.StringToUint(cv.Value)
-			con, err := s.repos.Content.FindByID(id)
-			if err == nil {
-				cvr.Collection = &dto.CollectionResponse{
-					ID:    con.CollectionID,
-					Name:  con.Collection.Name,
-					Alias: con.Collection.Alias,
-				}
-			}
-		}
+var (
+	ErrInvalidFilter      = errors.New("invalid filter")
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrContentNotFound    = errors.New("content not found")
+)
 
-		if cv.Field.FieldType == model.FieldTypeAsset {
-			id, _ := utils.StringToUint(cv.Value)
-			ass, err := s.repos.Asset.FindByID(id)
-			if err == nil {
-				cvr.Asset = &dto.AssetResponse{
-					ID:   ass.ID,
-					Name: ass.Name,
-					Path: ass.Path,
-				}
-			}
-		}
+func notFound(err error, sentinel error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return sentinel
+	}
+	return err
+}
+
+func (s *apiService) queryOptions(collectionID uint, q dto.ApiQuery) ([]base.QueryOption, base.QueryOption, error) {
+	opts := visibilityOptions(q)
+	hasFilter := q.Filter != nil && len(q.Filter.Conditions) > 0
+	if !hasFilter && q.Sort == "" {
+		return opts, repository.OrderBy(), nil
+	}
+
+	fields, err := s.repos.Field.FindByCollectionID(collectionID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if cv.Field.IsList {
-			items, ok := values[alias].([]any)
-			if !ok {
-				items = []any{}
-			}
-			items = append(items, cvr)
-			values[alias] = items
+	sortKeys, err := parseSort(q.Sort, fields)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		} else {
-			values[alias] = cvr
+	if hasFilter {
+		match, err := filterOption(q.Filter, fields)
+		if err != nil {
+			return nil, nil, err
 		}
+		opts = append(opts, match)
 	}
 
-	return dto.ContentItemResponse{
-		ID:        ce.ID,
-		CreatedAt: ce.CreatedAt,
-		UpdatedAt: ce.UpdatedAt,
-		Values:    values,
-		Collection: dto.CollectionResponse{
-			Alias: ce.Collection.Alias,
-			ID:    ce.CollectionID,
-			Name:  ce.Collection.Name,
-		},
-	}, nil
+	return opts, repository.OrderBy(sortKeys...), nil
 }
 
-func (s *apiService) FindContentByCollectionAlias(alias string, offset int, perPage int) ([]dto.ContentItemResponse, error) {
+func (s *apiService) listContent(collectionID uint, offset, perPage int, q dto.ApiQuery, extra ...base.QueryOption) ([]dto.ContentItemResponse, int64, error) {
 	var data []dto.ContentItemResponse
 
-	collection, err := s.repos.Collection.FindByAlias(alias)
+	opts, order, err := s.queryOptions(collectionID, q)
+	if err != nil {
+		return data, 0, err
+	}
+	opts = append(opts, extra...)
+
+	total, err := s.repos.Content.CountByCollectionID(collectionID, opts...)
 	if err != nil {
-		return data, err
+		return data, 0, err
+	}
+
+	findOpts := append([]base.QueryOption{}, opts...)
+	if q.After > 0 {
+		findOpts = append(findOpts, repository.AfterID(q.After))
 	}
+	findOpts = append(findOpts, order)
+	findOpts = append(findOpts, projectionOptions(q)...)
 
-	content, err := s.repos.Content.FindByCollectionID(collection.ID, offset, perPage)
+	content, err := s.repos.Content.FindByCollectionID(collectionID, offset, perPage, findOpts...)
 	if err != nil {
-		return data, err
+		return data, 0, err
 	}
 
+	resolver := newContentResolver(s.repos, q)
 	for _, ce := range content {
-		ci, err := s.PrepareContent(&ce)
-		if err != nil {
-			return data, nil
+		if q.Fields == nil {
+			resolver.remember(&ce)
 		}
+		data = append(data, resolver.prepare(&ce, "", 0, nil))
+	}
 
-		data = append(data, ci)
+	return data, total, nil
+}
+
+func filterOption(filter *dto.ContentFilter, fields []model.Field) (base.QueryOption, error) {
+	byAlias := make(map[string]model.Field, len(fields))
+	for _, f := range fields {
+		byAlias[f.Alias] = f
+	}
+
+	conditions := make([]repository.FieldCondition, 0, len(filter.Conditions))
+	for _, c := range filter.Conditions {
+		field, ok := byAlias[c.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, c.Field)
+		}
+
+		conditions = append(conditions, repository.FieldCondition{
+			Field:  field,
+			Op:     repository.FilterOp(c.Op),
+			Values: c.Values,
+		})
+	}
+
+	match, err := repository.MatchConditions(filter.Or, conditions...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
 	}
 
-	return data, nil
+	return match, nil
+}
+
+func (s *apiService) PrepareContent(ce *model.Content, q dto.ApiQuery) (dto.ContentItemResponse, error) {
+	return newContentResolver(s.repos, q).prepare(ce, "", 0, nil), nil
 }
 
-func (s *apiService) FindContentByID(id uint) (dto.ContentItemResponse, error) {
+func (s *apiService) FindContentByCollectionAlias(alias string, offset int, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error) {
+	collection, err := s.repos.Collection.FindByAlias(alias)
+	if err != nil {
+		return nil, 0, notFound(err, ErrCollectionNotFound)
+	}
+
+	return s.listContent(collection.ID, offset, perPage, q)
+}
+
+func (s *apiService) FindContentByID(id uint, q dto.ApiQuery) (dto.ContentItemResponse, error) {
 	var data dto.ContentItemResponse
 
-	content, err := s.repos.Content.FindByID(id)
+	content, err := s.repos.Content.FindByID(id, append(visibilityOptions(q), projectionOptions(q)...)...)
 	if err != nil {
-		return data, err
+		return data, notFound(err, ErrContentNotFound)
 	}
 
-	return s.PrepareContent(content)
+	return s.PrepareContent(content, q)
 }
 
-func (s *apiService) FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int) ([]dto.ContentItemResponse, error) {
-	collection, _ := s.repos.Collection.FindByAlias(alias)
-	contents, _, _ := s.repos.Content.FindByCollectionAndFieldValue(collection.ID, fieldAlias, value, offset, perPage)
-	var items []dto.ContentItemResponse
-	for _, ce := range contents {
-		ci, _ := s.PrepareContent(&ce)
-		items = append(items, ci)
+func (s *apiService) FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error) {
+	collection, err := s.repos.Collection.FindByAlias(alias)
+	if err != nil {
+		return nil, 0, notFound(err, ErrCollectionNotFound)
 	}
-	return items, nil
+
+	if !slices.ContainsFunc(collection.Fields, func(f model.Field) bool { return f.Alias == fieldAlias }) {
+		return nil, 0, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, fieldAlias)
+	}
+
+	return s.listContent(collection.ID, offset, perPage, q, repository.FieldValueEquals(fieldAlias, value))
+}
+
+func (s *apiService) CollectionLastModified(alias string) (time.Time, error) {
+	collection, err := s.repos.Collection.FindByAlias(alias)
+	if err != nil {
+		return time.Time{}, notFound(err, ErrCollectionNotFound)
+	}
+
+	return s.repos.Content.LastModifiedByCollectionID(collection.ID, time.Now())
 }
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"github.com/janmarkuslanger/nuricms/internal/utils"
+	"gorm.io/gorm"
+)
+
+type contentResolver struct {
+	repos    *repository.Set
+	q        dto.ApiQuery
+	now      time.Time
+	maxDepth int
+	contents map[uint]*model.Content
+	assets   map[uint]*model.Asset
+
+	components map[string]model.Collection
+}
+
+func newContentResolver(repos *repository.Set, q dto.ApiQuery) *contentResolver {
+	return &contentResolver{
+		repos:    repos,
+		q:        q,
+		now:      time.Now(),
+		maxDepth: includeDepth(q),
+		contents: make(map[uint]*model.Content),
+		assets:   make(map[uint]*model.Asset),
+	}
+}
+
+func includeDepth(q dto.ApiQuery) int {
+	depth := q.Depth
+	if len(q.Include) > 0 {
+		longest := 0
+		for _, path := range q.Include {
+			longest = max(longest, strings.Count(path, ".")+1)
+		}
+		if depth == 0 || longest < depth {
+			depth = longest
+		}
+	}
+
+	return min(depth, dto.MaxIncludeDepth)
+}
+
+func (r *contentResolver) remember(ce *model.Content) {
+	r.contents[ce.ID] = ce
+}
+
+func (r *contentResolver) content(id uint) *model.Content {
+	if ce, ok := r.contents[id]; ok {
+		return ce
+	}
+
+	ce, err := r.repos.Content.FindByID(id)
+	if err != nil {
+		ce = nil
+	}
+	r.contents[id] = ce
+
+	return ce
+}
+
+// load fetches the entries that are not cached yet in one query, so that many
+// references cost one round trip instead of one per entry.
+func (r *contentResolver) load(ids []uint) {
+	missing := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := r.contents[id]; !ok && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	contents, _, err := r.repos.Content.List(1, len(missing),
+		base.WithIDs(missing...),
+		base.Preload("ContentValues", func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Field")
+		}),
+		base.Preload("Collection"),
+	)
+	if err != nil {
+		return
+	}
+
+	for _, id := range missing {
+		r.contents[id] = nil
+	}
+	for i := range contents {
+		r.contents[contents[i].ID] = &contents[i]
+	}
+}
+
+// referencedIDs lists the entries the values of the locales point to.
+func referencedIDs(values []model.ContentValue, locales map[uint]string) []uint {
+	var ids []uint
+	for _, cv := range values {
+		if locale, ok := locales[cv.FieldID]; !ok || cv.Locale != locale || cv.Field.FieldType != model.FieldTypeCollection {
+			continue
+		}
+		if id, ok := utils.StringToUint(cv.Value); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *contentResolver) asset(id uint) *model.Asset {
+	if a, ok := r.assets[id]; ok {
+		return a
+	}
+
+	a, err := r.repos.Asset.FindByID(id)
+	if err != nil {
+		a = nil
+	}
+	r.assets[id] = a
+
+	return a
+}
+
+func (r *contentResolver) component(alias string) (model.Collection, bool) {
+	if r.components == nil {
+		components, _ := r.repos.Collection.FindComponents()
+		r.components = make(map[string]model.Collection, len(components))
+		for _, c := range components {
+			r.components[c.Alias] = c
+		}
+	}
+
+	c, ok := r.components[alias]
+	return c, ok
+}
+
+// block types the values of a stored block by the fields of its component.
+// Fields that accept many values become arrays, references stay IDs.
+func (r *contentResolver) block(value string) any {
+	b, err := model.ParseBlock(value)
+	if err != nil {
+		return nil
+	}
+
+	values := make(map[string]any, len(b.Values))
+	component, ok := r.component(b.Component)
+	if !ok {
+		for alias, v := range b.Values {
+			values[alias] = v
+		}
+		return dto.Block{Component: b.Component, Values: values}
+	}
+
+	for _, f := range component.Fields {
+		raw := b.Values[f.Alias]
+		if f.AcceptsMany() {
+			items := make([]any, 0, len(raw))
+			for _, v := range raw {
+				items = append(items, coerceValue(f.FieldType, v))
+			}
+			values[f.Alias] = items
+			continue
+		}
+
+		first := ""
+		if len(raw) > 0 {
+			first = raw[0]
+		}
+		values[f.Alias] = coerceValue(f.FieldType, first)
+	}
+
+	return dto.Block{Component: b.Component, Values: values}
+}
+
+func (r *contentResolver) shouldExpand(path string, depth int) bool {
+	if depth >= r.maxDepth {
+		return false
+	}
+
+	if len(r.q.Include) == 0 {
+		return true
+	}
+
+	for _, include := range r.q.Include {
+		if include == path || strings.HasPrefix(include, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *contentResolver) visible(ce *model.Content) bool {
+	return r.q.Preview || ce.IsVisibleAt(r.now)
+}
+
+// pickLocales returns per field the first locale of the fallback chain that
+// has values, fields that are not localized only use the default locale.
+func pickLocales(values []model.ContentValue, chain []string) map[uint]string {
+	if len(chain) == 0 {
+		chain = []string{""}
+	}
+
+	picked := make(map[uint]string)
+	for _, cv := range values {
+		rank := slices.Index(chain, cv.Locale)
+		if rank < 0 || (cv.Locale != "" && !cv.Field.Localized) {
+			continue
+		}
+
+		if current, ok := picked[cv.FieldID]; !ok || rank < slices.Index(chain, current) {
+			picked[cv.FieldID] = cv.Locale
+		}
+	}
+
+	return picked
+}
+
+func (r *contentResolver) prepare(ce *model.Content, path string, depth int, ancestors []uint) dto.ContentItemResponse {
+	ancestors = append(slices.Clip(ancestors), ce.ID)
+	values := make(map[string]any, len(ce.ContentValues))
+	locales := pickLocales(ce.ContentValues, r.q.Locales)
+
+	lastModified := ce.UpdatedAt
+	touch := func(t time.Time) {
+		if t.After(lastModified) && !t.After(r.now) {
+			lastModified = t
+		}
+	}
+	if ce.PublishAt != nil {
+		touch(*ce.PublishAt)
+	}
+
+	for _, cv := range ce.ContentValues {
+		if locale, ok := locales[cv.FieldID]; !ok || cv.Locale != locale {
+			continue
+		}
+
+		alias := cv.Field.Alias
+		touch(cv.UpdatedAt)
+
+		cvr := dto.ContentValueResponse{
+			ID:        cv.ID,
+			Value:     cv.Value,
+			FieldType: cv.Field.FieldType,
+		}
+
+		if cv.Field.FieldType == model.FieldTypeCollection {
+			id, _ := utils.StringToUint(cv.Value)
+			if con := r.content(id); con != nil && cv.Field.AllowsTarget(con.CollectionID) {
+				cvr.Collection = &dto.CollectionResponse{
+					ID:    con.CollectionID,
+					Name:  con.Collection.Name,
+					Alias: con.Collection.Alias,
+				}
+
+				fieldPath := alias
+				if path != "" {
+					fieldPath = path + "." + alias
+				}
+
+				if r.shouldExpand(fieldPath, depth) && !slices.Contains(ancestors, con.ID) && r.visible(con) {
+					item := r.prepare(con, fieldPath, depth+1, ancestors)
+					touch(item.LastModified)
+					cvr.Content = &item
+				}
+			}
+		}
+
+		if cv.Field.FieldType == model.FieldTypeAsset {
+			id, _ := utils.StringToUint(cv.Value)
+			if ass := r.asset(id); ass != nil {
+				touch(ass.UpdatedAt)
+				cvr.Asset = &dto.AssetResponse{
+					ID:   ass.ID,
+					Name: ass.Name,
+					Path: ass.Path,
+				}
+			}
+		}
+
+		if r.q.TypedValues() {
+			cvr.Value = coerceValue(cv.Field.FieldType, cv.Value)
+		}
+
+		if r.q.TypedValues() && cv.Field.FieldType == model.FieldTypeBlocks {
+			cvr.Value = r.block(cv.Value)
+		}
+
+		if r.q.TypedValues() && cv.Field.FieldType == model.FieldTypeMultiSelect && !cv.Field.IsList {
+			var selected []any
+			if prev, ok := values[alias].(dto.ContentValueResponse); ok {
+				selected, _ = prev.Value.([]any)
+			}
+			cvr.Value = append(selected, cvr.Value)
+			values[alias] = cvr
+			continue
+		}
+
+		if cv.Field.IsList {
+			items, ok := values[alias].([]any)
+			if !ok {
+				items = []any{}
+			}
+			items = append(items, cvr)
+			values[alias] = items
+
+		} else {
+			values[alias] = cvr
+		}
+	}
+
+	return dto.ContentItemResponse{
+		ID:          ce.ID,
+		CreatedAt:   ce.CreatedAt,
+		UpdatedAt:   ce.UpdatedAt,
+		Status:      string(ce.Status),
+		PublishAt:   ce.PublishAt,
+		UnpublishAt: ce.UnpublishAt,
+		Values:      values,
+		Collection: dto.CollectionResponse{
+			Alias: ce.Collection.Alias,
+			ID:    ce.CollectionID,
+			Name:  ce.Collection.Name,
+		},
+		LastModified: lastModified,
+	}
+}
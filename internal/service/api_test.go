@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/repository"
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/janmarkuslanger/nuricms/testutils/mockrepo"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -55,7 +57,7 @@ func TestApiService_prepareContent_SimpleAndListCollectionAsset(t *testing.T) {
 
 	ce.ContentValues = []model.ContentValue{cv1, cv2, cv3, cv4, cv5}
 
-	resp, err := s.PrepareContent(ce)
+	resp, err := s.PrepareContent(ce, dto.ApiQuery{})
 	assert.NoError(t, err)
 	assert.Equal(t, ce.ID, resp.ID)
 	assert.Equal(t, now.Unix(), resp.CreatedAt.Unix())
@@ -89,13 +91,13 @@ func TestApiService_FindContentByCollectionAlias_FindByID(t *testing.T) {
 	repos.Content.Create(c)
 	repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: f.ID, Value: "val"})
 
-	list, err := s.FindContentByCollectionAlias("colx", 0, 10)
+	list, _, err := s.FindContentByCollectionAlias("colx", 0, 10, dto.ApiQuery{})
 	assert.NoError(t, err)
 	assert.Len(t, list, 1)
 	item := list[0]
 	assert.Equal(t, c.ID, item.ID)
 
-	single, err := s.FindContentByID(c.ID)
+	single, err := s.FindContentByID(c.ID, dto.ApiQuery{})
 	assert.NoError(t, err)
 	assert.Equal(t, c.ID, single.ID)
 }
@@ -114,7 +116,7 @@ func TestApiService_FindContentByCollectionAndFieldValue(t *testing.T) {
 		repos.Content.Create(c)
 		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: f.ID, Value: "match"})
 	}
-	items, err := s.FindContentByCollectionAndFieldValue("coly", "f2", "match", 0, 10)
+	items, _, err := s.FindContentByCollectionAndFieldValue("coly", "f2", "match", 0, 10, dto.ApiQuery{})
 	assert.NoError(t, err)
 	assert.Len(t, items, 3)
 }
@@ -124,6 +126,586 @@ func TestApiService_FindContentByCollectionAlias_Error(t *testing.T) {
 	repos := repository.NewSet(testDB)
 	s := service.NewApiService(repos)
 
-	_, err := s.FindContentByCollectionAlias("nonexistent", 0, 10)
+	_, _, err := s.FindContentByCollectionAlias("nonexistent", 0, 10, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+}
+
+func TestApiService_NotFoundErrors(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	_, err := s.FindContentByID(9999, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrContentNotFound)
+
+	_, _, err = s.FindContentByCollectionAndFieldValue("nonexistent", "name", "Ada", 0, 10, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+
+	_, _, err = s.FindContentByCollectionAndFieldValue("people", "nope", "Ada", 0, 10, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrInvalidFilter)
+
+	list, _, err := s.FindContentByCollectionAndFieldValue("people", "name", "Ada", 0, 10, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func TestApiService_HidesUnpublishedContent(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	repos := repository.NewSet(testDB)
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "ColZ", Alias: "colz"}
+	repos.Collection.Create(col)
+	published := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+	repos.Content.Create(published)
+	draft := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft}
+	repos.Content.Create(draft)
+	archived := &model.Content{CollectionID: col.ID, Status: model.ContentStatusArchived}
+	repos.Content.Create(archived)
+
+	list, _, err := s.FindContentByCollectionAlias("colz", 0, 10, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, published.ID, list[0].ID)
+	assert.Equal(t, string(model.ContentStatusPublished), list[0].Status)
+
+	_, err = s.FindContentByID(draft.ID, dto.ApiQuery{})
+	assert.Error(t, err)
+
+	preview, _, err := s.FindContentByCollectionAlias("colz", 0, 10, dto.ApiQuery{Preview: true})
+	assert.NoError(t, err)
+	assert.Len(t, preview, 3)
+
+	single, err := s.FindContentByID(draft.ID, dto.ApiQuery{Preview: true})
+	assert.NoError(t, err)
+	assert.Equal(t, string(model.ContentStatusDraft), single.Status)
+}
+
+func TestApiService_HonorsScheduleWindows(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	repos := repository.NewSet(testDB)
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "ColS", Alias: "cols"}
+	repos.Collection.Create(col)
+
+	past := time.Now().UTC().Add(-time.Minute)
+	future := time.Now().UTC().Add(time.Hour)
+
+	due := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft, PublishAt: &past}
+	repos.Content.Create(due)
+	scheduled := &model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft, PublishAt: &future}
+	repos.Content.Create(scheduled)
+	expired := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished, UnpublishAt: &past}
+	repos.Content.Create(expired)
+
+	list, _, err := s.FindContentByCollectionAlias("cols", 0, 10, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, due.ID, list[0].ID)
+	assert.NotNil(t, list[0].PublishAt)
+
+	_, err = s.FindContentByID(expired.ID, dto.ApiQuery{})
 	assert.Error(t, err)
 }
+
+func TestApiService_PrepareContent_TypedValues(t *testing.T) {
+	s := service.NewApiService(repository.NewSet(testutils.SetupTestDB(t)))
+
+	fNumber := model.Field{Alias: "count", FieldType: model.FieldTypeNumber}
+	fPrice := model.Field{Alias: "price", FieldType: model.FieldTypeNumber}
+	fBool := model.Field{Alias: "active", FieldType: model.FieldTypeBoolean}
+	fDate := model.Field{Alias: "day", FieldType: model.FieldTypeDate}
+	fSelect := model.Field{Alias: "tags", FieldType: model.FieldTypeMultiSelect}
+	fText := model.Field{Alias: "title", FieldType: model.FieldTypeText}
+
+	ce := &model.Content{ContentValues: []model.ContentValue{
+		{Field: fNumber, Value: "42"},
+		{Field: fPrice, Value: "9.5"},
+		{Field: fBool, Value: "on"},
+		{Field: fDate, Value: "2024-03-01"},
+		{Field: fSelect, Value: "a"},
+		{Field: fSelect, Value: "b"},
+		{Field: fText, Value: "42"},
+	}}
+
+	legacy, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionLegacy})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", legacy.Values["count"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, "on", legacy.Values["active"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, "b", legacy.Values["tags"].(dto.ContentValueResponse).Value)
+
+	typed, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionTyped})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), typed.Values["count"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, 9.5, typed.Values["price"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, true, typed.Values["active"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, "2024-03-01T00:00:00Z", typed.Values["day"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, []any{"a", "b"}, typed.Values["tags"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, "42", typed.Values["title"].(dto.ContentValueResponse).Value)
+}
+
+func TestApiService_PrepareContent_TypedInvalidValuesAreNull(t *testing.T) {
+	s := service.NewApiService(repository.NewSet(testutils.SetupTestDB(t)))
+
+	ce := &model.Content{ContentValues: []model.ContentValue{
+		{Field: model.Field{Alias: "count", FieldType: model.FieldTypeNumber}, Value: "abc"},
+		{Field: model.Field{Alias: "ratio", FieldType: model.FieldTypeNumber}, Value: "NaN"},
+		{Field: model.Field{Alias: "limit", FieldType: model.FieldTypeNumber}, Value: "-Infinity"},
+		{Field: model.Field{Alias: "day", FieldType: model.FieldTypeDate}, Value: "soon"},
+		{Field: model.Field{Alias: "active", FieldType: model.FieldTypeBoolean}, Value: ""},
+	}}
+
+	typed, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionTyped})
+	assert.NoError(t, err)
+	assert.Nil(t, typed.Values["count"].(dto.ContentValueResponse).Value)
+	assert.Nil(t, typed.Values["ratio"].(dto.ContentValueResponse).Value, "legacy NaN rows are null")
+	assert.Nil(t, typed.Values["limit"].(dto.ContentValueResponse).Value, "legacy Inf rows are null")
+
+	_, err = json.Marshal(typed)
+	assert.NoError(t, err)
+	assert.Nil(t, typed.Values["day"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, false, typed.Values["active"].(dto.ContentValueResponse).Value)
+}
+
+// seedRelations seeds a person working at a company located in a city, the
+// company references the person back as its CEO.
+func seedRelations(t *testing.T, db *gorm.DB) (person, company, city *model.Content) {
+	people := testutils.SeedCollection(t, db, &model.Collection{Name: "People", Alias: "people"},
+		model.Field{Alias: "name", FieldType: model.FieldTypeText},
+		model.Field{Alias: "company", FieldType: model.FieldTypeCollection},
+	)
+	companies := testutils.SeedCollection(t, db, &model.Collection{Name: "Companies", Alias: "companies"},
+		model.Field{Alias: "ceo", FieldType: model.FieldTypeCollection},
+		model.Field{Alias: "city", FieldType: model.FieldTypeCollection},
+	)
+	cities := testutils.SeedCollection(t, db, &model.Collection{Name: "Cities", Alias: "cities"},
+		model.Field{Alias: "name", FieldType: model.FieldTypeText},
+	)
+
+	city = testutils.SeedContent(t, db, cities, model.ContentStatusPublished, map[string][]string{"name": {"Hamburg"}})
+	company = testutils.SeedContent(t, db, companies, model.ContentStatusPublished, map[string][]string{"city": {fmt.Sprint(city.ID)}})
+	person = testutils.SeedContent(t, db, people, model.ContentStatusPublished, map[string][]string{"name": {"Ada"}, "company": {fmt.Sprint(company.ID)}})
+	testutils.SeedValues(t, db, companies, company, map[string][]string{"ceo": {fmt.Sprint(person.ID)}})
+
+	return person, company, city
+}
+
+func embedded(t *testing.T, item dto.ContentItemResponse, alias string) *dto.ContentItemResponse {
+	t.Helper()
+	v, ok := item.Values[alias].(dto.ContentValueResponse)
+	if !assert.True(t, ok, "missing value %s", alias) {
+		return nil
+	}
+	return v.Content
+}
+
+func TestApiService_Depth_EmbedsRelationsRecursively(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, company, city := seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	flat, err := s.FindContentByID(person.ID, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Nil(t, embedded(t, flat, "company"))
+
+	deep, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 2})
+	assert.NoError(t, err)
+	employer := embedded(t, deep, "company")
+	if assert.NotNil(t, employer) {
+		assert.Equal(t, company.ID, employer.ID)
+		location := embedded(t, *employer, "city")
+		if assert.NotNil(t, location) {
+			assert.Equal(t, city.ID, location.ID)
+			assert.Equal(t, "Hamburg", location.Values["name"].(dto.ContentValueResponse).Value)
+		}
+	}
+
+	shallow, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 1})
+	assert.NoError(t, err)
+	employer = embedded(t, shallow, "company")
+	if assert.NotNil(t, employer) {
+		assert.Nil(t, embedded(t, *employer, "city"))
+	}
+}
+
+func TestApiService_Depth_StopsAtCycles(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, _, _ := seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	item, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: dto.MaxIncludeDepth})
+	assert.NoError(t, err)
+
+	company := embedded(t, item, "company")
+	if assert.NotNil(t, company) {
+		ceo := company.Values["ceo"].(dto.ContentValueResponse)
+		assert.Equal(t, fmt.Sprint(person.ID), ceo.Value)
+		assert.NotNil(t, ceo.Collection)
+		assert.Nil(t, ceo.Content)
+	}
+}
+
+func TestApiService_Include_ExpandsOnlyRequestedPaths(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, company, _ := seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	item, err := s.FindContentByID(company.ID, dto.ApiQuery{Include: []string{"city"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, embedded(t, item, "city"))
+	assert.Nil(t, embedded(t, item, "ceo"))
+
+	item, err = s.FindContentByID(person.ID, dto.ApiQuery{Include: []string{"company.city"}})
+	assert.NoError(t, err)
+	employer := embedded(t, item, "company")
+	if assert.NotNil(t, employer) {
+		assert.NotNil(t, embedded(t, *employer, "city"))
+		assert.Nil(t, embedded(t, *employer, "ceo"))
+	}
+
+	item, err = s.FindContentByID(person.ID, dto.ApiQuery{Include: []string{"company.city"}, Depth: 1})
+	assert.NoError(t, err)
+	employer = embedded(t, item, "company")
+	if assert.NotNil(t, employer) {
+		assert.Nil(t, embedded(t, *employer, "city"))
+	}
+}
+
+func TestApiService_Depth_HidesInvisibleRelations(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, company, _ := seedRelations(t, db)
+	repos.Content.UpdateStatus(company.ID, model.ContentStatusDraft)
+	s := service.NewApiService(repos)
+
+	item, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 1})
+	assert.NoError(t, err)
+	assert.Nil(t, embedded(t, item, "company"))
+
+	preview, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 1, Preview: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, embedded(t, preview, "company"))
+}
+
+func TestApiService_PrepareContent_CachesLookups(t *testing.T) {
+	contentRepo := new(mockrepo.MockContentRepo)
+	assetRepo := new(mockrepo.MockAssetRepo)
+	s := service.NewApiService(&repository.Set{Content: contentRepo, Asset: assetRepo})
+
+	fRef := model.Field{Alias: "refs", FieldType: model.FieldTypeCollection, IsList: true}
+	fImage := model.Field{Alias: "images", FieldType: model.FieldTypeAsset, IsList: true}
+
+	related := &model.Content{
+		Model:  gorm.Model{ID: 2},
+		Status: model.ContentStatusPublished,
+	}
+	contentRepo.On("FindByID", uint(2)).Return(related, nil).Once()
+	assetRepo.On("FindByID", uint(9)).Return(&model.Asset{Model: gorm.Model{ID: 9}}, nil).Once()
+
+	ce := &model.Content{Model: gorm.Model{ID: 1}, ContentValues: []model.ContentValue{
+		{Field: fRef, Value: "2"},
+		{Field: fRef, Value: "2"},
+		{Field: fImage, Value: "9"},
+		{Field: fImage, Value: "9"},
+	}}
+
+	item, err := s.PrepareContent(ce, dto.ApiQuery{Depth: 1})
+	assert.NoError(t, err)
+	refs := item.Values["refs"].([]any)
+	assert.Len(t, refs, 2)
+	for _, ref := range refs {
+		assert.Equal(t, uint(2), ref.(dto.ContentValueResponse).Content.ID)
+	}
+
+	contentRepo.AssertExpectations(t)
+	assetRepo.AssertExpectations(t)
+}
+
+func TestApiService_FindContentByCollectionAlias_Filter(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "Products", Alias: "products"}
+	repos.Collection.Create(col)
+	fTitle := &model.Field{Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	repos.Field.Create(fTitle)
+	fPrice := &model.Field{Alias: "price", FieldType: model.FieldTypeNumber, CollectionID: col.ID}
+	repos.Field.Create(fPrice)
+
+	var ids []uint
+	for _, row := range [][2]string{{"Go book", "9"}, {"Go course", "120"}, {"Rust book", "30"}} {
+		c := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fTitle.ID, Value: row[0]})
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fPrice.ID, Value: row[1]})
+		ids = append(ids, c.ID)
+	}
+
+	q := dto.ApiQuery{Filter: &dto.ContentFilter{Conditions: []dto.FilterCondition{
+		{Field: "title", Op: "contains", Values: []string{"go"}},
+		{Field: "price", Op: "gte", Values: []string{"10"}},
+	}}}
+	list, _, err := s.FindContentByCollectionAlias("products", 0, 10, q)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, ids[1], list[0].ID)
+	}
+
+	q.Filter.Or = true
+	list, _, err = s.FindContentByCollectionAlias("products", 0, 10, q)
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+
+	list, _, err = s.FindContentByCollectionAndFieldValue("products", "title", "Rust book", 0, 10, dto.ApiQuery{
+		Filter: &dto.ContentFilter{Conditions: []dto.FilterCondition{{Field: "price", Op: "lt", Values: []string{"20"}}}},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestApiService_FindContentByCollectionAlias_InvalidFilter(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "Products", Alias: "products"}
+	repos.Collection.Create(col)
+	repos.Field.Create(&model.Field{Alias: "price", FieldType: model.FieldTypeNumber, CollectionID: col.ID})
+
+	for _, cond := range []dto.FilterCondition{
+		{Field: "missing", Op: "eq", Values: []string{"x"}},
+		{Field: "price", Op: "gte", Values: []string{"cheap"}},
+		{Field: "price", Op: "between", Values: []string{"1"}},
+	} {
+		_, _, err := s.FindContentByCollectionAlias("products", 0, 10, dto.ApiQuery{
+			Filter: &dto.ContentFilter{Conditions: []dto.FilterCondition{cond}},
+		})
+		assert.ErrorIs(t, err, service.ErrInvalidFilter)
+	}
+}
+
+func TestApiService_FindContentByCollectionAlias_Sort(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "Products", Alias: "products"}
+	repos.Collection.Create(col)
+	fPrice := &model.Field{Alias: "price", FieldType: model.FieldTypeNumber, CollectionID: col.ID}
+	repos.Field.Create(fPrice)
+
+	var ids []uint
+	for _, price := range []string{"100", "9", "30"} {
+		c := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fPrice.ID, Value: price})
+		ids = append(ids, c.ID)
+	}
+
+	list, _, err := s.FindContentByCollectionAlias("products", 0, 10, dto.ApiQuery{Sort: "-price"})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 3) {
+		assert.Equal(t, []uint{ids[0], ids[2], ids[1]}, []uint{list[0].ID, list[1].ID, list[2].ID})
+	}
+
+	list, _, err = s.FindContentByCollectionAlias("products", 0, 10, dto.ApiQuery{Sort: "-id"})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 3) {
+		assert.Equal(t, ids[2], list[0].ID)
+	}
+
+	_, _, err = s.FindContentByCollectionAlias("products", 0, 10, dto.ApiQuery{Sort: "weight"})
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+
+	_, _, err = s.FindContentByCollectionAlias("products", 0, 10, dto.ApiQuery{Sort: "price,,id"})
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+}
+
+func TestApiService_FindContentByCollectionAlias_TotalAndCursor(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "Posts", Alias: "posts"}
+	repos.Collection.Create(col)
+	fTitle := &model.Field{Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	repos.Field.Create(fTitle)
+
+	var ids []uint
+	for i := 0; i < 5; i++ {
+		c := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fTitle.ID, Value: fmt.Sprintf("post %d", i%2)})
+		ids = append(ids, c.ID)
+	}
+	repos.Content.Create(&model.Content{CollectionID: col.ID, Status: model.ContentStatusDraft})
+
+	list, total, err := s.FindContentByCollectionAlias("posts", 2, 2, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, []uint{ids[2], ids[3]}, []uint{list[0].ID, list[1].ID})
+
+	list, total, err = s.FindContentByCollectionAlias("posts", 0, 2, dto.ApiQuery{After: ids[3]})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, ids[4], list[0].ID)
+	}
+
+	list, total, err = s.FindContentByCollectionAndFieldValue("posts", "title", "post 0", 0, 2, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, list, 2)
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	id, err := service.DecodeCursor(service.EncodeCursor(42))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), id)
+
+	for _, cursor := range []string{"", "nope", "YWZ0ZXI6MA", "Zm9vOjE"} {
+		_, err := service.DecodeCursor(cursor)
+		assert.ErrorIs(t, err, service.ErrInvalidCursor, cursor)
+	}
+}
+
+func TestApiService_Fields_LimitsValues(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, _, _ := seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	item, err := s.FindContentByID(person.ID, dto.ApiQuery{Fields: []string{"name"}})
+	assert.NoError(t, err)
+	assert.Len(t, item.Values, 1)
+	assert.Contains(t, item.Values, "name")
+
+	list, _, err := s.FindContentByCollectionAlias("companies", 0, 10, dto.ApiQuery{Fields: []string{"city"}, Depth: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Len(t, list[0].Values, 1)
+		city := embedded(t, list[0], "city")
+		if assert.NotNil(t, city) {
+			assert.Contains(t, city.Values, "name")
+		}
+	}
+
+	item, err = s.FindContentByID(person.ID, dto.ApiQuery{Fields: []string{"unknown"}})
+	assert.NoError(t, err)
+	assert.Empty(t, item.Values)
+}
+
+func TestApiService_LastModifiedIncludesValuesAndRelations(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, _, city := seedRelations(t, db)
+	s := service.NewApiService(repos)
+
+	before, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 2})
+	assert.NoError(t, err)
+	assert.False(t, before.LastModified.Before(before.UpdatedAt))
+
+	time.Sleep(5 * time.Millisecond)
+	cityFields, _ := repos.Field.FindByCollectionID(city.CollectionID)
+	repos.ContentValue.Create(&model.ContentValue{ContentID: city.ID, FieldID: cityFields[0].ID, Value: "Berlin"})
+
+	after, err := s.FindContentByID(person.ID, dto.ApiQuery{Depth: 2})
+	assert.NoError(t, err)
+	assert.True(t, after.LastModified.After(before.LastModified))
+
+	stamp, err := s.CollectionLastModified("cities")
+	assert.NoError(t, err)
+	assert.False(t, stamp.Before(after.LastModified))
+
+	_, err = s.CollectionLastModified("nonexistent")
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+}
+
+func TestApiService_PrepareContent_StructuredValues(t *testing.T) {
+	s := service.NewApiService(repository.NewSet(testutils.SetupTestDB(t)))
+
+	fJSON := model.Field{Alias: "meta", FieldType: model.FieldTypeJSON}
+	fGeo := model.Field{Alias: "location", FieldType: model.FieldTypeGeoPoint}
+	fURL := model.Field{Alias: "website", FieldType: model.FieldTypeURL}
+
+	ce := &model.Content{ContentValues: []model.ContentValue{
+		{Field: fJSON, Value: `{"tags":["a"],"n":1}`},
+		{Field: fGeo, Value: "52.52,13.405"},
+		{Field: fURL, Value: "https://example.com"},
+	}}
+
+	legacy, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionLegacy})
+	assert.NoError(t, err)
+	assert.Equal(t, "52.52,13.405", legacy.Values["location"].(dto.ContentValueResponse).Value)
+
+	typed, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionTyped})
+	assert.NoError(t, err)
+	assert.Equal(t, dto.GeoPoint{Lat: 52.52, Lng: 13.405}, typed.Values["location"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, "https://example.com", typed.Values["website"].(dto.ContentValueResponse).Value)
+
+	body, err := json.Marshal(typed.Values["meta"])
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"value":{"tags":["a"],"n":1}`)
+}
+
+func TestApiService_PrepareContent_Blocks(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewApiService(repos)
+
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	assert.NoError(t, repos.Collection.Create(hero))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: hero.ID}))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Count", Alias: "count", FieldType: model.FieldTypeNumber, CollectionID: hero.ID}))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Tags", Alias: "tags", FieldType: model.FieldTypeText, CollectionID: hero.ID, IsList: true}))
+
+	fBlocks := model.Field{Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true}
+	stored := `{"component":"hero","values":{"count":["3"],"tags":["a","b"],"title":["Hi"]}}`
+	ce := &model.Content{ContentValues: []model.ContentValue{{Field: fBlocks, Value: stored}}}
+
+	legacy, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionLegacy})
+	assert.NoError(t, err)
+	assert.Equal(t, stored, legacy.Values["body"].([]any)[0].(dto.ContentValueResponse).Value)
+
+	typed, err := s.PrepareContent(ce, dto.ApiQuery{Version: dto.ApiVersionTyped})
+	assert.NoError(t, err)
+	assert.Equal(t, dto.Block{Component: "hero", Values: map[string]any{
+		"title": "Hi",
+		"count": int64(3),
+		"tags":  []any{"a", "b"},
+	}}, typed.Values["body"].([]any)[0].(dto.ContentValueResponse).Value)
+}
+
+func TestApiService_PrepareContent_TargetCollections(t *testing.T) {
+	contentRepo := new(mockrepo.MockContentRepo)
+	s := service.NewApiService(&repository.Set{Content: contentRepo})
+
+	fAuthor := model.Field{Alias: "author", FieldType: model.FieldTypeCollection, TargetCollections: "5"}
+	fEditor := model.Field{Alias: "editor", FieldType: model.FieldTypeCollection, TargetCollections: "6"}
+
+	author := &model.Content{
+		Model:        gorm.Model{ID: 2},
+		CollectionID: 5,
+		Status:       model.ContentStatusPublished,
+	}
+	contentRepo.On("FindByID", uint(2)).Return(author, nil)
+
+	ce := &model.Content{Model: gorm.Model{ID: 1}, ContentValues: []model.ContentValue{
+		{Field: fAuthor, Value: "2"},
+		{Field: fEditor, Value: "2"},
+	}}
+
+	item, err := s.PrepareContent(ce, dto.ApiQuery{Depth: 1})
+	assert.NoError(t, err)
+
+	allowed := item.Values["author"].(dto.ContentValueResponse)
+	assert.NotNil(t, allowed.Collection)
+	assert.NotNil(t, allowed.Content)
+
+	outside := item.Values["editor"].(dto.ContentValueResponse)
+	assert.Nil(t, outside.Collection, "entries outside the target collections are not resolved")
+	assert.Nil(t, outside.Content)
+	assert.Equal(t, "2", outside.Value)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+)
+
+func coerceValue(fieldType model.FieldType, value string) any {
+	switch fieldType {
+	case model.FieldTypeNumber:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil && model.IsFinite(f) {
+			return f
+		}
+		return nil
+
+	case model.FieldTypeBoolean:
+		switch value {
+		case "on", "true", "1":
+			return true
+		default:
+			return false
+		}
+
+	case model.FieldTypeDate:
+		d, err := model.ParseDate(value)
+		if err != nil {
+			return nil
+		}
+		return d.Format(time.RFC3339)
+
+	case model.FieldTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return nil
+		}
+		return json.RawMessage(value)
+
+	case model.FieldTypeGeoPoint:
+		lat, lng, err := model.ParseGeoPoint(value)
+		if err != nil {
+			return nil
+		}
+		return dto.GeoPoint{Lat: lat, Lng: lng}
+
+	default:
+		return value
+	}
+}
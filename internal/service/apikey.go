@@ -14,9 +14,11 @@ import (
 type ApikeyService interface {
 	List(page, pageSize int) ([]model.Apikey, int64, error)
 	Create(dto dto.ApikeyData) (*model.Apikey, error)
+	UpdateByID(id uint, dto dto.ApikeyData) (*model.Apikey, error)
 	FindByID(id uint) (*model.Apikey, error)
 	DeleteByID(id uint) error
 	Validate(token string) error
+	FindByToken(token string) (*model.Apikey, error)
 }
 
 type apikeyService struct {
@@ -41,8 +43,10 @@ func (s apikeyService) Create(dto dto.ApikeyData) (apiKey *model.Apikey, err err
 	token := hex.EncodeToString(b)
 
 	apiKey = &model.Apikey{
-		Name:  dto.Name,
-		Token: token,
+		Name:       dto.Name,
+		Token:      token,
+		CanPreview: dto.CanPreview == "on",
+		CanWrite:   dto.CanWrite == "on",
 	}
 
 	if err := s.repos.Apikey.Create(apiKey); err != nil {
@@ -51,6 +55,25 @@ func (s apikeyService) Create(dto dto.ApikeyData) (apiKey *model.Apikey, err err
 	return apiKey, nil
 }
 
+// UpdateByID changes the permissions of a key, the token stays the same.
+func (s apikeyService) UpdateByID(id uint, dto dto.ApikeyData) (*model.Apikey, error) {
+	apiKey, err := s.repos.Apikey.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != "" {
+		apiKey.Name = dto.Name
+	}
+	apiKey.CanPreview = dto.CanPreview == "on"
+	apiKey.CanWrite = dto.CanWrite == "on"
+
+	if err := s.repos.Apikey.Save(apiKey); err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
 func (s apikeyService) Validate(token string) error {
 	apikey, err := s.repos.Apikey.FindByToken(token)
 	if err != nil {
@@ -62,6 +85,10 @@ func (s apikeyService) Validate(token string) error {
 	return nil
 }
 
+func (s apikeyService) FindByToken(token string) (*model.Apikey, error) {
+	return s.repos.Apikey.FindByToken(token)
+}
+
 func (s apikeyService) List(page, pageSize int) ([]model.Apikey, int64, error) {
 	return s.repos.Apikey.List(page, pageSize)
 }
@@ -127,6 +127,22 @@ func TestFindByID(t *testing.T) {
 	assert.Equal(t, key, found)
 }
 
+func TestUpdateByID(t *testing.T) {
+	repo := new(testutils.MockApikeyRepo)
+	svc := newTestService(repo)
+
+	key := &model.Apikey{Model: gorm.Model{ID: 10}, Name: "Test", CanPreview: true}
+	repo.On("FindByID", uint(10)).Return(key, nil)
+	repo.On("Save", mock.MatchedBy(func(a *model.Apikey) bool {
+		return a.Name == "Test" && !a.CanPreview && a.CanWrite
+	})).Return(nil)
+
+	updated, err := svc.UpdateByID(10, dto.ApikeyData{CanWrite: "on"})
+	assert.NoError(t, err)
+	assert.True(t, updated.CanWrite)
+	repo.AssertExpectations(t)
+}
+
 func TestDeleteByID_Success(t *testing.T) {
 	repo := new(testutils.MockApikeyRepo)
 	svc := newTestService(repo)
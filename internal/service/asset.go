@@ -7,6 +7,7 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/fs"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"github.com/janmarkuslanger/nuricms/internal/server"
 )
 
@@ -17,6 +18,8 @@ type AssetService interface {
 	Create(asset *model.Asset) error
 	UploadFile(ctx server.Context, header fs.FileOpener, filename string) (string, error)
 	FindByID(id uint) (*model.Asset, error)
+	FindByIDs(ids []uint) ([]model.Asset, error)
+	Search(query string, page, pageSize int) ([]model.Asset, int64, error)
 }
 
 type assetService struct {
@@ -69,6 +72,20 @@ func (s *assetService) FindByID(id uint) (*model.Asset, error) {
 	return s.repos.Asset.FindByID(id)
 }
 
+func (s *assetService) FindByIDs(ids []uint) ([]model.Asset, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	assets, _, err := s.repos.Asset.List(1, len(ids), base.WithIDs(ids...))
+	return assets, err
+}
+
+// Search lists the assets whose name contains the query, by name.
+func (s *assetService) Search(query string, page, pageSize int) ([]model.Asset, int64, error) {
+	return s.repos.Asset.List(page, pageSize, repository.NameMatching(query), base.Order("name, id"))
+}
+
 func (s *assetService) DeleteByID(id uint) error {
 	asset, err := s.repos.Asset.FindByID(id)
 
@@ -128,6 +128,51 @@ func createMultipartFileHeader(t *testing.T, filename string, content []byte) (*
 	return files[0], files[0].Filename
 }
 
+func TestAssetService_Search(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	svc := service.NewAssetService(repos, &mockservices.MockFileOps{})
+	for _, name := range []string{"Team photo", "Logo", "team_100%", "Office"} {
+		assert.NoError(t, svc.Create(&model.Asset{Name: name, Path: "p"}))
+	}
+
+	assets, total, err := svc.Search("TEAM", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.Len(t, assets, 1) {
+		assert.Equal(t, "Team photo", assets[0].Name)
+	}
+
+	assets, _, err = svc.Search("100%", 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1, "wildcards are matched literally")
+
+	assets, total, err = svc.Search("", 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, "Logo", assets[0].Name)
+}
+
+func TestAssetService_FindByIDs(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	svc := service.NewAssetService(repos, &mockservices.MockFileOps{})
+	a := &model.Asset{Name: "A", Path: "a"}
+	b := &model.Asset{Name: "B", Path: "b"}
+	svc.Create(a)
+	svc.Create(b)
+
+	assets, err := svc.FindByIDs([]uint{b.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, assets, 1) {
+		assert.Equal(t, "B", assets[0].Name)
+	}
+
+	assets, err = svc.FindByIDs(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, assets)
+}
+
 func Test_UploadFile_Success(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "uploaded")
 	assert.NoError(t, err)
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
@@ -14,6 +15,7 @@ type CollectionService interface {
 	Create(data dto.CollectionData) (*model.Collection, error)
 	FindByAlias(alias string) (*model.Collection, error)
 	FindByID(id uint) (*model.Collection, error)
+	FindComponents() ([]model.Collection, error)
 	List(page, pageSize int) ([]model.Collection, int64, error)
 	Save(col *model.Collection) error
 }
@@ -34,12 +36,20 @@ func (s *collectionService) FindByID(id uint) (*model.Collection, error) {
 	return s.repos.Collection.FindByID(id)
 }
 
+func (s *collectionService) FindComponents() ([]model.Collection, error) {
+	return s.repos.Collection.FindComponents()
+}
+
 func (s *collectionService) Save(col *model.Collection) error {
 	return s.repos.Collection.Save(col)
 }
 
 func (s *collectionService) FindByAlias(alias string) (*model.Collection, error) {
-	return s.repos.Collection.FindByAlias(alias)
+	collection, err := s.repos.Collection.FindByAlias(alias)
+	if err != nil {
+		return nil, notFound(err, ErrCollectionNotFound)
+	}
+	return collection, nil
 }
 
 func (s *collectionService) Create(data dto.CollectionData) (*model.Collection, error) {
@@ -47,6 +57,7 @@ func (s *collectionService) Create(data dto.CollectionData) (*model.Collection,
 		Name:        data.Name,
 		Alias:       data.Alias,
 		Description: data.Description,
+		IsComponent: data.IsComponent == "on",
 	}
 
 	err := s.repos.Collection.Create(collection)
@@ -84,6 +95,23 @@ func (s *collectionService) UpdateByID(colID uint, data dto.CollectionData) (*mo
 	collection.Name = data.Name
 	collection.Description = data.Description
 
+	isComponent := data.IsComponent == "on"
+	if isComponent && !collection.IsComponent {
+		entries, err := s.repos.Content.CountByCollectionID(collection.ID)
+		if err != nil {
+			return nil, err
+		}
+		if entries > 0 {
+			return nil, errors.New("cannot make collection a component, it has entries")
+		}
+		for _, f := range collection.Fields {
+			if err := componentFieldError(f); err != nil {
+				return nil, fmt.Errorf("cannot make collection a component, field %q: %w", f.Name, err)
+			}
+		}
+	}
+	collection.IsComponent = isComponent
+
 	err = s.repos.Collection.Save(collection)
 	return collection, err
 }
@@ -11,50 +11,15 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
-	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils/mockrepo"
 )
 
-type mockCollectionRepo struct{ mock.Mock }
-
-func (m *mockCollectionRepo) Create(c *model.Collection) error {
-	return m.Called(c).Error(0)
-}
-
-func (m *mockCollectionRepo) Save(c *model.Collection) error {
-	return m.Called(c).Error(0)
-}
-
-func (m *mockCollectionRepo) Delete(c *model.Collection) error {
-	return m.Called(c).Error(0)
-}
-
-func (m *mockCollectionRepo) FindByID(id uint, opts ...base.QueryOption) (*model.Collection, error) {
-	args := m.Called(id)
-	if obj := args.Get(0); obj != nil {
-		return obj.(*model.Collection), args.Error(1)
-	}
-	return nil, args.Error(1)
-}
-
-func (m *mockCollectionRepo) List(page, pageSize int, opts ...base.QueryOption) ([]model.Collection, int64, error) {
-	args := m.Called(page, pageSize)
-	return args.Get(0).([]model.Collection), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *mockCollectionRepo) FindByAlias(alias string) (*model.Collection, error) {
-	args := m.Called(alias)
-	if obj := args.Get(0); obj != nil {
-		return obj.(*model.Collection), args.Error(1)
-	}
-	return nil, args.Error(1)
-}
-
 func newTestCollectionService(repo repository.CollectionRepo) service.CollectionService {
 	return service.NewCollectionService(&repository.Set{Collection: repo})
 }
 func TestCollectionService_List(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := service.NewCollectionService(&repository.Set{Collection: repo})
 
 	sample := []model.Collection{{Model: gorm.Model{ID: 1}}}
@@ -67,7 +32,7 @@ func TestCollectionService_List(t *testing.T) {
 }
 
 func TestCollectionService_List_Error(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := service.NewCollectionService(&repository.Set{Collection: repo})
 
 	repo.On("List", 1, 1).Return([]model.Collection{}, int64(0), errors.New("fail"))
@@ -79,7 +44,7 @@ func TestCollectionService_List_Error(t *testing.T) {
 }
 
 func TestCollectionService_FindByID(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 7}}
@@ -91,7 +56,7 @@ func TestCollectionService_FindByID(t *testing.T) {
 }
 
 func TestCollectionService_FindByID_NotFound(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	repo.On("FindByID", uint(9)).Return(nil, errors.New("nf"))
@@ -101,7 +66,7 @@ func TestCollectionService_FindByID_NotFound(t *testing.T) {
 }
 
 func TestCollectionService_FindByAlias(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 3}, Alias: "a"}
@@ -113,17 +78,21 @@ func TestCollectionService_FindByAlias(t *testing.T) {
 }
 
 func TestCollectionService_FindByAlias_NotFound(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	repo.On("FindByAlias", "x").Return(nil, errors.New("nf2"))
 
 	_, err := svc.FindByAlias("x")
 	assert.EqualError(t, err, "nf2")
+
+	repo.On("FindByAlias", "y").Return(nil, gorm.ErrRecordNotFound)
+	_, err = svc.FindByAlias("y")
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
 }
 
 func TestCollectionService_Create(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	data := dto.CollectionData{Name: "N", Alias: "A", Description: "D"}
@@ -139,7 +108,7 @@ func TestCollectionService_Create(t *testing.T) {
 }
 
 func TestCollectionService_Create_Error(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	data := dto.CollectionData{Name: "N", Alias: "A", Description: ""}
@@ -150,7 +119,7 @@ func TestCollectionService_Create_Error(t *testing.T) {
 }
 
 func TestCollectionService_DeleteByID(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 5}}
@@ -162,7 +131,7 @@ func TestCollectionService_DeleteByID(t *testing.T) {
 }
 
 func TestCollectionService_DeleteByID_FindError(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	repo.On("FindByID", uint(6)).Return(nil, errors.New("dx"))
@@ -172,7 +141,7 @@ func TestCollectionService_DeleteByID_FindError(t *testing.T) {
 }
 
 func TestCollectionService_UpdateByID_Success(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 8}, Name: "Old", Alias: "OA", Description: "OD"}
@@ -188,7 +157,7 @@ func TestCollectionService_UpdateByID_Success(t *testing.T) {
 }
 
 func TestCollectionService_UpdateByID_FindError(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	repo.On("FindByID", uint(9)).Return(nil, errors.New("dx2"))
@@ -198,7 +167,7 @@ func TestCollectionService_UpdateByID_FindError(t *testing.T) {
 }
 
 func TestCollectionService_UpdateByID_NoAlias(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 10}}
@@ -209,7 +178,7 @@ func TestCollectionService_UpdateByID_NoAlias(t *testing.T) {
 }
 
 func TestCollectionService_UpdateByID_NoName(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 11}, Alias: "AliasOnly"}
@@ -220,7 +189,7 @@ func TestCollectionService_UpdateByID_NoName(t *testing.T) {
 }
 
 func TestCollectionService_UpdateByID_SaveError(t *testing.T) {
-	repo := new(mockCollectionRepo)
+	repo := new(mockrepo.MockCollectionRepo)
 	svc := newTestCollectionService(repo)
 
 	col := &model.Collection{Model: gorm.Model{ID: 12}, Name: "X", Alias: "Y"}
@@ -233,3 +202,28 @@ func TestCollectionService_UpdateByID_SaveError(t *testing.T) {
 	_, err := svc.UpdateByID(12, data)
 	assert.EqualError(t, err, "sfail")
 }
+
+func TestCollectionService_UpdateByID_Component(t *testing.T) {
+	repo := new(mockrepo.MockCollectionRepo)
+	contentRepo := new(mockrepo.MockContentRepo)
+	svc := service.NewCollectionService(&repository.Set{Collection: repo, Content: contentRepo})
+
+	col := &model.Collection{Model: gorm.Model{ID: 13}, Name: "Hero", Alias: "hero"}
+	repo.On("FindByID", uint(13)).Return(col, nil)
+	data := dto.CollectionData{Name: "Hero", Alias: "hero", IsComponent: "on"}
+
+	contentRepo.On("CountByCollectionID", uint(13)).Return(int64(1), nil).Once()
+	_, err := svc.UpdateByID(13, data)
+	assert.EqualError(t, err, "cannot make collection a component, it has entries")
+
+	contentRepo.On("CountByCollectionID", uint(13)).Return(int64(0), nil)
+	col.Fields = []model.Field{{Name: "Path", FieldType: model.FieldTypeSlug}}
+	_, err = svc.UpdateByID(13, data)
+	assert.EqualError(t, err, `cannot make collection a component, field "Path": components cannot contain slug fields`)
+
+	col.Fields = []model.Field{{Name: "Title", FieldType: model.FieldTypeText}}
+	repo.On("Save", col).Return(nil)
+	updated, err := svc.UpdateByID(13, data)
+	assert.NoError(t, err)
+	assert.True(t, updated.IsComponent)
+}
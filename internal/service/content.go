@@ -2,10 +2,12 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"gorm.io/gorm"
 )
 
@@ -14,11 +16,17 @@ type ContentService interface {
 	DeleteByID(id uint) error
 	CreateWithValues(cwv dto.ContentWithValues) (*model.Content, error)
 	FindContentsWithDisplayContentValue() ([]model.Content, error)
-	FindDisplayValueByCollectionID(collectionID uint, page, pageSize int) ([]model.Content, int64, error)
+	FindReferences(ids []uint) ([]model.Content, error)
+	SearchReferences(query string, collectionIDs []uint, page, pageSize int) ([]model.Content, int64, error)
+	FindDisplayValueByCollectionID(collectionID uint, page, pageSize int, sort, query string) ([]model.Content, int64, error)
 	FindByCollectionID(collectionID uint) ([]model.Content, error)
 	ListByCollectionAlias(alias string, offset int, limit int) ([]model.Content, error)
 	FindByID(id uint) (*model.Content, error)
 	Create(c *model.Content) (*model.Content, error)
+	UpdateStatus(id uint, status model.ContentStatus) (*model.Content, error)
+	RestoreRevision(contentID uint, revisionID uint, userID uint) (*model.Content, error)
+	ApplySchedule(now time.Time) ([]dto.StatusChange, error)
+	MissingTranslations(collectionID uint, defaultLocale string, locales []string) ([]dto.MissingTranslation, error)
 }
 
 type contentService struct {
@@ -35,7 +43,11 @@ func (s *contentService) Create(c *model.Content) (*model.Content, error) {
 }
 
 func (s *contentService) FindByID(id uint) (*model.Content, error) {
-	return s.repos.Content.FindByID(id)
+	content, err := s.repos.Content.FindByID(id)
+	if err != nil {
+		return nil, notFound(err, ErrContentNotFound)
+	}
+	return content, nil
 }
 
 func (s *contentService) ListByCollectionAlias(alias string, offset int, limit int) ([]model.Content, error) {
@@ -53,26 +65,70 @@ func (s *contentService) FindByCollectionID(collectionID uint) ([]model.Content,
 	return s.repos.Content.FindByCollectionID(collectionID, 0, 0)
 }
 
-func (s *contentService) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int) ([]model.Content, int64, error) {
-	return s.repos.Content.FindDisplayValueByCollectionID(collectionID, page, pageSize)
+func (s *contentService) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int, sort, query string) ([]model.Content, int64, error) {
+	var fields []model.Field
+	if sort != "" {
+		var err error
+		fields, err = s.repos.Field.FindByCollectionID(collectionID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	keys, err := parseSort(sort, fields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := []base.QueryOption{repository.OrderBy(keys...)}
+	if query != "" {
+		opts = append(opts, s.repos.Search.Matching(query))
+	}
+
+	return s.repos.Content.FindDisplayValueByCollectionID(collectionID, page, pageSize, opts...)
 }
 
 func (s *contentService) FindContentsWithDisplayContentValue() ([]model.Content, error) {
 	return s.repos.Content.ListWithDisplayContentValue()
 }
 
-func (s *contentService) saveContentValues(contentValueRepo repository.ContentValueRepo, contentID uint, fields []model.Field, formData map[string][]string) error {
-	for _, f := range fields {
-		for i, v := range formData[f.Alias] {
-			cv := model.ContentValue{
-				SortIndex: i + 1,
-				ContentID: contentID,
-				FieldID:   f.ID,
-				Value:     v,
-			}
+// FindReferences loads the entries with their display values, as shown for
+// selected references in the editor.
+func (s *contentService) FindReferences(ids []uint) ([]model.Content, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
 
-			if err := contentValueRepo.Create(&cv); err != nil {
-				return err
+	contents, _, err := s.repos.Content.List(1, len(ids), base.WithIDs(ids...), repository.WithDisplayValues())
+	return contents, err
+}
+
+// SearchReferences lists the entries of the collections, all when none are
+// given, whose ID or display values match the query, latest changes first.
+func (s *contentService) SearchReferences(query string, collectionIDs []uint, page, pageSize int) ([]model.Content, int64, error) {
+	return s.repos.Content.List(page, pageSize,
+		repository.InCollections(collectionIDs...),
+		repository.DisplayValueMatching(query),
+		repository.WithDisplayValues(),
+		repository.OrderBy(repository.SortKey{System: "updated_at", Desc: true}),
+	)
+}
+
+func (s *contentService) saveContentValues(contentValueRepo repository.ContentValueRepo, contentID uint, fields []model.Field, forms localeForms) error {
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			for i, v := range forms[locale][f.Alias] {
+				cv := model.ContentValue{
+					SortIndex: i + 1,
+					ContentID: contentID,
+					FieldID:   f.ID,
+					Locale:    locale,
+					Value:     v,
+				}
+
+				if err := contentValueRepo.Create(&cv); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -80,19 +136,102 @@ func (s *contentService) saveContentValues(contentValueRepo repository.ContentVa
 	return nil
 }
 
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+	return &utc
+}
+
+func normalizeSchedule(schedule *dto.ContentSchedule) error {
+	if schedule == nil {
+		return nil
+	}
+
+	schedule.PublishAt = toUTC(schedule.PublishAt)
+	schedule.UnpublishAt = toUTC(schedule.UnpublishAt)
+
+	if schedule.PublishAt == nil || schedule.UnpublishAt == nil {
+		return nil
+	}
+
+	if !schedule.UnpublishAt.After(*schedule.PublishAt) {
+		return errors.New("unpublish time must be after publish time")
+	}
+
+	return nil
+}
+
+func (s *contentService) saveRevision(revisionRepo repository.ContentRevisionRepo, contentID uint, userID uint, fields []model.Field, forms localeForms) error {
+	values := make([]model.RevisionValue, 0)
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			for i, v := range forms[locale][f.Alias] {
+				values = append(values, model.RevisionValue{
+					FieldID:   f.ID,
+					Alias:     f.Alias,
+					SortIndex: i + 1,
+					Locale:    locale,
+					Value:     v,
+				})
+			}
+		}
+	}
+
+	revision := model.ContentRevision{ContentID: contentID}
+	if userID != 0 {
+		revision.UserID = &userID
+	}
+
+	if err := revision.SetValues(values); err != nil {
+		return err
+	}
+
+	return revisionRepo.Create(&revision)
+}
+
 func (s *contentService) CreateWithValues(cwv dto.ContentWithValues) (*model.Content, error) {
 	var content model.Content
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		txField := s.repos.Field.WithTx(tx)
 		txContent := s.repos.Content.WithTx(tx)
 		txContentValue := s.repos.ContentValue.WithTx(tx)
+		txRevision := s.repos.Revision.WithTx(tx)
 
 		fields, err := txField.FindByCollectionID(cwv.CollectionID)
 		if err != nil {
 			return err
 		}
 
-		found := model.Content{CollectionID: cwv.CollectionID}
+		forms := newLocaleForms(cwv.FormData, cleanTranslations(fields, cwv.Translations))
+		if err := deriveSlugs(txContentValue, 0, fields, forms); err != nil {
+			return err
+		}
+
+		if err := s.validateForms(tx, txContent, txContentValue, 0, fields, forms); err != nil {
+			return err
+		}
+
+		status := cwv.Status
+		if status == "" {
+			status = model.ContentStatusDraft
+		}
+
+		if !model.IsValidContentStatus(status) {
+			return errors.New("invalid content status")
+		}
+
+		if err := normalizeSchedule(cwv.Schedule); err != nil {
+			return err
+		}
+
+		found := model.Content{CollectionID: cwv.CollectionID, Status: status}
+		if cwv.Schedule != nil {
+			found.PublishAt = cwv.Schedule.PublishAt
+			found.UnpublishAt = cwv.Schedule.UnpublishAt
+		}
 		content = found
 
 		err = txContent.Create(&content)
@@ -100,11 +239,15 @@ func (s *contentService) CreateWithValues(cwv dto.ContentWithValues) (*model.Con
 			return err
 		}
 
-		if err := s.saveContentValues(txContentValue, content.ID, fields, cwv.FormData); err != nil {
+		if err := s.saveContentValues(txContentValue, content.ID, fields, forms); err != nil {
 			return err
 		}
 
-		return nil
+		if err := indexContent(s.repos.Search.WithTx(tx), content.ID, content.CollectionID, fields, forms); err != nil {
+			return err
+		}
+
+		return s.saveRevision(txRevision, content.ID, cwv.UserID, fields, forms)
 	})
 
 	return &content, err
@@ -124,7 +267,7 @@ func (s *contentService) DeleteByID(id uint) error {
 			return err
 		}
 
-		return nil
+		return s.repos.Search.WithTx(tx).DeleteByContentID(id)
 	})
 }
 
@@ -144,11 +287,35 @@ func (s *contentService) deleteContentValuesByID(repo repository.ContentValueRep
 	return nil
 }
 
+// validateForms runs the checks every write of content values goes through,
+// whether the values come from the editor or from a revision.
+func (s *contentService) validateForms(tx *gorm.DB, txContent repository.ContentRepo, txContentValue repository.ContentValueRepo, contentID uint, fields []model.Field, forms localeForms) error {
+	if err := validateContent(fields, forms[""], forms.translations()); err != nil {
+		return err
+	}
+
+	components, err := loadComponents(s.repos.Collection, tx, fields)
+	if err != nil {
+		return err
+	}
+
+	if err := validateBlocks(fields, components, forms); err != nil {
+		return err
+	}
+
+	if err := validateReferences(txContent, fields, forms); err != nil {
+		return err
+	}
+
+	return validateUnique(txContentValue, contentID, fields, forms)
+}
+
 func (s *contentService) EditWithValues(cwv dto.ContentWithValues) (*model.Content, error) {
 	var content *model.Content
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 		txContent := s.repos.Content.WithTx(tx)
 		txContentValue := s.repos.ContentValue.WithTx(tx)
+		txRevision := s.repos.Revision.WithTx(tx)
 
 		found, err := txContent.FindByID(cwv.ContentID)
 		content = found
@@ -160,21 +327,201 @@ func (s *contentService) EditWithValues(cwv dto.ContentWithValues) (*model.Conte
 			return errors.New("content doesnt relate to Collection")
 		}
 
+		fields, err := s.repos.Field.WithTx(tx).FindByCollectionID(cwv.CollectionID)
+		if err != nil {
+			return err
+		}
+
+		translations := cwv.Translations
+		if translations == nil && len(localizedFields(fields)) > 0 {
+			stored, err := txContentValue.FindByContentID(content.ID)
+			if err != nil {
+				return err
+			}
+			translations = formsFromValues(fields, stored).translations()
+		}
+
+		forms := newLocaleForms(cwv.FormData, cleanTranslations(fields, translations))
+		if err := deriveSlugs(txContentValue, content.ID, fields, forms); err != nil {
+			return err
+		}
+
+		if err := s.validateForms(tx, txContent, txContentValue, content.ID, fields, forms); err != nil {
+			return err
+		}
+
+		if cwv.Status != "" && cwv.Status != content.Status {
+			if !model.IsValidContentStatus(cwv.Status) {
+				return errors.New("invalid content status")
+			}
+
+			if err := txContent.UpdateStatus(content.ID, cwv.Status); err != nil {
+				return err
+			}
+			content.Status = cwv.Status
+		}
+
+		if cwv.Schedule != nil {
+			if err := normalizeSchedule(cwv.Schedule); err != nil {
+				return err
+			}
+
+			if err := txContent.UpdateSchedule(content.ID, cwv.Schedule.PublishAt, cwv.Schedule.UnpublishAt); err != nil {
+				return err
+			}
+			content.PublishAt = cwv.Schedule.PublishAt
+			content.UnpublishAt = cwv.Schedule.UnpublishAt
+		}
+
 		if err = s.deleteContentValuesByID(txContentValue, content.ID); err != nil {
 			return err
 		}
 
-		fields, err := s.repos.Field.FindByCollectionID(cwv.CollectionID)
+		if err := s.saveContentValues(txContentValue, content.ID, fields, forms); err != nil {
+			return err
+		}
+
+		if err := indexContent(s.repos.Search.WithTx(tx), content.ID, content.CollectionID, fields, forms); err != nil {
+			return err
+		}
+
+		return s.saveRevision(txRevision, content.ID, cwv.UserID, fields, forms)
+	})
+
+	return content, err
+}
+
+func (s *contentService) UpdateStatus(id uint, status model.ContentStatus) (*model.Content, error) {
+	if !model.IsValidContentStatus(status) {
+		return nil, errors.New("invalid content status")
+	}
+
+	content, err := s.repos.Content.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.Content.UpdateStatus(content.ID, status); err != nil {
+		return nil, err
+	}
+
+	content.Status = status
+	return content, nil
+}
+
+func (s *contentService) RestoreRevision(contentID uint, revisionID uint, userID uint) (*model.Content, error) {
+	var content *model.Content
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txField := s.repos.Field.WithTx(tx)
+		txContent := s.repos.Content.WithTx(tx)
+		txContentValue := s.repos.ContentValue.WithTx(tx)
+		txRevision := s.repos.Revision.WithTx(tx)
+
+		found, err := txContent.FindByID(contentID)
+		if err != nil {
+			return err
+		}
+		content = found
+
+		revision, err := txRevision.FindByID(revisionID)
 		if err != nil {
 			return err
 		}
 
-		if err := s.saveContentValues(txContentValue, content.ID, fields, cwv.FormData); err != nil {
+		if revision.ContentID != content.ID {
+			return errors.New("revision doesnt relate to content")
+		}
+
+		values, err := revision.Values()
+		if err != nil {
 			return err
 		}
 
-		return nil
+		fields, err := txField.FindByCollectionID(content.CollectionID)
+		if err != nil {
+			return err
+		}
+
+		restored := make([]model.ContentValue, 0, len(values))
+		for _, v := range values {
+			restored = append(restored, model.ContentValue{FieldID: v.FieldID, SortIndex: v.SortIndex, Locale: v.Locale, Value: v.Value})
+		}
+		forms := formsFromValues(fields, restored)
+
+		if err := s.validateForms(tx, txContent, txContentValue, content.ID, fields, forms); err != nil {
+			return err
+		}
+
+		if err := s.deleteContentValuesByID(txContentValue, content.ID); err != nil {
+			return err
+		}
+
+		if err := s.saveContentValues(txContentValue, content.ID, fields, forms); err != nil {
+			return err
+		}
+
+		if err := indexContent(s.repos.Search.WithTx(tx), content.ID, content.CollectionID, fields, forms); err != nil {
+			return err
+		}
+
+		return s.saveRevision(txRevision, content.ID, userID, fields, forms)
 	})
 
 	return content, err
 }
+
+func (s *contentService) ApplySchedule(now time.Time) ([]dto.StatusChange, error) {
+	changes := make([]dto.StatusChange, 0)
+
+	due, err := s.repos.Content.FindDueForPublish(now)
+	if err != nil {
+		return changes, err
+	}
+
+	for _, c := range due {
+		change := dto.StatusChange{ContentID: c.ID, CollectionID: c.CollectionID, From: c.Status, To: model.ContentStatusPublished}
+		if err := s.applyScheduledChange(change, nil, c.UnpublishAt); err != nil {
+			return changes, err
+		}
+
+		if change.From != change.To {
+			changes = append(changes, change)
+		}
+	}
+
+	due, err = s.repos.Content.FindDueForUnpublish(now)
+	if err != nil {
+		return changes, err
+	}
+
+	for _, c := range due {
+		change := dto.StatusChange{ContentID: c.ID, CollectionID: c.CollectionID, From: c.Status, To: c.Status}
+		if c.Status == model.ContentStatusPublished {
+			change.To = model.ContentStatusDraft
+		}
+
+		if err := s.applyScheduledChange(change, c.PublishAt, nil); err != nil {
+			return changes, err
+		}
+
+		if change.From != change.To {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+func (s *contentService) applyScheduledChange(change dto.StatusChange, publishAt, unpublishAt *time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		txContent := s.repos.Content.WithTx(tx)
+
+		if change.From != change.To {
+			if err := txContent.UpdateStatus(change.ContentID, change.To); err != nil {
+				return err
+			}
+		}
+
+		return txContent.UpdateSchedule(change.ContentID, publishAt, unpublishAt)
+	})
+}
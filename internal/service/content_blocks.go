@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"gorm.io/gorm"
+)
+
+// loadComponents loads the components when one of the fields is a Blocks
+// field and nil otherwise.
+func loadComponents(repo repository.CollectionRepo, tx *gorm.DB, fields []model.Field) ([]model.Collection, error) {
+	for _, f := range fields {
+		if f.FieldType == model.FieldTypeBlocks {
+			return repo.WithTx(tx).FindComponents()
+		}
+	}
+	return nil, nil
+}
+
+func findComponent(components []model.Collection, alias string) (model.Collection, bool) {
+	for _, c := range components {
+		if c.Alias == alias {
+			return c, true
+		}
+	}
+	return model.Collection{}, false
+}
+
+// validateBlocks checks each block of the Blocks fields against the fields of
+// its component. Values of fields the component does not have are dropped.
+func validateBlocks(fields []model.Field, components []model.Collection, forms localeForms) error {
+	verrs := make(ValidationErrors)
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			values := forms[locale][f.Alias]
+			if f.FieldType != model.FieldTypeBlocks || len(values) == 0 {
+				continue
+			}
+
+			var errs []string
+			blocks := make([]string, 0, len(values))
+			for i, v := range values {
+				if strings.TrimSpace(v) == "" {
+					continue
+				}
+
+				block, err := model.ParseBlock(v)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("block %d: %s", i+1, err))
+					continue
+				}
+
+				component, ok := findComponent(components, block.Component)
+				if !ok {
+					errs = append(errs, fmt.Sprintf("block %d: unknown component %q", i+1, block.Component))
+					continue
+				}
+
+				known := make(map[string][]string, len(component.Fields))
+				for _, cf := range component.Fields {
+					if v, ok := block.Values[cf.Alias]; ok {
+						known[cf.Alias] = v
+					}
+					for _, e := range cf.Validate(block.Values[cf.Alias]) {
+						errs = append(errs, fmt.Sprintf("block %d (%s), %s: %s", i+1, component.Name, cf.Name, e))
+					}
+				}
+				block.Values = known
+				blocks = append(blocks, block.String())
+			}
+
+			key := f.Alias
+			if locale != "" {
+				key = TranslationKey(f.Alias, locale)
+			}
+
+			if len(errs) > 0 {
+				verrs[key] = errs
+				continue
+			}
+
+			data := maps.Clone(forms[locale])
+			data[f.Alias] = blocks
+			forms[locale] = data
+		}
+	}
+
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	return nil
+}
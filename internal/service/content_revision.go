@@ -0,0 +1,149 @@
+package service
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+type ContentRevisionService interface {
+	History(contentID uint) ([]dto.RevisionEntry, error)
+}
+
+type contentRevisionService struct {
+	repos *repository.Set
+}
+
+func NewContentRevisionService(repos *repository.Set) *contentRevisionService {
+	return &contentRevisionService{repos: repos}
+}
+
+func (s *contentRevisionService) History(contentID uint) ([]dto.RevisionEntry, error) {
+	content, err := s.repos.Content.FindByID(contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := s.repos.Field.FindByCollectionID(content.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repos.Revision.FindByContentID(contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]map[string][]string, len(revisions))
+	for i, rev := range revisions {
+		values, err := rev.Values()
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = groupRevisionValues(values)
+	}
+
+	entries := make([]dto.RevisionEntry, 0, len(revisions))
+	for i, rev := range revisions {
+		previous := map[string][]string{}
+		if i+1 < len(revisions) {
+			previous = snapshots[i+1]
+		}
+
+		entry := dto.RevisionEntry{
+			Revision: rev,
+			Changes:  diffRevisionValues(fields, previous, snapshots[i]),
+		}
+		if rev.User != nil {
+			entry.Author = rev.User.Email
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func groupRevisionValues(values []model.RevisionValue) map[string][]string {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].SortIndex < values[j].SortIndex
+	})
+
+	grouped := make(map[string][]string)
+	for _, v := range values {
+		key := v.Alias
+		if v.Locale != "" {
+			key = TranslationKey(v.Alias, v.Locale)
+		}
+		grouped[key] = append(grouped[key], v.Value)
+	}
+
+	return grouped
+}
+
+func diffRevisionValues(fields []model.Field, before, after map[string][]string) []dto.FieldChange {
+	changes := make([]dto.FieldChange, 0)
+	seen := make(map[string]bool)
+
+	for _, f := range fields {
+		seen[f.Alias] = true
+		if !slices.Equal(before[f.Alias], after[f.Alias]) {
+			changes = append(changes, dto.FieldChange{
+				Alias:  f.Alias,
+				Name:   f.Name,
+				Before: before[f.Alias],
+				After:  after[f.Alias],
+			})
+		}
+
+		var translations []string
+		for _, snapshot := range []map[string][]string{before, after} {
+			for key := range snapshot {
+				if alias, _, ok := SplitTranslationKey(key); ok && alias == f.Alias && !seen[key] {
+					seen[key] = true
+					translations = append(translations, key)
+				}
+			}
+		}
+		sort.Strings(translations)
+
+		for _, key := range translations {
+			if !slices.Equal(before[key], after[key]) {
+				_, locale, _ := SplitTranslationKey(key)
+				changes = append(changes, dto.FieldChange{
+					Alias:  key,
+					Name:   f.Name + " (" + locale + ")",
+					Before: before[key],
+					After:  after[key],
+				})
+			}
+		}
+	}
+
+	removed := make([]string, 0)
+	for _, snapshot := range []map[string][]string{before, after} {
+		for alias := range snapshot {
+			if !seen[alias] {
+				seen[alias] = true
+				removed = append(removed, alias)
+			}
+		}
+	}
+	sort.Strings(removed)
+
+	for _, alias := range removed {
+		if !slices.Equal(before[alias], after[alias]) {
+			changes = append(changes, dto.FieldChange{
+				Alias:  alias,
+				Name:   alias,
+				Before: before[alias],
+				After:  after[alias],
+			})
+		}
+	}
+
+	return changes
+}
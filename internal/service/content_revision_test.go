@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func revisionWithValues(id uint, values []model.RevisionValue, user *model.User) model.ContentRevision {
+	rev := model.ContentRevision{Model: gorm.Model{ID: id}, ContentID: 3, User: user}
+	rev.SetValues(values)
+	return rev
+}
+
+func TestContentRevisionService_History(t *testing.T) {
+	contentRepo := new(mockrepo.MockContentRepo)
+	fieldRepo := new(mockrepo.MockFieldRepo)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	s := service.NewContentRevisionService(&repository.Set{
+		Content:  contentRepo,
+		Field:    fieldRepo,
+		Revision: revisionRepo,
+	})
+
+	fields := []model.Field{
+		{Model: gorm.Model{ID: 1}, Alias: "title", Name: "Title"},
+		{Model: gorm.Model{ID: 2}, Alias: "body", Name: "Body"},
+	}
+
+	newest := revisionWithValues(2, []model.RevisionValue{
+		{FieldID: 1, Alias: "title", SortIndex: 1, Value: "New"},
+		{FieldID: 2, Alias: "body", SortIndex: 1, Value: "Text"},
+	}, &model.User{Email: "editor@example.com"})
+	oldest := revisionWithValues(1, []model.RevisionValue{
+		{FieldID: 1, Alias: "title", SortIndex: 1, Value: "Old"},
+		{FieldID: 2, Alias: "body", SortIndex: 1, Value: "Text"},
+		{FieldID: 5, Alias: "legacy", SortIndex: 1, Value: "x"},
+	}, nil)
+
+	contentRepo.On("FindByID", uint(3)).Return(&model.Content{Model: gorm.Model{ID: 3}, CollectionID: 1}, nil)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return(fields, nil)
+	revisionRepo.On("FindByContentID", uint(3)).Return([]model.ContentRevision{newest, oldest}, nil)
+
+	entries, err := s.History(3)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, "editor@example.com", entries[0].Author)
+	assert.Len(t, entries[0].Changes, 2)
+	assert.Equal(t, "Title", entries[0].Changes[0].Name)
+	assert.Equal(t, []string{"Old"}, entries[0].Changes[0].Before)
+	assert.Equal(t, []string{"New"}, entries[0].Changes[0].After)
+	assert.Equal(t, "legacy", entries[0].Changes[1].Alias)
+	assert.Nil(t, entries[0].Changes[1].After)
+
+	assert.Empty(t, entries[1].Author)
+	assert.Len(t, entries[1].Changes, 3)
+	assert.Nil(t, entries[1].Changes[0].Before)
+}
+
+func TestContentRevisionService_History_ContentNotFound(t *testing.T) {
+	contentRepo := new(mockrepo.MockContentRepo)
+	s := service.NewContentRevisionService(&repository.Set{Content: contentRepo})
+
+	contentRepo.On("FindByID", uint(3)).Return(nil, errors.New("not found"))
+
+	_, err := s.History(3)
+	assert.Error(t, err)
+}
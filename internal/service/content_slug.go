@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+const maxSlugSuffix = 1000
+
+func hasValue(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// deriveSlugs fills empty Slug fields from their source field. A slug taken
+// by another entry gets a counter, "my-post" becomes "my-post-2".
+func deriveSlugs(repo repository.ContentValueRepo, contentID uint, fields []model.Field, forms localeForms) error {
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			if f.FieldType != model.FieldTypeSlug || f.SlugSource == "" || hasValue(forms[locale][f.Alias]) {
+				continue
+			}
+
+			base := model.Slugify(strings.Join(forms[locale][f.SlugSource], " "))
+			if base == "" {
+				continue
+			}
+
+			slug, err := freeSlug(repo, f.ID, locale, base, contentID)
+			if err != nil {
+				return err
+			}
+
+			data := maps.Clone(forms[locale])
+			if data == nil {
+				data = map[string][]string{}
+			}
+			data[f.Alias] = []string{slug}
+			forms[locale] = data
+		}
+	}
+
+	return nil
+}
+
+func freeSlug(repo repository.ContentValueRepo, fieldID uint, locale, base string, contentID uint) (string, error) {
+	slug := base
+	for i := 2; i <= maxSlugSuffix; i++ {
+		taken, err := repo.ValueExists(fieldID, locale, slug, contentID)
+		if err != nil || !taken {
+			return slug, err
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return "", fmt.Errorf("no free slug for %q", base)
+}
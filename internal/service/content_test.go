@@ -2,11 +2,14 @@ package service_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/testutils"
 	"github.com/janmarkuslanger/nuricms/testutils/mockrepo"
@@ -20,9 +23,14 @@ func TestDeleteByID(t *testing.T) {
 	mockContentRepo := new(mockrepo.MockContentRepo)
 	mockContentValueRepo := new(mockrepo.MockContentValueRepo)
 
+	mockSearchRepo := new(mockrepo.MockSearchRepo)
+	mockSearchRepo.On("WithTx", mock.Anything).Return(mockSearchRepo).Maybe()
+	mockSearchRepo.On("DeleteByContentID", mock.Anything).Return(nil).Maybe()
+
 	repos := &repository.Set{
 		Content:      mockContentRepo,
 		ContentValue: mockContentValueRepo,
+		Search:       mockSearchRepo,
 	}
 
 	s := service.NewContentService(repos, testDB)
@@ -43,9 +51,14 @@ func TestDeleteByID_Failed(t *testing.T) {
 	mockContentRepo := new(mockrepo.MockContentRepo)
 	mockContentValueRepo := new(mockrepo.MockContentValueRepo)
 
+	mockSearchRepo := new(mockrepo.MockSearchRepo)
+	mockSearchRepo.On("WithTx", mock.Anything).Return(mockSearchRepo).Maybe()
+	mockSearchRepo.On("DeleteByContentID", mock.Anything).Return(nil).Maybe()
+
 	repos := &repository.Set{
 		Content:      mockContentRepo,
 		ContentValue: mockContentValueRepo,
+		Search:       mockSearchRepo,
 	}
 
 	s := service.NewContentService(repos, testDB)
@@ -66,9 +79,14 @@ func TestDeleteByID_FindByContentIDErr(t *testing.T) {
 	mockContentRepo := new(mockrepo.MockContentRepo)
 	mockContentValueRepo := new(mockrepo.MockContentValueRepo)
 
+	mockSearchRepo := new(mockrepo.MockSearchRepo)
+	mockSearchRepo.On("WithTx", mock.Anything).Return(mockSearchRepo).Maybe()
+	mockSearchRepo.On("DeleteByContentID", mock.Anything).Return(nil).Maybe()
+
 	repos := &repository.Set{
 		Content:      mockContentRepo,
 		ContentValue: mockContentValueRepo,
+		Search:       mockSearchRepo,
 	}
 
 	s := service.NewContentService(repos, testDB)
@@ -89,9 +107,14 @@ func TestDeleteByID_DeleteErr(t *testing.T) {
 	mockContentRepo := new(mockrepo.MockContentRepo)
 	mockContentValueRepo := new(mockrepo.MockContentValueRepo)
 
+	mockSearchRepo := new(mockrepo.MockSearchRepo)
+	mockSearchRepo.On("WithTx", mock.Anything).Return(mockSearchRepo).Maybe()
+	mockSearchRepo.On("DeleteByContentID", mock.Anything).Return(nil).Maybe()
+
 	repos := &repository.Set{
 		Content:      mockContentRepo,
 		ContentValue: mockContentValueRepo,
+		Search:       mockSearchRepo,
 	}
 
 	s := service.NewContentService(repos, testDB)
@@ -133,12 +156,16 @@ func TestFindByID(t *testing.T) {
 	result, err := s.FindByID(42)
 	assert.NoError(t, err)
 	assert.Equal(t, mockContent, result)
+
+	mockContentRepo.On("FindByID", uint(43)).Return(nil, gorm.ErrRecordNotFound)
+	_, err = s.FindByID(43)
+	assert.ErrorIs(t, err, service.ErrContentNotFound)
 }
 
 func TestListByCollectionAlias(t *testing.T) {
 	testDB := testutils.SetupTestDB(t)
 	mockContentRepo := new(mockrepo.MockContentRepo)
-	mockCollectionRepo := new(testutils.MockCollectionRepo)
+	mockCollectionRepo := new(mockrepo.MockCollectionRepo)
 	repos := &repository.Set{
 		Content:    mockContentRepo,
 		Collection: mockCollectionRepo,
@@ -158,7 +185,7 @@ func TestListByCollectionAlias(t *testing.T) {
 func TestListByCollectionAlias_FindByAliasErr(t *testing.T) {
 	testDB := testutils.SetupTestDB(t)
 	mockContentRepo := new(mockrepo.MockContentRepo)
-	mockCollectionRepo := new(testutils.MockCollectionRepo)
+	mockCollectionRepo := new(mockrepo.MockCollectionRepo)
 	repos := &repository.Set{
 		Content:    mockContentRepo,
 		Collection: mockCollectionRepo,
@@ -193,7 +220,7 @@ func TestFindDisplayValueByCollectionID(t *testing.T) {
 	s := service.NewContentService(repos, testDB)
 
 	mockContentRepo.On("FindDisplayValueByCollectionID", uint(1), 0, 10).Return([]model.Content{{}}, int64(1), nil)
-	result, count, err := s.FindDisplayValueByCollectionID(1, 0, 10)
+	result, count, err := s.FindDisplayValueByCollectionID(1, 0, 10, "", "")
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, int64(1), count)
@@ -215,13 +242,22 @@ func setupWithValues(t *testing.T) (*gorm.DB, *mockrepo.MockFieldRepo, *mockrepo
 	testDB := testutils.SetupTestDB(t)
 
 	fieldRepo := new(mockrepo.MockFieldRepo)
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo).Maybe()
 	contentRepo := new(mockrepo.MockContentRepo)
 	contentValueRepo := new(mockrepo.MockContentValueRepo)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	revisionRepo.On("WithTx", mock.Anything).Return(revisionRepo).Maybe()
+	revisionRepo.On("Create", mock.AnythingOfType("*model.ContentRevision")).Return(nil).Maybe()
+	searchRepo := new(mockrepo.MockSearchRepo)
+	searchRepo.On("WithTx", mock.Anything).Return(searchRepo).Maybe()
+	searchRepo.On("Save", mock.AnythingOfType("*model.SearchDocument")).Return(nil).Maybe()
 
 	repos := &repository.Set{
 		Content:      contentRepo,
 		Field:        fieldRepo,
 		ContentValue: contentValueRepo,
+		Revision:     revisionRepo,
+		Search:       searchRepo,
 	}
 
 	return testDB, fieldRepo, contentRepo, contentValueRepo, repos
@@ -488,7 +524,6 @@ func TestEditWithValues_NoFields(t *testing.T) {
 	contentRepo.On("FindByID", mock.Anything).Return(content, nil)
 
 	contentValueRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(contentValueRepo).Maybe()
-	contentValueRepo.On("FindByContentID", uint(42)).Return([]model.ContentValue{}, nil)
 	contentValueRepo.On("Delete", mock.AnythingOfType("*model.ContentValue")).Return(nil).Maybe()
 	contentValueRepo.On("Create", mock.AnythingOfType("*model.ContentValue")).Return(nil).Maybe()
 
@@ -506,4 +541,609 @@ func TestEditWithValues_NoFields(t *testing.T) {
 	fieldRepo.AssertExpectations(t)
 	contentRepo.AssertExpectations(t)
 	contentValueRepo.AssertExpectations(t)
+	contentValueRepo.AssertNotCalled(t, "FindByContentID", mock.Anything)
+}
+
+func TestCreateWithValues_DefaultsToDraft(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	s := service.NewContentService(repos, testDB)
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{}, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("Create", mock.MatchedBy(func(c *model.Content) bool {
+		return c.Status == model.ContentStatusDraft
+	})).Return(nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+
+	result, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ContentStatusDraft, result.Status)
+	contentRepo.AssertExpectations(t)
+}
+
+func TestCreateWithValues_InvalidStatus(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	s := service.NewContentService(repos, testDB)
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{}, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+
+	_, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: 1, Status: "Unknown"})
+	assert.Error(t, err)
+	contentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	mockContentRepo := new(mockrepo.MockContentRepo)
+	repos := &repository.Set{Content: mockContentRepo}
+	s := service.NewContentService(repos, testDB)
+
+	mockContentRepo.On("FindByID", uint(3)).Return(&model.Content{Model: gorm.Model{ID: 3}, Status: model.ContentStatusDraft}, nil)
+	mockContentRepo.On("UpdateStatus", uint(3), model.ContentStatusPublished).Return(nil)
+
+	content, err := s.UpdateStatus(3, model.ContentStatusPublished)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ContentStatusPublished, content.Status)
+}
+
+func TestUpdateStatus_Invalid(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	mockContentRepo := new(mockrepo.MockContentRepo)
+	repos := &repository.Set{Content: mockContentRepo}
+	s := service.NewContentService(repos, testDB)
+
+	_, err := s.UpdateStatus(3, model.ContentStatus("nope"))
+	assert.Error(t, err)
+	mockContentRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestCreateWithValues_WritesRevision(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	repos.Revision = revisionRepo
+	s := service.NewContentService(repos, testDB)
+
+	fields := []model.Field{{Model: gorm.Model{ID: 1}, Alias: "title"}}
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return(fields, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("Create", mock.AnythingOfType("*model.Content")).Return(nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+	contentValueRepo.On("Create", mock.AnythingOfType("*model.ContentValue")).Return(nil)
+	revisionRepo.On("WithTx", mock.Anything).Return(revisionRepo)
+	revisionRepo.On("Create", mock.MatchedBy(func(r *model.ContentRevision) bool {
+		values, err := r.Values()
+		return err == nil &&
+			r.UserID != nil && *r.UserID == 7 &&
+			len(values) == 1 && values[0].Alias == "title" && values[0].Value == "Hello"
+	})).Return(nil)
+
+	_, err := s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: 1,
+		UserID:       7,
+		FormData:     map[string][]string{"title": {"Hello"}},
+	})
+
+	assert.NoError(t, err)
+	revisionRepo.AssertExpectations(t)
+}
+
+func TestRestoreRevision(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	repos.Revision = revisionRepo
+	s := service.NewContentService(repos, testDB)
+
+	revision := &model.ContentRevision{Model: gorm.Model{ID: 9}, ContentID: 3}
+	revision.SetValues([]model.RevisionValue{
+		{FieldID: 1, Alias: "title", SortIndex: 1, Value: "Old title"},
+		{FieldID: 99, Alias: "removed", SortIndex: 1, Value: "gone"},
+	})
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{{Model: gorm.Model{ID: 1}, Alias: "title"}}, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("FindByID", uint(3)).Return(&model.Content{Model: gorm.Model{ID: 3}, CollectionID: 1}, nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+	contentValueRepo.On("FindByContentID", uint(3)).Return([]model.ContentValue{{Model: gorm.Model{ID: 5}}}, nil)
+	contentValueRepo.On("Delete", mock.AnythingOfType("*model.ContentValue")).Return(nil)
+	contentValueRepo.On("Create", mock.MatchedBy(func(cv *model.ContentValue) bool {
+		return cv.FieldID == 1 && cv.Value == "Old title"
+	})).Return(nil).Once()
+	revisionRepo.On("WithTx", mock.Anything).Return(revisionRepo)
+	revisionRepo.On("FindByID", uint(9)).Return(revision, nil)
+	revisionRepo.On("Create", mock.AnythingOfType("*model.ContentRevision")).Return(nil)
+
+	content, err := s.RestoreRevision(3, 9, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), content.ID)
+	contentValueRepo.AssertExpectations(t)
+	revisionRepo.AssertExpectations(t)
+}
+
+func TestRestoreRevision_Invalid(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	repos.Revision = revisionRepo
+	s := service.NewContentService(repos, testDB)
+
+	revision := &model.ContentRevision{Model: gorm.Model{ID: 9}, ContentID: 3}
+	revision.SetValues([]model.RevisionValue{
+		{FieldID: 1, Alias: "price", SortIndex: 1, Value: "cheap"},
+	})
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{{Model: gorm.Model{ID: 1}, Alias: "price", FieldType: model.FieldTypeNumber}}, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("FindByID", uint(3)).Return(&model.Content{Model: gorm.Model{ID: 3}, CollectionID: 1}, nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+	revisionRepo.On("WithTx", mock.Anything).Return(revisionRepo)
+	revisionRepo.On("FindByID", uint(9)).Return(revision, nil)
+
+	_, err := s.RestoreRevision(3, 9, 2)
+
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"must be a number"}, verrs["price"])
+	contentValueRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	revisionRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRestoreRevision_WrongContent(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	revisionRepo := new(mockrepo.MockContentRevisionRepo)
+	repos.Revision = revisionRepo
+	s := service.NewContentService(repos, testDB)
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("FindByID", uint(3)).Return(&model.Content{Model: gorm.Model{ID: 3}, CollectionID: 1}, nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+	revisionRepo.On("WithTx", mock.Anything).Return(revisionRepo)
+	revisionRepo.On("FindByID", uint(9)).Return(&model.ContentRevision{Model: gorm.Model{ID: 9}, ContentID: 4}, nil)
+
+	_, err := s.RestoreRevision(3, 9, 2)
+
+	assert.Error(t, err)
+	contentValueRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	revisionRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateWithValues_InvalidSchedule(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	s := service.NewContentService(repos, testDB)
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{}, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+
+	publishAt := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(-time.Hour)
+
+	_, err := s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: 1,
+		Schedule:     &dto.ContentSchedule{PublishAt: &publishAt, UnpublishAt: &unpublishAt},
+	})
+	assert.Error(t, err)
+	contentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestApplySchedule(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	contentRepo := new(mockrepo.MockContentRepo)
+	repos := &repository.Set{Content: contentRepo}
+	s := service.NewContentService(repos, testDB)
+
+	now := time.Date(2030, 1, 2, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("FindDueForPublish", now).Return([]model.Content{
+		{Model: gorm.Model{ID: 1}, Status: model.ContentStatusDraft, PublishAt: &past, UnpublishAt: &later},
+		{Model: gorm.Model{ID: 2}, Status: model.ContentStatusPublished, PublishAt: &past},
+	}, nil)
+	contentRepo.On("FindDueForUnpublish", now).Return([]model.Content{
+		{Model: gorm.Model{ID: 3}, Status: model.ContentStatusPublished, UnpublishAt: &past},
+		{Model: gorm.Model{ID: 4}, Status: model.ContentStatusDraft, UnpublishAt: &past},
+	}, nil)
+	contentRepo.On("UpdateStatus", uint(1), model.ContentStatusPublished).Return(nil)
+	contentRepo.On("UpdateSchedule", uint(1), (*time.Time)(nil), &later).Return(nil)
+	contentRepo.On("UpdateSchedule", uint(2), (*time.Time)(nil), (*time.Time)(nil)).Return(nil)
+	contentRepo.On("UpdateStatus", uint(3), model.ContentStatusDraft).Return(nil)
+	contentRepo.On("UpdateSchedule", uint(3), (*time.Time)(nil), (*time.Time)(nil)).Return(nil)
+	contentRepo.On("UpdateSchedule", uint(4), (*time.Time)(nil), (*time.Time)(nil)).Return(nil)
+
+	changes, err := s.ApplySchedule(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.StatusChange{
+		{ContentID: 1, From: model.ContentStatusDraft, To: model.ContentStatusPublished},
+		{ContentID: 3, From: model.ContentStatusPublished, To: model.ContentStatusDraft},
+	}, changes)
+	contentRepo.AssertExpectations(t)
+	contentRepo.AssertNotCalled(t, "UpdateStatus", uint(2), mock.Anything)
+	contentRepo.AssertNotCalled(t, "UpdateStatus", uint(4), mock.Anything)
+}
+
+func TestApplySchedule_FindErr(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	contentRepo := new(mockrepo.MockContentRepo)
+	repos := &repository.Set{Content: contentRepo}
+	s := service.NewContentService(repos, testDB)
+
+	now := time.Now()
+	contentRepo.On("FindDueForPublish", now).Return([]model.Content{}, errors.New("db down"))
+
+	_, err := s.ApplySchedule(now)
+	assert.Error(t, err)
+}
+
+func TestCreateWithValues_ValidationErrors(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	s := service.NewContentService(repos, testDB)
+
+	minValue := 1.0
+	fields := []model.Field{
+		{Model: gorm.Model{ID: 1}, Alias: "title", FieldType: model.FieldTypeText, IsRequired: true},
+		{Model: gorm.Model{ID: 2}, Alias: "price", FieldType: model.FieldTypeNumber, MinValue: &minValue},
+		{Model: gorm.Model{ID: 3}, Alias: "desc", FieldType: model.FieldTypeText},
+	}
+
+	fieldRepo.On("WithTx", mock.Anything).Return(fieldRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return(fields, nil)
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+
+	_, err := s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: 1,
+		FormData: map[string][]string{
+			"price": {"0"},
+			"desc":  {"fine"},
+		},
+	})
+
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, service.ValidationErrors{
+		"title": {"is required"},
+		"price": {"must be at least 1"},
+	}, verrs)
+	assert.Equal(t, "validation failed: price: must be at least 1; title: is required", err.Error())
+	contentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestEditWithValues_ValidationErrors(t *testing.T) {
+	testDB, fieldRepo, contentRepo, contentValueRepo, repos := setupWithValues(t)
+	s := service.NewContentService(repos, testDB)
+
+	fields := []model.Field{
+		{Model: gorm.Model{ID: 1}, Alias: "date", FieldType: model.FieldTypeDate},
+	}
+
+	contentRepo.On("WithTx", mock.Anything).Return(contentRepo)
+	contentRepo.On("FindByID", uint(42)).Return(&model.Content{Model: gorm.Model{ID: 42}, CollectionID: 1}, nil)
+	contentValueRepo.On("WithTx", mock.Anything).Return(contentValueRepo)
+	fieldRepo.On("FindByCollectionID", uint(1)).Return(fields, nil)
+
+	_, err := s.EditWithValues(dto.ContentWithValues{
+		ContentID:    42,
+		CollectionID: 1,
+		FormData:     map[string][]string{"date": {"garbage"}},
+	})
+
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Contains(t, verrs, "date")
+	contentValueRepo.AssertNotCalled(t, "FindByContentID", mock.Anything)
+}
+
+func TestFindDisplayValueByCollectionID_Sort(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	mockContentRepo := new(mockrepo.MockContentRepo)
+	mockFieldRepo := new(mockrepo.MockFieldRepo)
+	repos := &repository.Set{Content: mockContentRepo, Field: mockFieldRepo}
+	s := service.NewContentService(repos, testDB)
+
+	mockFieldRepo.On("FindByCollectionID", uint(1)).Return([]model.Field{{Alias: "title"}}, nil)
+	mockContentRepo.On("FindDisplayValueByCollectionID", uint(1), 1, 10).Return([]model.Content{{}}, int64(1), nil)
+
+	_, _, err := s.FindDisplayValueByCollectionID(1, 1, 10, "-title,created_at", "")
+	assert.NoError(t, err)
+
+	_, _, err = s.FindDisplayValueByCollectionID(1, 1, 10, "missing", "")
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+	mockContentRepo.AssertNumberOfCalls(t, "FindDisplayValueByCollectionID", 1)
+}
+
+func TestFindDisplayValueByCollectionID_Search(t *testing.T) {
+	testDB := testutils.SetupTestDB(t)
+	mockContentRepo := new(mockrepo.MockContentRepo)
+	mockSearchRepo := new(mockrepo.MockSearchRepo)
+	repos := &repository.Set{Content: mockContentRepo, Search: mockSearchRepo}
+	s := service.NewContentService(repos, testDB)
+
+	mockSearchRepo.On("Matching", "bread").Return(base.QueryOption(func(db *gorm.DB) *gorm.DB { return db }))
+	mockContentRepo.On("FindDisplayValueByCollectionID", uint(1), 1, 10).Return([]model.Content{{}}, int64(1), nil)
+
+	_, _, err := s.FindDisplayValueByCollectionID(1, 1, 10, "", "bread")
+	assert.NoError(t, err)
+	mockSearchRepo.AssertExpectations(t)
+}
+
+func TestContentService_UniqueFields(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+
+	products := &model.Collection{Name: "Products", Alias: "products"}
+	assert.NoError(t, repos.Collection.Create(products))
+	sku := &model.Field{Name: "SKU", Alias: "sku", FieldType: model.FieldTypeText, CollectionID: products.ID, IsUnique: true}
+	slug := &model.Field{Name: "Slug", Alias: "slug", FieldType: model.FieldTypeText, CollectionID: products.ID, IsUnique: true, Localized: true}
+	assert.NoError(t, repos.Field.Create(sku))
+	assert.NoError(t, repos.Field.Create(slug))
+
+	create := func(formData map[string][]string, translations map[string]map[string][]string) (*model.Content, error) {
+		return s.CreateWithValues(dto.ContentWithValues{CollectionID: products.ID, FormData: formData, Translations: translations})
+	}
+
+	first, err := create(map[string][]string{"sku": {"A-1"}, "slug": {"chair"}}, map[string]map[string][]string{"de": {"slug": {"stuhl"}}})
+	assert.NoError(t, err)
+
+	_, err = create(map[string][]string{"sku": {"A-1"}, "slug": {"table"}}, nil)
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Contains(t, verrs, "sku")
+	assert.NotContains(t, verrs, "slug")
+
+	_, err = create(map[string][]string{"sku": {"A-2"}, "slug": {"stuhl"}}, map[string]map[string][]string{"de": {"slug": {"stuhl"}}})
+	verrs, ok = service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"slug@de"}, keys(verrs), "values only clash within the same locale")
+
+	_, err = create(map[string][]string{"sku": {""}}, nil)
+	assert.NoError(t, err)
+	_, err = create(map[string][]string{"sku": {""}}, nil)
+	assert.NoError(t, err, "empty values are not unique")
+
+	_, err = s.EditWithValues(dto.ContentWithValues{CollectionID: products.ID, ContentID: first.ID, FormData: map[string][]string{"sku": {"A-1"}, "slug": {"chair"}}})
+	assert.NoError(t, err, "an entry keeps its own values")
+
+	other := &model.Collection{Name: "Other", Alias: "other"}
+	assert.NoError(t, repos.Collection.Create(other))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "SKU", Alias: "sku", FieldType: model.FieldTypeText, CollectionID: other.ID, IsUnique: true}))
+	_, err = s.CreateWithValues(dto.ContentWithValues{CollectionID: other.ID, FormData: map[string][]string{"sku": {"A-1"}}})
+	assert.NoError(t, err, "uniqueness is scoped per collection")
+}
+
+func keys(verrs service.ValidationErrors) []string {
+	var ks []string
+	for k := range verrs {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func TestContentService_SelectOptions(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+	api := service.NewApiService(repos)
+
+	col := &model.Collection{Name: "Shirts", Alias: "shirts"}
+	assert.NoError(t, repos.Collection.Create(col))
+	size := &model.Field{Name: "Size", Alias: "size", FieldType: model.FieldTypeSelect, CollectionID: col.ID}
+	colors := &model.Field{Name: "Colors", Alias: "colors", FieldType: model.FieldTypeMultiSelect, CollectionID: col.ID}
+	assert.NoError(t, repos.Field.Create(size))
+	assert.NoError(t, repos.Field.Create(colors))
+	for _, o := range []model.FieldOption{
+		{FieldID: size.ID, Value: "S"}, {FieldID: size.ID, Value: "M"},
+		{FieldID: colors.ID, Value: "red"}, {FieldID: colors.ID, Value: "blue"},
+	} {
+		assert.NoError(t, repos.FieldOption.Create(&o))
+	}
+
+	_, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: col.ID, FormData: map[string][]string{"size": {"XL"}, "colors": {"red", "green"}}})
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"must be one of the options: S, M"}, verrs["size"])
+	assert.Equal(t, []string{"must be one of the options: red, blue"}, verrs["colors"])
+
+	shirt, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: col.ID, FormData: map[string][]string{"size": {"M"}, "colors": {"blue", "red"}}})
+	assert.NoError(t, err)
+
+	item, err := api.FindContentByID(shirt.ID, dto.ApiQuery{Preview: true, Version: dto.ApiVersionTyped})
+	assert.NoError(t, err)
+	assert.Equal(t, "M", item.Values["size"].(dto.ContentValueResponse).Value)
+	assert.Equal(t, []any{"blue", "red"}, item.Values["colors"].(dto.ContentValueResponse).Value)
+}
+
+func TestContentService_DerivesSlugs(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+
+	col := &model.Collection{Name: "Posts", Alias: "posts"}
+	assert.NoError(t, repos.Collection.Create(col))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID, Localized: true}))
+	slug := &model.Field{Name: "Slug", Alias: "slug", FieldType: model.FieldTypeSlug, CollectionID: col.ID, Localized: true, IsRequired: true, SlugSource: "title"}
+	assert.NoError(t, repos.Field.Create(slug))
+
+	slugOf := func(id uint, locale string) string {
+		values, err := repos.ContentValue.FindByContentID(id)
+		assert.NoError(t, err)
+		for _, v := range values {
+			if v.Locale == locale && v.FieldID == slug.ID {
+				return v.Value
+			}
+		}
+		return ""
+	}
+
+	first, err := s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: col.ID,
+		FormData:     map[string][]string{"title": {"Grüße aus Köln"}},
+		Translations: map[string]map[string][]string{"de": {"title": {"Hallo Köln"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "gruesse-aus-koeln", slugOf(first.ID, ""))
+	assert.Equal(t, "hallo-koeln", slugOf(first.ID, "de"))
+
+	second, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: col.ID, FormData: map[string][]string{"title": {"Grüße aus Köln!"}, "slug": {""}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "gruesse-aus-koeln-2", slugOf(second.ID, ""))
+
+	_, err = s.EditWithValues(dto.ContentWithValues{CollectionID: col.ID, ContentID: first.ID, FormData: map[string][]string{"title": {"Renamed"}, "slug": {"gruesse-aus-koeln"}}})
+	assert.NoError(t, err, "an existing slug is kept when the source changes")
+	assert.Equal(t, "gruesse-aus-koeln", slugOf(first.ID, ""))
+
+	_, err = s.CreateWithValues(dto.ContentWithValues{CollectionID: col.ID, FormData: map[string][]string{"title": {"Other"}, "slug": {"gruesse-aus-koeln"}}})
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Contains(t, verrs, "slug", "slugs are always unique")
+
+	_, err = s.CreateWithValues(dto.ContentWithValues{CollectionID: col.ID, FormData: map[string][]string{"title": {"!!!"}}})
+	verrs, ok = service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"is required"}, verrs["slug"])
+}
+
+func TestContentService_Blocks(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+
+	page := &model.Collection{Name: "Page", Alias: "page"}
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	assert.NoError(t, repos.Collection.Create(page))
+	assert.NoError(t, repos.Collection.Create(hero))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: hero.ID, IsRequired: true}))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Body", Alias: "body", FieldType: model.FieldTypeBlocks, CollectionID: page.ID, IsList: true}))
+
+	content, err := s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: page.ID,
+		FormData: map[string][]string{"body": {
+			`{"component":"hero","values":{"title":["Welcome"],"stray":["x"]}}`,
+			`{"component":"hero","values":{"title":["Second"]}}`,
+		}},
+	})
+	assert.NoError(t, err)
+
+	values, err := repos.ContentValue.FindByContentID(content.ID)
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+	assert.Equal(t, `{"component":"hero","values":{"title":["Welcome"]}}`, values[0].Value, "unknown block values are dropped")
+	assert.Equal(t, 2, values[1].SortIndex)
+
+	_, err = s.CreateWithValues(dto.ContentWithValues{
+		CollectionID: page.ID,
+		FormData: map[string][]string{"body": {
+			`{"component":"hero","values":{}}`,
+			`{"component":"gallery","values":{}}`,
+		}},
+	})
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{
+		"block 1 (Hero), Title: is required",
+		`block 2: unknown component "gallery"`,
+	}, verrs["body"])
+
+	_, err = s.CreateWithValues(dto.ContentWithValues{CollectionID: page.ID, FormData: map[string][]string{"body": {"hero"}}})
+	verrs, ok = service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"must be a block"}, verrs["body"])
+}
+
+func TestContentService_TargetCollections(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+
+	post := &model.Collection{Name: "Post", Alias: "post"}
+	author := &model.Collection{Name: "Author", Alias: "author"}
+	assert.NoError(t, repos.Collection.Create(post))
+	assert.NoError(t, repos.Collection.Create(author))
+	assert.NoError(t, repos.Field.Create(&model.Field{Name: "Author", Alias: "author", FieldType: model.FieldTypeCollection, CollectionID: post.ID, TargetCollections: fmt.Sprint(author.ID)}))
+
+	jane := &model.Content{CollectionID: author.ID}
+	other := &model.Content{CollectionID: post.ID}
+	assert.NoError(t, repos.Content.Create(jane))
+	assert.NoError(t, repos.Content.Create(other))
+
+	created, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: post.ID, FormData: map[string][]string{"author": {fmt.Sprint(jane.ID)}}})
+	assert.NoError(t, err)
+
+	_, err = s.EditWithValues(dto.ContentWithValues{ContentID: created.ID, CollectionID: post.ID, FormData: map[string][]string{"author": {fmt.Sprint(other.ID)}}})
+	verrs, ok := service.AsValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{fmt.Sprintf("entry %q is not in the allowed collections", fmt.Sprint(other.ID))}, verrs["author"])
+
+	_, err = s.CreateWithValues(dto.ContentWithValues{CollectionID: post.ID, FormData: map[string][]string{"author": {"999"}}})
+	_, ok = service.AsValidationErrors(err)
+	assert.True(t, ok, "missing entries are rejected")
+}
+
+func TestContentService_SearchReferences(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewContentService(repos, db)
+
+	author := &model.Collection{Name: "Author", Alias: "author"}
+	page := &model.Collection{Name: "Page", Alias: "page"}
+	assert.NoError(t, repos.Collection.Create(author))
+	assert.NoError(t, repos.Collection.Create(page))
+	name := &model.Field{Name: "Name", Alias: "name", FieldType: model.FieldTypeText, CollectionID: author.ID, DisplayField: true}
+	bio := &model.Field{Name: "Bio", Alias: "bio", FieldType: model.FieldTypeText, CollectionID: author.ID}
+	title := &model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: page.ID, DisplayField: true}
+	assert.NoError(t, repos.Field.Create(name))
+	assert.NoError(t, repos.Field.Create(bio))
+	assert.NoError(t, repos.Field.Create(title))
+
+	create := func(collectionID uint, values map[string][]string) *model.Content {
+		c, err := s.CreateWithValues(dto.ContentWithValues{CollectionID: collectionID, FormData: values})
+		assert.NoError(t, err)
+		return c
+	}
+	jane := create(author.ID, map[string][]string{"name": {"Jane Doe"}, "bio": {"Writes about Berlin"}})
+	john := create(author.ID, map[string][]string{"name": {"John Roe"}})
+	about := create(page.ID, map[string][]string{"title": {"About Jane"}})
+
+	contents, total, err := s.SearchReferences("jane", nil, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, contents, 2)
+
+	contents, total, err = s.SearchReferences("jane", []uint{author.ID}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, contents, 1) {
+		assert.Equal(t, jane.ID, contents[0].ID)
+		assert.Equal(t, "Author", contents[0].Collection.Name)
+		assert.Len(t, contents[0].ContentValues, 1, "only display values are loaded")
+	}
+
+	contents, _, err = s.SearchReferences("berlin", nil, 1, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, contents, "only display fields are searched")
+
+	contents, _, err = s.SearchReferences(fmt.Sprint(about.ID), nil, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, contents, 1, "entries are found by ID")
+
+	contents, total, err = s.SearchReferences("", []uint{author.ID}, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, contents, 1)
+
+	contents, err = s.FindReferences([]uint{john.ID, about.ID})
+	assert.NoError(t, err)
+	assert.Len(t, contents, 2)
 }
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+// localeForms holds the values of an entry keyed by locale and field alias,
+// the default locale is "".
+type localeForms map[string]map[string][]string
+
+func newLocaleForms(formData map[string][]string, translations map[string]map[string][]string) localeForms {
+	forms := localeForms{"": formData}
+	for locale, data := range translations {
+		if locale != "" {
+			forms[locale] = data
+		}
+	}
+	return forms
+}
+
+func (f localeForms) locales() []string {
+	locales := make([]string, 0, len(f))
+	for locale := range f {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func (f localeForms) translations() map[string]map[string][]string {
+	translations := make(map[string]map[string][]string)
+	for locale, data := range f {
+		if locale != "" {
+			translations[locale] = data
+		}
+	}
+	return translations
+}
+
+func localizedFields(fields []model.Field) []model.Field {
+	localized := make([]model.Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Localized {
+			localized = append(localized, f)
+		}
+	}
+	return localized
+}
+
+// fieldsFor returns the fields stored for a locale, translations only carry
+// localized fields.
+func fieldsFor(fields []model.Field, locale string) []model.Field {
+	if locale == "" {
+		return fields
+	}
+	return localizedFields(fields)
+}
+
+func formsFromValues(fields []model.Field, values []model.ContentValue) localeForms {
+	aliases := make(map[uint]string, len(fields))
+	for _, f := range fields {
+		aliases[f.ID] = f.Alias
+	}
+
+	values = append([]model.ContentValue(nil), values...)
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].SortIndex < values[j].SortIndex
+	})
+
+	forms := localeForms{"": map[string][]string{}}
+	for _, v := range values {
+		alias, ok := aliases[v.FieldID]
+		if !ok {
+			continue
+		}
+
+		if forms[v.Locale] == nil {
+			forms[v.Locale] = map[string][]string{}
+		}
+		forms[v.Locale][alias] = append(forms[v.Locale][alias], v.Value)
+	}
+
+	return forms
+}
+
+// cleanTranslations drops empty translated values and values of fields that
+// are not localized, a missing translation falls back to the default locale.
+func cleanTranslations(fields []model.Field, translations map[string]map[string][]string) map[string]map[string][]string {
+	cleaned := make(map[string]map[string][]string)
+	for locale, data := range translations {
+		if locale == "" {
+			continue
+		}
+
+		for _, f := range fieldsFor(fields, locale) {
+			for _, v := range data[f.Alias] {
+				if strings.TrimSpace(v) == "" {
+					continue
+				}
+
+				if cleaned[locale] == nil {
+					cleaned[locale] = map[string][]string{}
+				}
+				cleaned[locale][f.Alias] = append(cleaned[locale][f.Alias], v)
+			}
+		}
+	}
+
+	return cleaned
+}
+
+func validateTranslations(fields []model.Field, translations map[string]map[string][]string) ValidationErrors {
+	verrs := make(ValidationErrors)
+	for locale, data := range translations {
+		for _, f := range fieldsFor(fields, locale) {
+			values, ok := data[f.Alias]
+			if !ok {
+				continue
+			}
+
+			f.IsRequired = false
+			if errs := f.Validate(values); len(errs) > 0 {
+				verrs[TranslationKey(f.Alias, locale)] = errs
+			}
+		}
+	}
+
+	return verrs
+}
+
+func validateContent(fields []model.Field, formData map[string][]string, translations map[string]map[string][]string) error {
+	verrs := validateTranslations(fields, translations)
+	if err := validateFormData(fields, formData); err != nil {
+		for key, errs := range err.(ValidationErrors) {
+			verrs[key] = errs
+		}
+	}
+
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	return nil
+}
+
+// translated reports whether a localized field has values for the locale or
+// one of its parents, "de-AT" is covered by "de" even when "de" is the
+// default locale.
+func translated(forms localeForms, alias, locale, defaultLocale string) bool {
+	for _, parent := range parentLocales(locale) {
+		if strings.EqualFold(parent, defaultLocale) || len(forms[parent][alias]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingTranslations lists per entry and locale the localized fields that
+// have a default value but no translation to fall back to.
+func (s *contentService) MissingTranslations(collectionID uint, defaultLocale string, locales []string) ([]dto.MissingTranslation, error) {
+	missing := make([]dto.MissingTranslation, 0)
+
+	fields, err := s.repos.Field.FindByCollectionID(collectionID)
+	if err != nil {
+		return missing, err
+	}
+
+	localized := localizedFields(fields)
+	if len(localized) == 0 || len(locales) == 0 {
+		return missing, nil
+	}
+
+	contents, err := s.repos.Content.FindByCollectionID(collectionID, 0, 0, repository.OrderBy())
+	if err != nil {
+		return missing, err
+	}
+
+	for _, content := range contents {
+		forms := formsFromValues(fields, content.ContentValues)
+
+		var labels []string
+		for _, f := range fields {
+			if f.DisplayField {
+				labels = append(labels, forms[""][f.Alias]...)
+			}
+		}
+
+		for _, locale := range locales {
+			var names []string
+			for _, f := range localized {
+				if len(forms[""][f.Alias]) > 0 && !translated(forms, f.Alias, locale, defaultLocale) {
+					names = append(names, f.Name)
+				}
+			}
+
+			if len(names) > 0 {
+				missing = append(missing, dto.MissingTranslation{
+					ContentID: content.ID,
+					Label:     strings.Join(labels, ", "),
+					Locale:    locale,
+					Fields:    names,
+				})
+			}
+		}
+	}
+
+	return missing, nil
+}
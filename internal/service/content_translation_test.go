@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var translatedFields = []model.Field{
+	{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, Localized: true, DisplayField: true, IsRequired: true},
+	{Name: "Teaser", Alias: "teaser", FieldType: model.FieldTypeText, Localized: true},
+	{Name: "Price", Alias: "price", FieldType: model.FieldTypeNumber},
+}
+
+func testLocales(t *testing.T) service.Locales {
+	locales, err := service.NewLocales([]string{"en", "de", "de-AT", "fr"})
+	require.NoError(t, err)
+	return locales
+}
+
+func readLocalized(t *testing.T, repos *repository.Set, id uint, locale string) map[string]any {
+	chain, err := testLocales(t).Chain(locale)
+	require.NoError(t, err)
+
+	item, err := service.NewApiService(repos).FindContentByID(id, dto.ApiQuery{Preview: true, Locales: chain})
+	require.NoError(t, err)
+
+	values := make(map[string]any, len(item.Values))
+	for alias, v := range item.Values {
+		values[alias] = v.(dto.ContentValueResponse).Value
+	}
+	return values
+}
+
+func TestTranslations_FallbackChain(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, translatedFields...)
+
+	post, err := contents.CreateWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		FormData:     map[string][]string{"title": {"Hello"}, "teaser": {"Read on"}, "price": {"5"}},
+		Translations: map[string]map[string][]string{
+			"de":    {"title": {"Hallo"}, "teaser": {"Lies weiter"}, "price": {"7"}},
+			"de-AT": {"title": {"Servus"}, "teaser": {"  "}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"title": "Hello", "teaser": "Read on", "price": "5"}, readLocalized(t, repos, post.ID, ""))
+	assert.Equal(t, map[string]any{"title": "Hallo", "teaser": "Lies weiter", "price": "5"}, readLocalized(t, repos, post.ID, "de"))
+	assert.Equal(t, map[string]any{"title": "Servus", "teaser": "Lies weiter", "price": "5"}, readLocalized(t, repos, post.ID, "de-AT"))
+	assert.Equal(t, map[string]any{"title": "Hello", "teaser": "Read on", "price": "5"}, readLocalized(t, repos, post.ID, "fr"))
+
+	matched, err := repos.Content.FindByCollectionID(blog.ID, 0, 0, repository.FieldValueEquals("title", "Hallo"))
+	require.NoError(t, err)
+	assert.Empty(t, matched, "filters only match the default locale")
+}
+
+func TestTranslations_EditKeepsOrReplaces(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, translatedFields...)
+
+	post, err := contents.CreateWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		FormData:     map[string][]string{"title": {"Hello"}},
+		Translations: map[string]map[string][]string{"de": {"title": {"Hallo"}}},
+	})
+	require.NoError(t, err)
+
+	_, err = contents.EditWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		ContentID:    post.ID,
+		FormData:     map[string][]string{"title": {"Hello again"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", readLocalized(t, repos, post.ID, "de")["title"])
+
+	_, err = contents.EditWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		ContentID:    post.ID,
+		FormData:     map[string][]string{"title": {"Hello again"}},
+		Translations: map[string]map[string][]string{"fr": {"title": {"Bonjour"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello again", readLocalized(t, repos, post.ID, "de")["title"])
+	assert.Equal(t, "Bonjour", readLocalized(t, repos, post.ID, "fr")["title"])
+
+	revisions, err := repos.Revision.FindByContentID(post.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, revisions)
+
+	_, err = contents.RestoreRevision(post.ID, revisions[len(revisions)-1].ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", readLocalized(t, repos, post.ID, "de")["title"])
+	assert.Equal(t, "Hello", readLocalized(t, repos, post.ID, "fr")["title"])
+}
+
+func TestTranslations_Validation(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	contents := service.NewContentService(repository.NewSet(db), db)
+	maxLength := 5
+	fields := slices.Clone(translatedFields)
+	fields[1].MaxLength = &maxLength
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, fields...)
+
+	_, err := contents.CreateWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		FormData:     map[string][]string{"title": {"Hello"}},
+		Translations: map[string]map[string][]string{"de": {"teaser": {"Viel zu lang"}}},
+	})
+
+	verrs, ok := service.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Contains(t, verrs, "teaser@de")
+	assert.NotContains(t, verrs, "title@de", "translations are never required")
+}
+
+func TestTranslations_MissingTranslations(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, translatedFields...)
+
+	_, err := contents.CreateWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		FormData:     map[string][]string{"title": {"Complete"}, "teaser": {"Yes"}},
+		Translations: map[string]map[string][]string{
+			"de": {"title": {"Fertig"}, "teaser": {"Ja"}},
+			"fr": {"title": {"Fini"}, "teaser": {"Oui"}},
+		},
+	})
+	require.NoError(t, err)
+
+	partial, err := contents.CreateWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		FormData:     map[string][]string{"title": {"Partial"}},
+		Translations: map[string]map[string][]string{"de": {"title": {"Teilweise"}}},
+	})
+	require.NoError(t, err)
+
+	locales := testLocales(t)
+	missing, err := contents.MissingTranslations(blog.ID, locales.Default(), locales.Translations())
+	require.NoError(t, err)
+	assert.Equal(t, []dto.MissingTranslation{
+		{ContentID: partial.ID, Label: "Partial", Locale: "fr", Fields: []string{"Title"}},
+	}, missing)
+}
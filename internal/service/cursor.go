@@ -0,0 +1,35 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const cursorPrefix = "after:"
+
+func EncodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatUint(uint64(id), 10)))
+}
+
+func DecodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	value, ok := strings.CutPrefix(string(raw), cursorPrefix)
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return uint(id), nil
+}
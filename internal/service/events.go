@@ -0,0 +1,141 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+const (
+	defaultEventBufferSize = 256
+	eventSubscriberQueue   = 64
+)
+
+type eventSubscriber struct {
+	events      chan dto.ContentEvent
+	collections map[string]bool
+}
+
+func (s *eventSubscriber) wants(alias string) bool {
+	return len(s.collections) == 0 || s.collections[alias]
+}
+
+type EventService interface {
+	Publish(eventType string, contentID, collectionID uint)
+	Subscribe(collections []string, lastEventID uint64) (<-chan dto.ContentEvent, []dto.ContentEvent, bool)
+	Unsubscribe(events <-chan dto.ContentEvent)
+}
+
+type eventService struct {
+	repos       *repository.Set
+	size        int
+	mu          sync.Mutex
+	last        uint64
+	buffer      []dto.ContentEvent
+	subscribers map[<-chan dto.ContentEvent]*eventSubscriber
+}
+
+func NewEventService(repos *repository.Set, size int) EventService {
+	if size <= 0 {
+		size = defaultEventBufferSize
+	}
+
+	// ids start at the boot time so a Last-Event-ID from a previous process
+	// never matches the buffer of this one
+	return &eventService{
+		repos:       repos,
+		size:        size,
+		last:        uint64(time.Now().UnixMilli()),
+		subscribers: make(map[<-chan dto.ContentEvent]*eventSubscriber),
+	}
+}
+
+func (s *eventService) Publish(eventType string, contentID, collectionID uint) {
+	var alias string
+	if collection, err := s.repos.Collection.FindByID(collectionID); err == nil {
+		alias = collection.Alias
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last++
+	event := dto.ContentEvent{
+		ID:         s.last,
+		Type:       eventType,
+		ContentID:  contentID,
+		Collection: alias,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	s.buffer = append(s.buffer, event)
+	if len(s.buffer) > s.size {
+		s.buffer = s.buffer[len(s.buffer)-s.size:]
+	}
+
+	for key, sub := range s.subscribers {
+		if !sub.wants(alias) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// the subscriber fell behind, it reconnects with its Last-Event-ID
+			// and catches up from the buffer
+			delete(s.subscribers, key)
+			close(sub.events)
+		}
+	}
+}
+
+func (s *eventService) Subscribe(collections []string, lastEventID uint64) (<-chan dto.ContentEvent, []dto.ContentEvent, bool) {
+	sub := &eventSubscriber{
+		events:      make(chan dto.ContentEvent, eventSubscriberQueue),
+		collections: make(map[string]bool, len(collections)),
+	}
+	for _, alias := range collections {
+		sub.collections[alias] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscribers[sub.events] = sub
+
+	if lastEventID == 0 {
+		return sub.events, nil, true
+	}
+
+	first := s.last + 1 - uint64(len(s.buffer))
+	if lastEventID+1 < first || lastEventID > s.last {
+		return sub.events, nil, false
+	}
+
+	var missed []dto.ContentEvent
+	for _, event := range s.buffer {
+		if event.ID > lastEventID && sub.wants(event.Collection) {
+			missed = append(missed, event)
+		}
+	}
+
+	return sub.events, missed, true
+}
+
+func (s *eventService) Unsubscribe(events <-chan dto.ContentEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sub, ok := s.subscribers[events]; ok {
+		delete(s.subscribers, events)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEvents(t *testing.T, size int) (service.EventService, *model.Collection, *model.Collection) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+
+	blog := &model.Collection{Name: "Blog", Alias: "blog"}
+	news := &model.Collection{Name: "News", Alias: "news"}
+	assert.NoError(t, repos.Collection.Create(blog))
+	assert.NoError(t, repos.Collection.Create(news))
+
+	return service.NewEventService(repos, size), blog, news
+}
+
+func receive(t *testing.T, events <-chan dto.ContentEvent) dto.ContentEvent {
+	select {
+	case event := <-events:
+		return event
+	default:
+		t.Fatal("expected an event")
+		return dto.ContentEvent{}
+	}
+}
+
+func TestEventService_PublishFiltersByCollection(t *testing.T) {
+	s, blog, news := setupEvents(t, 0)
+
+	all, _, ok := s.Subscribe(nil, 0)
+	assert.True(t, ok)
+	onlyNews, _, _ := s.Subscribe([]string{"news"}, 0)
+
+	s.Publish(service.EventCreated, 1, blog.ID)
+	s.Publish(service.EventDeleted, 2, news.ID)
+
+	first := receive(t, all)
+	assert.Equal(t, service.EventCreated, first.Type)
+	assert.Equal(t, uint(1), first.ContentID)
+	assert.Equal(t, "blog", first.Collection)
+
+	second := receive(t, all)
+	assert.Equal(t, first.ID+1, second.ID)
+
+	event := receive(t, onlyNews)
+	assert.Equal(t, service.EventDeleted, event.Type)
+	assert.Equal(t, "news", event.Collection)
+	assert.Len(t, onlyNews, 0)
+
+	s.Unsubscribe(all)
+	_, open := <-all
+	assert.False(t, open)
+}
+
+func TestEventService_ResumeFromBuffer(t *testing.T) {
+	s, blog, news := setupEvents(t, 3)
+
+	events, _, _ := s.Subscribe(nil, 0)
+	for i := uint(1); i <= 4; i++ {
+		s.Publish(service.EventUpdated, i, blog.ID)
+	}
+	s.Publish(service.EventUpdated, 5, news.ID)
+
+	var ids []uint64
+	for range 5 {
+		ids = append(ids, receive(t, events).ID)
+	}
+
+	_, missed, ok := s.Subscribe([]string{"blog"}, ids[1])
+	assert.True(t, ok)
+	if assert.Len(t, missed, 2) {
+		assert.Equal(t, uint(3), missed[0].ContentID)
+		assert.Equal(t, uint(4), missed[1].ContentID)
+	}
+
+	_, missed, ok = s.Subscribe(nil, ids[4])
+	assert.True(t, ok)
+	assert.Empty(t, missed)
+
+	_, _, ok = s.Subscribe(nil, ids[0])
+	assert.False(t, ok, "event fell out of the buffer")
+
+	_, _, ok = s.Subscribe(nil, ids[4]+10)
+	assert.False(t, ok, "event from another process")
+}
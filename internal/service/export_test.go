@@ -0,0 +1,13 @@
+package service
+
+import (
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+// NewSyncServiceWithLag lets the sync tests close windows without waiting
+// for the default lag.
+func NewSyncServiceWithLag(repos *repository.Set, lag time.Duration) SyncService {
+	return &syncService{repos: repos, now: time.Now, lag: lag}
+}
@@ -2,6 +2,11 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/model"
@@ -70,6 +75,12 @@ func (s *fieldService) UpdateByID(id uint, data dto.FieldData) (*model.Field, er
 		return nil, errors.New("no alias given")
 	}
 
+	if strings.Contains(data.Alias, translationSeparator) {
+		return nil, errors.New("alias must not contain " + translationSeparator)
+	}
+
+	wasUnique := field.Unique()
+
 	field.Name = data.Name
 	field.Alias = data.Alias
 	field.CollectionID = collectionID
@@ -77,6 +88,36 @@ func (s *fieldService) UpdateByID(id uint, data dto.FieldData) (*model.Field, er
 	field.IsList = data.IsList == "on"
 	field.IsRequired = data.IsRequired == "on"
 	field.DisplayField = data.DisplayField == "on"
+	field.Localized = data.Localized == "on"
+	field.IsUnique = data.IsUnique == "on"
+
+	// the flag and the Slug type both make a field unique, either one may
+	// turn it on for values that were stored without the check
+	if field.Unique() && !wasUnique {
+		duplicates, err := s.repos.ContentValue.HasDuplicateValues(field.ID)
+		if err != nil {
+			return nil, err
+		}
+		if duplicates {
+			return nil, errors.New("cannot make field unique, existing entries share values")
+		}
+	}
+
+	if err := applyValidationRules(field, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSlugSource(field); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyTargetCollections(field, data.TargetCollections); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkComponent(field); err != nil {
+		return nil, err
+	}
 
 	err = s.repos.Field.Save(field)
 	return field, err
@@ -96,6 +137,10 @@ func (s *fieldService) Create(data dto.FieldData) (*model.Field, error) {
 		return nil, errors.New("no alias given")
 	}
 
+	if strings.Contains(data.Alias, translationSeparator) {
+		return nil, errors.New("alias must not contain " + translationSeparator)
+	}
+
 	field := model.Field{
 		Name:         data.Name,
 		Alias:        data.Alias,
@@ -104,6 +149,24 @@ func (s *fieldService) Create(data dto.FieldData) (*model.Field, error) {
 		IsList:       data.IsList == "on",
 		IsRequired:   data.IsRequired == "on",
 		DisplayField: data.DisplayField == "on",
+		Localized:    data.Localized == "on",
+		IsUnique:     data.IsUnique == "on",
+	}
+
+	if err := applyValidationRules(&field, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSlugSource(&field); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyTargetCollections(&field, data.TargetCollections); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkComponent(&field); err != nil {
+		return nil, err
 	}
 
 	err := s.repos.Field.Create(&field)
@@ -118,3 +181,202 @@ func (s *fieldService) DeleteByID(id uint) error {
 
 	return s.repos.Field.Delete(field)
 }
+
+func parseOptionalFloat(name, value string) (*float64, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", name)
+	}
+
+	return &f, nil
+}
+
+func parseOptionalInt(name, value string) (*int, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return nil, fmt.Errorf("%s must be a positive whole number", name)
+	}
+
+	return &i, nil
+}
+
+func parseOptionalDate(name, value string) (string, error) {
+	if value = strings.TrimSpace(value); value == "" {
+		return "", nil
+	}
+
+	d, err := model.ParseDate(value)
+	if err != nil {
+		return "", fmt.Errorf("%s must be a date (YYYY-MM-DD)", name)
+	}
+
+	return d.Format(model.DateLayout), nil
+}
+
+func applyValidationRules(field *model.Field, data dto.FieldData) error {
+	var err error
+
+	if field.MinValue, err = parseOptionalFloat("min value", data.MinValue); err != nil {
+		return err
+	}
+	if field.MaxValue, err = parseOptionalFloat("max value", data.MaxValue); err != nil {
+		return err
+	}
+	if field.MinValue != nil && field.MaxValue != nil && *field.MinValue > *field.MaxValue {
+		return errors.New("min value must not exceed max value")
+	}
+
+	if field.MinLength, err = parseOptionalInt("min length", data.MinLength); err != nil {
+		return err
+	}
+	if field.MaxLength, err = parseOptionalInt("max length", data.MaxLength); err != nil {
+		return err
+	}
+	if field.MinLength != nil && field.MaxLength != nil && *field.MinLength > *field.MaxLength {
+		return errors.New("min length must not exceed max length")
+	}
+
+	if field.MinItems, err = parseOptionalInt("min items", data.MinItems); err != nil {
+		return err
+	}
+	if field.MaxItems, err = parseOptionalInt("max items", data.MaxItems); err != nil {
+		return err
+	}
+	if field.MinItems != nil && field.MaxItems != nil && *field.MinItems > *field.MaxItems {
+		return errors.New("min items must not exceed max items")
+	}
+
+	if field.MinDate, err = parseOptionalDate("min date", data.MinDate); err != nil {
+		return err
+	}
+	if field.MaxDate, err = parseOptionalDate("max date", data.MaxDate); err != nil {
+		return err
+	}
+	if field.MinDate != "" && field.MaxDate != "" && field.MinDate > field.MaxDate {
+		return errors.New("min date must not be after max date")
+	}
+
+	field.Pattern = strings.TrimSpace(data.Pattern)
+	if field.Pattern != "" {
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	field.AllowedValues = strings.Join(model.ParseAllowedValues(data.AllowedValues), "\n")
+
+	field.SlugSource = ""
+	if field.FieldType == model.FieldTypeSlug {
+		field.SlugSource = strings.TrimSpace(data.SlugSource)
+	}
+
+	// A Blocks field always holds a list of blocks.
+	if field.FieldType == model.FieldTypeBlocks {
+		field.IsList = true
+	}
+
+	return nil
+}
+
+// componentFieldError tells why a field cannot be part of a component. Block
+// values are only checked against the field rules, the checks that look at
+// other entries (unique values, slugs, target collections) do not run inside
+// blocks.
+func componentFieldError(field model.Field) error {
+	switch {
+	case field.FieldType == model.FieldTypeBlocks:
+		return errors.New("components cannot contain blocks fields")
+	case field.FieldType == model.FieldTypeSlug:
+		return errors.New("components cannot contain slug fields")
+	case field.IsUnique:
+		return errors.New("component fields cannot be unique")
+	case field.TargetCollections != "":
+		return errors.New("component fields cannot be restricted to target collections")
+	}
+	return nil
+}
+
+func (s *fieldService) checkComponent(field *model.Field) error {
+	if componentFieldError(*field) == nil {
+		return nil
+	}
+
+	collection, err := s.repos.Collection.FindByID(field.CollectionID)
+	if err != nil {
+		return err
+	}
+
+	if collection.IsComponent {
+		return componentFieldError(*field)
+	}
+
+	return nil
+}
+
+// applyTargetCollections restricts a Collection field to the given collections,
+// components cannot be referenced.
+func (s *fieldService) applyTargetCollections(field *model.Field, targets []string) error {
+	field.TargetCollections = ""
+	if field.FieldType != model.FieldTypeCollection {
+		return nil
+	}
+
+	var ids []string
+	for _, target := range targets {
+		if strings.TrimSpace(target) == "" {
+			continue
+		}
+
+		id, ok := utils.StringToUint(strings.TrimSpace(target))
+		if !ok {
+			return errors.New("cannot convert target collection id")
+		}
+
+		collection, err := s.repos.Collection.FindByID(id)
+		if err != nil {
+			return fmt.Errorf("target collection %d not found", id)
+		}
+
+		if collection.IsComponent {
+			return fmt.Errorf("target collection %q is a component", collection.Name)
+		}
+
+		if idStr := strconv.FormatUint(uint64(id), 10); !slices.Contains(ids, idStr) {
+			ids = append(ids, idStr)
+		}
+	}
+
+	field.TargetCollections = strings.Join(ids, "\n")
+	return nil
+}
+
+func (s *fieldService) checkSlugSource(field *model.Field) error {
+	if field.SlugSource == "" {
+		return nil
+	}
+
+	if field.SlugSource == field.Alias {
+		return errors.New("slug source must be another field")
+	}
+
+	fields, err := s.repos.Field.FindByCollectionID(field.CollectionID)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		if f.Alias == field.SlugSource && f.ID != field.ID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("slug source %q is not a field of the collection", field.SlugSource)
+}
@@ -131,6 +131,21 @@ func TestFieldService_Create_Success(t *testing.T) {
 	assert.True(t, field.DisplayField)
 }
 
+func TestFieldService_Create_Localized(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+
+	field, err := s.Create(dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Title", Alias: "title", FieldType: "text", Localized: "on"})
+	assert.NoError(t, err)
+	assert.True(t, field.Localized)
+
+	_, err = s.Create(dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Title", Alias: "title@de", FieldType: "text"})
+	assert.EqualError(t, err, "alias must not contain @")
+}
+
 func TestFieldService_UpdateByID_NotFound(t *testing.T) {
 	db := testutils.SetupTestDB(t)
 	repos := repository.NewSet(db)
@@ -218,3 +233,241 @@ func TestFieldService_DeleteByID(t *testing.T) {
 	_, err = repos.Field.FindByID(f.ID)
 	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 }
+
+func TestFieldService_Create_ValidationRules(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+
+	field, err := s.Create(dto.FieldData{
+		CollectionID:  fmt.Sprint(col.ID),
+		Name:          "Price",
+		Alias:         "price",
+		FieldType:     string(model.FieldTypeNumber),
+		MinValue:      "1.5",
+		MaxValue:      "10",
+		MinItems:      "1",
+		MinDate:       "2024-01-01",
+		Pattern:       "^[0-9.]+$",
+		AllowedValues: "1.5\r\n 2 \r\n\r\n10",
+	})
+	assert.NoError(t, err)
+
+	found, err := repos.Field.FindByID(field.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, *found.MinValue)
+	assert.Equal(t, 10.0, *found.MaxValue)
+	assert.Equal(t, 1, *found.MinItems)
+	assert.Nil(t, found.MaxItems)
+	assert.Nil(t, found.MinLength)
+	assert.Equal(t, "2024-01-01", found.MinDate)
+	assert.Equal(t, "^[0-9.]+$", found.Pattern)
+	assert.Equal(t, "1.5\n2\n10", found.AllowedValues)
+}
+
+func TestFieldService_Create_InvalidValidationRules(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+
+	base := dto.FieldData{CollectionID: "1", Name: "Name", Alias: "alias", FieldType: string(model.FieldTypeText)}
+	cases := map[string]func(d *dto.FieldData){
+		"min value must be a number":                 func(d *dto.FieldData) { d.MinValue = "abc" },
+		"min length must be a positive whole number": func(d *dto.FieldData) { d.MinLength = "-1" },
+		"min length must not exceed max length":      func(d *dto.FieldData) { d.MinLength = "5"; d.MaxLength = "2" },
+		"max date must be a date (YYYY-MM-DD)":       func(d *dto.FieldData) { d.MaxDate = "soon" },
+		"min items must not exceed max items":        func(d *dto.FieldData) { d.MinItems = "3"; d.MaxItems = "1" },
+	}
+
+	for expected, mutate := range cases {
+		data := base
+		mutate(&data)
+		_, err := s.Create(data)
+		assert.EqualError(t, err, expected)
+	}
+
+	data := base
+	data.Pattern = "("
+	_, err := s.Create(data)
+	assert.ErrorContains(t, err, "invalid pattern")
+}
+
+func TestFieldService_UpdateByID_ClearsValidationRules(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+
+	maxLength := 5
+	field := &model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID, MaxLength: &maxLength, Pattern: "x"}
+	repos.Field.Create(field)
+
+	_, err := s.UpdateByID(field.ID, dto.FieldData{
+		CollectionID: fmt.Sprint(col.ID),
+		Name:         "Title",
+		Alias:        "title",
+		FieldType:    string(model.FieldTypeText),
+	})
+	assert.NoError(t, err)
+
+	found, err := repos.Field.FindByID(field.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, found.MaxLength)
+	assert.Empty(t, found.Pattern)
+}
+
+func TestFieldService_UpdateByID_Unique(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+	field := &model.Field{Name: "Slug", Alias: "slug", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	repos.Field.Create(field)
+
+	for i := 0; i < 2; i++ {
+		c := &model.Content{CollectionID: col.ID}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: field.ID, Value: "same"})
+	}
+
+	data := dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Slug", Alias: "slug", FieldType: "Text", IsUnique: "on"}
+	_, err := s.UpdateByID(field.ID, data)
+	assert.EqualError(t, err, "cannot make field unique, existing entries share values")
+
+	values, _ := repos.ContentValue.FindByContentID(1)
+	repos.ContentValue.Delete(&values[0])
+
+	updated, err := s.UpdateByID(field.ID, data)
+	assert.NoError(t, err)
+	assert.True(t, updated.IsUnique)
+}
+
+func TestFieldService_UpdateByID_SlugTypeIsUnique(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+	field := &model.Field{Name: "Path", Alias: "path", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	repos.Field.Create(field)
+
+	for i := 0; i < 2; i++ {
+		c := &model.Content{CollectionID: col.ID}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: field.ID, Value: "same"})
+	}
+
+	data := dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Path", Alias: "path", FieldType: string(model.FieldTypeSlug)}
+	_, err := s.UpdateByID(field.ID, data)
+	assert.EqualError(t, err, "cannot make field unique, existing entries share values")
+
+	found, _ := repos.Field.FindByID(field.ID)
+	assert.Equal(t, model.FieldTypeText, found.FieldType)
+}
+
+func TestFieldService_Create_SlugSource(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	col := &model.Collection{Name: "TestCollection", Alias: "test"}
+	repos.Collection.Create(col)
+	repos.Field.Create(&model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID})
+
+	data := dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Slug", Alias: "slug", FieldType: "Slug", SlugSource: "headline"}
+	_, err := s.Create(data)
+	assert.EqualError(t, err, `slug source "headline" is not a field of the collection`)
+
+	data.SlugSource = "slug"
+	_, err = s.Create(data)
+	assert.EqualError(t, err, "slug source must be another field")
+
+	data.SlugSource = " title "
+	field, err := s.Create(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "title", field.SlugSource)
+
+	text, err := s.Create(dto.FieldData{CollectionID: fmt.Sprint(col.ID), Name: "Text", Alias: "text", FieldType: "Text", SlugSource: "title"})
+	assert.NoError(t, err)
+	assert.Empty(t, text.SlugSource, "only slug fields have a source")
+}
+
+func TestFieldService_Create_Blocks(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	page := &model.Collection{Name: "Page", Alias: "page"}
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	repos.Collection.Create(page)
+	repos.Collection.Create(hero)
+
+	field, err := s.Create(dto.FieldData{CollectionID: fmt.Sprint(page.ID), Name: "Body", Alias: "body", FieldType: "Blocks"})
+	assert.NoError(t, err)
+	assert.True(t, field.IsList, "blocks are always a list")
+
+	_, err = s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Body", Alias: "body", FieldType: "Blocks"})
+	assert.EqualError(t, err, "components cannot contain blocks fields")
+
+	_, err = s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Title", Alias: "title", FieldType: "Text"})
+	assert.NoError(t, err)
+}
+
+func TestFieldService_Create_ComponentFieldsWithoutEntryChecks(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	page := &model.Collection{Name: "Page", Alias: "page"}
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	repos.Collection.Create(page)
+	repos.Collection.Create(hero)
+
+	_, err := s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Path", Alias: "path", FieldType: "Slug"})
+	assert.EqualError(t, err, "components cannot contain slug fields")
+
+	_, err = s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Code", Alias: "code", FieldType: "Text", IsUnique: "on"})
+	assert.EqualError(t, err, "component fields cannot be unique")
+
+	_, err = s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Link", Alias: "link", FieldType: "Collection", TargetCollections: []string{fmt.Sprint(page.ID)}})
+	assert.EqualError(t, err, "component fields cannot be restricted to target collections")
+
+	link, err := s.Create(dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Link", Alias: "link", FieldType: "Collection"})
+	assert.NoError(t, err)
+
+	_, err = s.UpdateByID(link.ID, dto.FieldData{CollectionID: fmt.Sprint(hero.ID), Name: "Link", Alias: "link", FieldType: "Collection", IsUnique: "on"})
+	assert.EqualError(t, err, "component fields cannot be unique")
+}
+
+func TestFieldService_Create_TargetCollections(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := NewFieldService(repos)
+	page := &model.Collection{Name: "Page", Alias: "page"}
+	author := &model.Collection{Name: "Author", Alias: "author"}
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	repos.Collection.Create(page)
+	repos.Collection.Create(author)
+	repos.Collection.Create(hero)
+
+	data := dto.FieldData{CollectionID: fmt.Sprint(page.ID), Name: "Author", Alias: "author", FieldType: "Collection",
+		TargetCollections: []string{fmt.Sprint(author.ID), fmt.Sprint(page.ID), fmt.Sprint(author.ID), ""}}
+	field, err := s.Create(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{author.ID, page.ID}, field.TargetCollectionIDs())
+
+	data.TargetCollections = []string{fmt.Sprint(hero.ID)}
+	_, err = s.UpdateByID(field.ID, data)
+	assert.EqualError(t, err, `target collection "Hero" is a component`)
+
+	data.TargetCollections = []string{"999"}
+	_, err = s.UpdateByID(field.ID, data)
+	assert.EqualError(t, err, "target collection 999 not found")
+
+	data.FieldType = "Text"
+	data.TargetCollections = []string{fmt.Sprint(author.ID)}
+	field, err = s.UpdateByID(field.ID, data)
+	assert.NoError(t, err)
+	assert.Empty(t, field.TargetCollections, "only Collection fields have targets")
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+type GraphQLService interface {
+	Schema() (*graphql.Schema, error)
+	Execute(req dto.GraphQLRequest, maxPerPage int) *graphql.Result
+}
+
+type graphqlService struct {
+	repos *repository.Set
+	api   ApiService
+
+	mu          sync.Mutex
+	fingerprint string
+	schema      *graphql.Schema
+}
+
+type maxPerPageKey struct{}
+
+type contentResolverKey struct{}
+
+func newGraphQLResolver(repos *repository.Set) *contentResolver {
+	return newContentResolver(repos, dto.ApiQuery{Version: dto.ApiVersionTyped})
+}
+
+func NewGraphQLService(repos *repository.Set, api ApiService) GraphQLService {
+	return &graphqlService{
+		repos: repos,
+		api:   api,
+	}
+}
+
+func (s *graphqlService) Schema() (*graphql.Schema, error) {
+	fingerprint, err := s.repos.Schema.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.schema != nil && s.fingerprint == fingerprint {
+		return s.schema, nil
+	}
+
+	schema, err := s.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	s.schema = schema
+	s.fingerprint = fingerprint
+
+	return schema, nil
+}
+
+func (s *graphqlService) Execute(req dto.GraphQLRequest, maxPerPage int) *graphql.Result {
+	schema, err := s.Schema()
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	ctx := context.WithValue(context.Background(), maxPerPageKey{}, maxPerPage)
+	ctx = context.WithValue(ctx, contentResolverKey{}, newGraphQLResolver(s.repos))
+
+	return graphql.Do(graphql.Params{
+		Schema:         *schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+}
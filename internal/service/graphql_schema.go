@@ -0,0 +1,572 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/utils"
+)
+
+const graphqlDefaultPerPage = 100
+
+var reservedTypeNames = map[string]bool{
+	"Query": true, "Asset": true, "GeoPoint": true, "Block": true, "Content": true, "Collection": true, "ContentFilter": true,
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+var systemFieldNames = map[string]bool{
+	"id": true, "createdAt": true, "updatedAt": true, "status": true,
+}
+
+type schemaBuilder struct {
+	repos       *repository.Set
+	api         ApiService
+	objects     map[string]*graphql.Object
+	content     *graphql.Union
+	asset       *graphql.Object
+	geoPoint    *graphql.Object
+	block       *graphql.Object
+	filterInput *graphql.InputObject
+}
+
+func typeName(alias string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range alias {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "C" + name
+	}
+	return name
+}
+
+func fieldName(alias string) string {
+	var b strings.Builder
+	for _, r := range alias {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+func (s *graphqlService) buildSchema() (*graphql.Schema, error) {
+	collections, _, err := s.repos.Collection.List(1, 100000)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &schemaBuilder{
+		repos:   s.repos,
+		api:     s.api,
+		objects: make(map[string]*graphql.Object),
+	}
+	b.asset = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Asset",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name": &graphql.Field{Type: graphql.String},
+			"path": &graphql.Field{Type: graphql.String},
+		},
+	})
+	b.geoPoint = graphql.NewObject(graphql.ObjectConfig{
+		Name: "GeoPoint",
+		Fields: graphql.Fields{
+			"lat": &graphql.Field{Type: graphql.Float},
+			"lng": &graphql.Field{Type: graphql.Float},
+		},
+	})
+	b.block = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Block",
+		Fields: graphql.Fields{
+			"component": &graphql.Field{Type: graphql.String},
+			"values": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The typed values of the block encoded as JSON.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					block, ok := p.Source.(dto.Block)
+					if !ok {
+						return nil, nil
+					}
+					raw, err := json.Marshal(block.Values)
+					return string(raw), err
+				},
+			},
+		},
+	})
+	b.filterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ContentFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"field":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"op":     &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: "eq"},
+			"value":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"values": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		},
+	})
+	collectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Collection",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":  &graphql.Field{Type: graphql.String},
+			"alias": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	query := graphql.Fields{
+		"collections": &graphql.Field{
+			Type: graphql.NewList(collectionType),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				list := make([]dto.CollectionResponse, 0, len(collections))
+				for _, c := range collections {
+					if c.IsComponent {
+						continue
+					}
+					list = append(list, dto.CollectionResponse{ID: c.ID, Name: c.Name, Alias: c.Alias})
+				}
+				return list, nil
+			},
+		},
+	}
+
+	usedTypes := maps.Clone(reservedTypeNames)
+	taken := func(name, single string) bool {
+		return usedTypes[name] || usedTypes[name+"Page"] || query[single] != nil || query[single+"List"] != nil
+	}
+
+	var objects []*graphql.Object
+	for _, c := range collections {
+		if c.IsComponent {
+			continue
+		}
+
+		fields, err := s.repos.Field.FindByCollectionID(c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// aliases that map to a name already in use get the collection ID
+		// appended, so every collection stays queryable
+		name, single := typeName(c.Alias), fieldName(c.Alias)
+		for taken(name, single) {
+			name = fmt.Sprintf("%s%d", name, c.ID)
+			single = fmt.Sprintf("%s%d", single, c.ID)
+		}
+		usedTypes[name] = true
+		usedTypes[name+"Page"] = true
+
+		obj := b.collectionObject(name, fields)
+		b.objects[c.Alias] = obj
+		objects = append(objects, obj)
+
+		query[single] = b.findField(c.Alias, obj)
+		query[single+"List"] = b.listField(c.Alias, name, obj)
+	}
+
+	if len(objects) > 0 {
+		b.content = graphql.NewUnion(graphql.UnionConfig{
+			Name:  "Content",
+			Types: objects,
+			ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+				item, ok := p.Value.(dto.ContentItemResponse)
+				if !ok {
+					return nil
+				}
+				return b.objects[item.Collection.Alias]
+			},
+		})
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: query}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func (b *schemaBuilder) collectionObject(name string, fields []model.Field) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			result := graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: itemResolver(func(i dto.ContentItemResponse) any { return i.ID })},
+				"createdAt": &graphql.Field{Type: graphql.String, Resolve: itemResolver(func(i dto.ContentItemResponse) any { return i.CreatedAt.Format(time.RFC3339) })},
+				"updatedAt": &graphql.Field{Type: graphql.String, Resolve: itemResolver(func(i dto.ContentItemResponse) any { return i.UpdatedAt.Format(time.RFC3339) })},
+				"status":    &graphql.Field{Type: graphql.String, Resolve: itemResolver(func(i dto.ContentItemResponse) any { return i.Status })},
+			}
+
+			for _, f := range fields {
+				name := fieldName(f.Alias)
+				if systemFieldNames[name] || result[name] != nil {
+					continue
+				}
+				if field := b.valueField(f); field != nil {
+					result[name] = field
+				}
+			}
+
+			return result
+		}),
+	})
+}
+
+func itemResolver(get func(dto.ContentItemResponse) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		item, ok := p.Source.(dto.ContentItemResponse)
+		if !ok {
+			return nil, nil
+		}
+		return get(item), nil
+	}
+}
+
+func valuesOf(p graphql.ResolveParams, alias string) []dto.ContentValueResponse {
+	item, ok := p.Source.(dto.ContentItemResponse)
+	if !ok {
+		return nil
+	}
+	return itemValues(item, alias)
+}
+
+func itemValues(item dto.ContentItemResponse, alias string) []dto.ContentValueResponse {
+	switch v := item.Values[alias].(type) {
+	case dto.ContentValueResponse:
+		return []dto.ContentValueResponse{v}
+	case []any:
+		values := make([]dto.ContentValueResponse, 0, len(v))
+		for _, entry := range v {
+			if cvr, ok := entry.(dto.ContentValueResponse); ok {
+				values = append(values, cvr)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// resolverOf returns the resolver shared by the fields of a request, so every
+// referenced entry is loaded once however often it is asked for.
+func (b *schemaBuilder) resolverOf(p graphql.ResolveParams) *contentResolver {
+	if r, ok := p.Context.Value(contentResolverKey{}).(*contentResolver); ok {
+		return r
+	}
+	return newGraphQLResolver(b.repos)
+}
+
+// item prepares a visible entry and loads the entries it references with one
+// query, as their fields are likely resolved next.
+func (b *schemaBuilder) item(p graphql.ResolveParams, id uint) (dto.ContentItemResponse, bool) {
+	r := b.resolverOf(p)
+	ce := r.content(id)
+	if ce == nil || !r.visible(ce) {
+		return dto.ContentItemResponse{}, false
+	}
+
+	r.load(referencedIDs(ce.ContentValues, pickLocales(ce.ContentValues, r.q.Locales)))
+	return r.prepare(ce, "", 0, nil), true
+}
+
+// relationIDs lists the entries the values of the items point to.
+func relationIDs(items []dto.ContentItemResponse) []uint {
+	var ids []uint
+	for _, item := range items {
+		for alias := range item.Values {
+			for _, cvr := range itemValues(item, alias) {
+				if cvr.FieldType != model.FieldTypeCollection {
+					continue
+				}
+				if id, ok := utils.StringToUint(fmt.Sprint(cvr.Value)); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func (b *schemaBuilder) valueField(f model.Field) *graphql.Field {
+	alias := f.Alias
+
+	var output graphql.Output
+	var resolve func(p graphql.ResolveParams, cvr dto.ContentValueResponse) any
+
+	switch f.FieldType {
+	case model.FieldTypeNumber:
+		output = graphql.Float
+	case model.FieldTypeBoolean:
+		output = graphql.Boolean
+	case model.FieldTypeMultiSelect:
+		return &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				var selected []any
+				for _, cvr := range valuesOf(p, alias) {
+					if list, ok := cvr.Value.([]any); ok {
+						selected = append(selected, list...)
+					} else {
+						selected = append(selected, cvr.Value)
+					}
+				}
+				return selected, nil
+			},
+		}
+	case model.FieldTypeJSON:
+		output = graphql.String
+		resolve = func(_ graphql.ResolveParams, cvr dto.ContentValueResponse) any {
+			if raw, ok := cvr.Value.(json.RawMessage); ok {
+				return string(raw)
+			}
+			return cvr.Value
+		}
+	case model.FieldTypeGeoPoint:
+		output = b.geoPoint
+	case model.FieldTypeBlocks:
+		output = b.block
+	case model.FieldTypeAsset:
+		output = b.asset
+		resolve = func(_ graphql.ResolveParams, cvr dto.ContentValueResponse) any {
+			if cvr.Asset == nil {
+				return nil
+			}
+			return cvr.Asset
+		}
+	case model.FieldTypeCollection:
+		if b.content == nil {
+			return nil
+		}
+		output = b.content
+		resolve = func(p graphql.ResolveParams, cvr dto.ContentValueResponse) any {
+			id, ok := utils.StringToUint(fmt.Sprint(cvr.Value))
+			if !ok {
+				return nil
+			}
+			item, ok := b.item(p, id)
+			if !ok || !f.AllowsTarget(item.Collection.ID) {
+				return nil
+			}
+			return item
+		}
+	default:
+		output = graphql.String
+	}
+
+	if resolve == nil {
+		resolve = func(_ graphql.ResolveParams, cvr dto.ContentValueResponse) any { return cvr.Value }
+	}
+
+	field := &graphql.Field{
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			values := valuesOf(p, alias)
+			if !f.IsList {
+				if len(values) == 0 {
+					return nil, nil
+				}
+				return resolve(p, values[0]), nil
+			}
+
+			list := make([]any, 0, len(values))
+			for _, cvr := range values {
+				if v := resolve(p, cvr); v != nil {
+					list = append(list, v)
+				}
+			}
+			return list, nil
+		},
+	}
+
+	if f.IsList {
+		field.Type = graphql.NewList(output)
+	} else {
+		field.Type = output
+	}
+
+	return field
+}
+
+func (b *schemaBuilder) findField(alias string, obj *graphql.Object) *graphql.Field {
+	return &graphql.Field{
+		Type: obj,
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, _ := p.Args["id"].(int)
+			if id <= 0 {
+				return nil, nil
+			}
+
+			item, ok := b.item(p, uint(id))
+			if !ok || item.Collection.Alias != alias {
+				return nil, nil
+			}
+			return item, nil
+		},
+	}
+}
+
+type graphqlPage struct {
+	Items      []dto.ContentItemResponse `json:"items"`
+	Total      int                       `json:"total"`
+	Page       int                       `json:"page"`
+	PerPage    int                       `json:"perPage"`
+	TotalPages int                       `json:"totalPages"`
+	NextCursor *string                   `json:"nextCursor"`
+}
+
+func (b *schemaBuilder) listField(alias, name string, obj *graphql.Object) *graphql.Field {
+	page := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Page",
+		Fields: graphql.Fields{
+			"items":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(obj)))},
+			"total":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"page":       &graphql.Field{Type: graphql.Int},
+			"perPage":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"nextCursor": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	return &graphql.Field{
+		Type: page,
+		Args: graphql.FieldConfigArgument{
+			"filter":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(b.filterInput))},
+			"match":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "and"},
+			"sort":    &graphql.ArgumentConfig{Type: graphql.String},
+			"page":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+			"perPage": &graphql.ArgumentConfig{Type: graphql.Int},
+			"cursor":  &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			q, err := graphqlListQuery(p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			maxPerPage, _ := p.Context.Value(maxPerPageKey{}).(int)
+			if maxPerPage <= 0 {
+				maxPerPage = graphqlDefaultPerPage
+			}
+			perPage := min(graphqlDefaultPerPage, maxPerPage)
+			if v, ok := p.Args["perPage"].(int); ok && v > 0 {
+				perPage = min(v, maxPerPage)
+			}
+
+			pageNumber, _ := p.Args["page"].(int)
+			pageNumber = max(pageNumber, 1)
+			offset := (pageNumber - 1) * perPage
+			if q.After > 0 {
+				pageNumber, offset = 0, 0
+			}
+
+			items, total, err := b.api.FindContentByCollectionAlias(alias, offset, perPage, q)
+			if err != nil {
+				return nil, err
+			}
+
+			result := graphqlPage{
+				Items:      items,
+				Total:      int(total),
+				Page:       pageNumber,
+				PerPage:    perPage,
+				TotalPages: int(utils.CalcTotalPages(total, perPage)),
+			}
+			if result.Items == nil {
+				result.Items = []dto.ContentItemResponse{}
+			}
+			b.resolverOf(p).load(relationIDs(items))
+			if q.Sort == "" && len(items) > 0 && len(items) == perPage {
+				cursor := EncodeCursor(items[len(items)-1].ID)
+				result.NextCursor = &cursor
+			}
+
+			return result, nil
+		},
+	}
+}
+
+func graphqlListQuery(args map[string]any) (dto.ApiQuery, error) {
+	q := dto.ApiQuery{Version: dto.ApiVersionTyped}
+
+	if sort, ok := args["sort"].(string); ok {
+		q.Sort = strings.TrimSpace(sort)
+	}
+
+	if cursor, ok := args["cursor"].(string); ok && cursor != "" {
+		if q.Sort != "" {
+			return q, errors.New("cursor pagination only supports the default order")
+		}
+		after, err := DecodeCursor(cursor)
+		if err != nil {
+			return q, err
+		}
+		q.After = after
+	}
+
+	filters, _ := args["filter"].([]any)
+	if len(filters) == 0 {
+		return q, nil
+	}
+
+	filter := &dto.ContentFilter{}
+	switch match, _ := args["match"].(string); strings.ToLower(match) {
+	case "", "and":
+	case "or":
+		filter.Or = true
+	default:
+		return q, fmt.Errorf(`%w: match must be "and" or "or"`, ErrInvalidFilter)
+	}
+
+	for _, raw := range filters {
+		input, _ := raw.(map[string]any)
+		condition := dto.FilterCondition{}
+		condition.Field, _ = input["field"].(string)
+		condition.Op, _ = input["op"].(string)
+		if condition.Op == "" {
+			condition.Op = "eq"
+		}
+		if value, ok := input["value"].(string); ok {
+			condition.Values = append(condition.Values, value)
+		}
+		if values, ok := input["values"].([]any); ok {
+			for _, v := range values {
+				if s, ok := v.(string); ok {
+					condition.Values = append(condition.Values, s)
+				}
+			}
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+	q.Filter = filter
+
+	return q, nil
+}
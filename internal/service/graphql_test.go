@@ -0,0 +1,255 @@
+package service_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func runGraphQL(t *testing.T, s service.GraphQLService, query string, variables map[string]any) map[string]any {
+	t.Helper()
+	result := s.Execute(dto.GraphQLRequest{Query: query, Variables: variables}, 100)
+	if !assert.Empty(t, result.Errors) {
+		return nil
+	}
+	return result.Data.(map[string]any)
+}
+
+func TestGraphQLService_FindResolvesRelations(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, _, _ := seedRelations(t, db)
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	data := runGraphQL(t, s, `query ($id: Int!) {
+		people(id: $id) {
+			id
+			name
+			company {
+				... on Companies {
+					city { ... on Cities { name } }
+				}
+			}
+		}
+	}`, map[string]any{"id": person.ID})
+
+	ada := data["people"].(map[string]any)
+	assert.Equal(t, "Ada", ada["name"])
+	employer := ada["company"].(map[string]any)
+	assert.Equal(t, "Hamburg", employer["city"].(map[string]any)["name"])
+
+	data = runGraphQL(t, s, fmt.Sprintf(`{ cities(id: %d) { id } }`, person.ID), nil)
+	assert.Nil(t, data["cities"])
+}
+
+func setupGraphQLProducts(t *testing.T) *repository.Set {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+
+	col := &model.Collection{Name: "Products", Alias: "products"}
+	repos.Collection.Create(col)
+	fTitle := &model.Field{Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID}
+	repos.Field.Create(fTitle)
+	fPrice := &model.Field{Alias: "price", FieldType: model.FieldTypeNumber, CollectionID: col.ID}
+	repos.Field.Create(fPrice)
+	fActive := &model.Field{Alias: "active", FieldType: model.FieldTypeBoolean, CollectionID: col.ID}
+	repos.Field.Create(fActive)
+
+	for _, row := range [][3]string{{"Go book", "9", "on"}, {"Go course", "120", ""}, {"Rust book", "30", "on"}} {
+		c := &model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished}
+		repos.Content.Create(c)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fTitle.ID, Value: row[0]})
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fPrice.ID, Value: row[1]})
+		repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fActive.ID, Value: row[2]})
+	}
+
+	return repos
+}
+
+func TestGraphQLService_ListFilterSortPagination(t *testing.T) {
+	repos := setupGraphQLProducts(t)
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	data := runGraphQL(t, s, `{
+		productsList(filter: [{field: "price", op: "gte", value: "10"}], sort: "-price", perPage: 1) {
+			total totalPages perPage nextCursor
+			items { title price active }
+		}
+	}`, nil)
+
+	page := data["productsList"].(map[string]any)
+	assert.Equal(t, 2, page["total"])
+	assert.Equal(t, 2, page["totalPages"])
+	assert.Equal(t, 1, page["perPage"])
+	assert.Nil(t, page["nextCursor"])
+	items := page["items"].([]any)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "Go course", items[0].(map[string]any)["title"])
+		assert.Equal(t, 120.0, items[0].(map[string]any)["price"])
+	}
+
+	result := s.Execute(dto.GraphQLRequest{Query: `{ productsList(filter: [{field: "nope"}]) { total } }`}, 100)
+	assert.NotEmpty(t, result.Errors)
+}
+
+func TestGraphQLService_PerPageIsCapped(t *testing.T) {
+	repos := setupGraphQLProducts(t)
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	result := s.Execute(dto.GraphQLRequest{Query: `{ productsList(perPage: 500) { perPage nextCursor items { id } } }`}, 2)
+	assert.Empty(t, result.Errors)
+	page := result.Data.(map[string]any)["productsList"].(map[string]any)
+	assert.Equal(t, 2, page["perPage"])
+	assert.Len(t, page["items"], 2)
+	assert.NotNil(t, page["nextCursor"])
+
+	result = s.Execute(dto.GraphQLRequest{Query: fmt.Sprintf(`{ productsList(cursor: %q) { items { id } } }`, page["nextCursor"])}, 2)
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Data.(map[string]any)["productsList"].(map[string]any)["items"], 1)
+}
+
+func TestGraphQLService_SchemaRebuildsWhenFieldsChange(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	first, err := s.Schema()
+	assert.NoError(t, err)
+	again, err := s.Schema()
+	assert.NoError(t, err)
+	assert.Same(t, first, again)
+
+	result := s.Execute(dto.GraphQLRequest{Query: `{ citiesList { items { population } } }`}, 100)
+	assert.NotEmpty(t, result.Errors)
+
+	repos.Field.Create(&model.Field{Alias: "population", FieldType: model.FieldTypeNumber, CollectionID: city.CollectionID})
+
+	rebuilt, err := s.Schema()
+	assert.NoError(t, err)
+	assert.NotSame(t, first, rebuilt)
+	runGraphQL(t, s, `{ citiesList { items { population } } }`, nil)
+}
+
+func TestGraphQLService_Blocks(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	page := &model.Collection{Name: "Pages", Alias: "pages"}
+	repos.Collection.Create(page)
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	repos.Collection.Create(hero)
+	repos.Field.Create(&model.Field{Alias: "count", FieldType: model.FieldTypeNumber, CollectionID: hero.ID})
+	fBody := &model.Field{Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true, CollectionID: page.ID}
+	repos.Field.Create(fBody)
+
+	c := &model.Content{CollectionID: page.ID, Status: model.ContentStatusPublished}
+	repos.Content.Create(c)
+	repos.ContentValue.Create(&model.ContentValue{ContentID: c.ID, FieldID: fBody.ID, Value: `{"component":"hero","values":{"count":["2"]}}`})
+
+	data := runGraphQL(t, s, fmt.Sprintf(`{ pages(id: %d) { body { component values } } }`, c.ID), nil)
+	body := data["pages"].(map[string]any)["body"].([]any)
+	assert.Equal(t, map[string]any{"component": "hero", "values": `{"count":2}`}, body[0])
+
+	result := s.Execute(dto.GraphQLRequest{Query: `{ heroList { total } }`}, 100)
+	assert.NotEmpty(t, result.Errors, "components have no queries")
+}
+
+func TestGraphQLService_ListLoadsRelationsOnce(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	companies := &model.Collection{Name: "Companies", Alias: "companies"}
+	repos.Collection.Create(companies)
+	people := &model.Collection{Name: "People", Alias: "people"}
+	repos.Collection.Create(people)
+	fName := &model.Field{Alias: "name", FieldType: model.FieldTypeText, CollectionID: companies.ID}
+	repos.Field.Create(fName)
+	fCompanies := &model.Field{Alias: "companies", FieldType: model.FieldTypeCollection, CollectionID: people.ID, IsList: true}
+	repos.Field.Create(fCompanies)
+
+	var companyIDs []uint
+	for _, name := range []string{"Acme", "Globex", "Initech"} {
+		company := &model.Content{CollectionID: companies.ID, Status: model.ContentStatusPublished}
+		repos.Content.Create(company)
+		repos.ContentValue.Create(&model.ContentValue{ContentID: company.ID, FieldID: fName.ID, Value: name})
+		companyIDs = append(companyIDs, company.ID)
+	}
+
+	addPeople := func(n int) {
+		for range n {
+			person := &model.Content{CollectionID: people.ID, Status: model.ContentStatusPublished}
+			repos.Content.Create(person)
+			for _, id := range companyIDs {
+				repos.ContentValue.Create(&model.ContentValue{ContentID: person.ID, FieldID: fCompanies.ID, Value: fmt.Sprint(id)})
+			}
+		}
+	}
+
+	const query = `{ peopleList { items { companies { ... on Companies { name } } } } }`
+	queries := 0
+	db.Callback().Query().After("gorm:query").Register("count_queries", func(*gorm.DB) { queries++ })
+	countQueries := func() int {
+		// the first run builds the schema, which is not what is counted here
+		runGraphQL(t, s, query, nil)
+		queries = 0
+		data := runGraphQL(t, s, query, nil)
+		for _, item := range data["peopleList"].(map[string]any)["items"].([]any) {
+			assert.Len(t, item.(map[string]any)["companies"], 3)
+		}
+		return queries
+	}
+
+	addPeople(2)
+	few := countQueries()
+	addPeople(5)
+	assert.Equal(t, few, countQueries(), "the number of queries does not grow with the entries")
+}
+
+func TestGraphQLService_CollidingNamesGetIDSuffix(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	s := service.NewGraphQLService(repos, service.NewApiService(repos))
+
+	ids := map[string]uint{}
+	for _, alias := range []string{"post", "post-page", "content", "postList"} {
+		col := &model.Collection{Name: alias, Alias: alias}
+		repos.Collection.Create(col)
+		repos.Field.Create(&model.Field{Alias: "title", FieldType: model.FieldTypeText, CollectionID: col.ID})
+		repos.Content.Create(&model.Content{CollectionID: col.ID, Status: model.ContentStatusPublished})
+		ids[alias] = col.ID
+	}
+
+	schema, err := s.Schema()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotNil(t, schema.Type("PostPage"))
+	assert.NotNil(t, schema.Type(fmt.Sprintf("PostPage%d", ids["post-page"])))
+	assert.NotNil(t, schema.Type(fmt.Sprintf("Content%d", ids["content"])))
+
+	queries := []string{
+		"postList",
+		fmt.Sprintf("post_page%dList", ids["post-page"]),
+		fmt.Sprintf("content%dList", ids["content"]),
+		fmt.Sprintf("postList%dList", ids["postList"]),
+	}
+	var query strings.Builder
+	for _, q := range queries {
+		query.WriteString(q + " { total } ")
+	}
+
+	data := runGraphQL(t, s, "{ "+query.String()+"}", nil)
+	for _, q := range queries {
+		if assert.Contains(t, data, q) {
+			assert.Equal(t, 1, data[q].(map[string]any)["total"], q)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidLocale = errors.New("invalid locale")
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// translationSeparator joins a field alias and a locale to the form key of a
+// translated value, e.g. "title@de".
+const translationSeparator = "@"
+
+func TranslationKey(alias, locale string) string {
+	return alias + translationSeparator + locale
+}
+
+func SplitTranslationKey(key string) (alias, locale string, ok bool) {
+	return strings.Cut(key, translationSeparator)
+}
+
+// Locales holds the configured content locales. Values of the first, default
+// locale are stored without a locale, so content written before locales were
+// configured stays the default translation. The zero value has no locales.
+type Locales struct {
+	codes []string
+}
+
+func NewLocales(codes []string) (Locales, error) {
+	var l Locales
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if !localePattern.MatchString(code) {
+			return Locales{}, fmt.Errorf("%w: %q", ErrInvalidLocale, code)
+		}
+
+		if l.find(code) == "" {
+			l.codes = append(l.codes, code)
+		}
+	}
+
+	return l, nil
+}
+
+func (l Locales) find(code string) string {
+	for _, c := range l.codes {
+		if strings.EqualFold(c, code) {
+			return c
+		}
+	}
+	return ""
+}
+
+func (l Locales) Enabled() bool {
+	return len(l.codes) > 1
+}
+
+func (l Locales) Default() string {
+	if len(l.codes) == 0 {
+		return ""
+	}
+	return l.codes[0]
+}
+
+// Translations returns all locales except the default one.
+func (l Locales) Translations() []string {
+	if len(l.codes) < 2 {
+		return nil
+	}
+	return slices.Clone(l.codes[1:])
+}
+
+func (l Locales) IsTranslation(code string) bool {
+	return code != "" && slices.Contains(l.Translations(), code)
+}
+
+// parentLocales returns the locale itself followed by its less specific
+// parents, "de-AT" gives "de-AT", "de".
+func parentLocales(code string) []string {
+	parents := []string{code}
+	for {
+		i := strings.LastIndex(code, "-")
+		if i < 0 {
+			return parents
+		}
+		code = code[:i]
+		parents = append(parents, code)
+	}
+}
+
+// Chain resolves a requested locale to the stored locales to read values
+// from, most specific first: "de-AT" gives "de-AT", "de" and "" for the
+// default locale, skipping locales that are not configured. No locale gives
+// a nil chain, which reads the default locale only.
+func (l Locales) Chain(tag string) ([]string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, nil
+	}
+
+	if !localePattern.MatchString(tag) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLocale, tag)
+	}
+
+	var chain []string
+	known := false
+	for _, candidate := range parentLocales(tag) {
+		code := l.find(candidate)
+		if code == "" {
+			continue
+		}
+
+		known = true
+		if code == l.Default() {
+			break
+		}
+		chain = append(chain, code)
+	}
+
+	if !known {
+		return nil, fmt.Errorf("%w: %q is not configured", ErrInvalidLocale, tag)
+	}
+
+	return append(chain, ""), nil
+}
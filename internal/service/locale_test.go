@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocales(t *testing.T) {
+	l, err := NewLocales([]string{"en", " de ", "de-AT", "DE"})
+	assert.NoError(t, err)
+	assert.True(t, l.Enabled())
+	assert.Equal(t, "en", l.Default())
+	assert.Equal(t, []string{"de", "de-AT"}, l.Translations())
+	assert.True(t, l.IsTranslation("de-AT"))
+	assert.False(t, l.IsTranslation("en"))
+	assert.False(t, l.IsTranslation(""))
+
+	_, err = NewLocales([]string{"en", "de_DE"})
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+
+	var none Locales
+	assert.False(t, none.Enabled())
+	assert.Equal(t, "", none.Default())
+	assert.Empty(t, none.Translations())
+}
+
+func TestLocales_Chain(t *testing.T) {
+	l, _ := NewLocales([]string{"en", "de", "de-AT", "fr"})
+
+	tests := []struct {
+		tag   string
+		chain []string
+	}{
+		{"", nil},
+		{"de-AT", []string{"de-AT", "de", ""}},
+		{"de-at", []string{"de-AT", "de", ""}},
+		{"de-CH", []string{"de", ""}},
+		{"fr", []string{"fr", ""}},
+		{"en", []string{""}},
+		{"en-GB", []string{""}},
+	}
+
+	for _, tt := range tests {
+		chain, err := l.Chain(tt.tag)
+		assert.NoError(t, err, tt.tag)
+		assert.Equal(t, tt.chain, chain, tt.tag)
+	}
+
+	_, err := l.Chain("it")
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+
+	_, err = l.Chain("de AT")
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+
+	defaultDE, _ := NewLocales([]string{"de", "de-AT"})
+	chain, err := defaultDE.Chain("de-AT")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"de-AT", ""}, chain)
+}
+
+func TestTranslationKey(t *testing.T) {
+	key := TranslationKey("title", "de-AT")
+	assert.Equal(t, "title@de-AT", key)
+
+	alias, locale, ok := SplitTranslationKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, "title", alias)
+	assert.Equal(t, "de-AT", locale)
+
+	_, _, ok = SplitTranslationKey("title")
+	assert.False(t, ok)
+}
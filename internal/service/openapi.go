@@ -0,0 +1,659 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+type OpenAPIService interface {
+	Spec() (map[string]any, error)
+}
+
+type openAPIService struct {
+	repos *repository.Set
+
+	mu          sync.Mutex
+	fingerprint string
+	spec        map[string]any
+}
+
+func NewOpenAPIService(repos *repository.Set) OpenAPIService {
+	return &openAPIService{repos: repos}
+}
+
+func (s *openAPIService) Spec() (map[string]any, error) {
+	fingerprint, err := s.repos.Schema.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.spec != nil && s.fingerprint == fingerprint {
+		return s.spec, nil
+	}
+
+	spec, err := s.buildSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	s.spec = spec
+	s.fingerprint = fingerprint
+
+	return spec, nil
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func responseRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/responses/" + name}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+func envelope(data map[string]any, paginated bool) map[string]any {
+	properties := map[string]any{"data": data}
+	if paginated {
+		properties["pagination"] = ref("Pagination")
+	}
+
+	return map[string]any{
+		"allOf": []any{
+			ref("ApiResponse"),
+			map[string]any{"type": "object", "properties": properties},
+		},
+	}
+}
+
+func queryParam(name, description string, schema map[string]any) map[string]any {
+	return map[string]any{"name": name, "in": "query", "description": description, "schema": schema}
+}
+
+func typedValueSchema(f model.Field) map[string]any {
+	switch f.FieldType {
+	case model.FieldTypeNumber:
+		return map[string]any{"type": "number", "nullable": true}
+	case model.FieldTypeBoolean:
+		return map[string]any{"type": "boolean"}
+	case model.FieldTypeDate:
+		return map[string]any{"type": "string", "format": "date-time", "nullable": true}
+	case model.FieldTypeMultiSelect:
+		if !f.IsList {
+			return map[string]any{"type": "array", "items": map[string]any{"type": "string"}}
+		}
+	case model.FieldTypeJSON:
+		return map[string]any{"description": "The stored JSON value.", "nullable": true}
+	case model.FieldTypeGeoPoint:
+		return map[string]any{"nullable": true, "allOf": []any{ref("GeoPoint")}}
+	case model.FieldTypeBlocks:
+		return ref("Block")
+	}
+	return map[string]any{"type": "string"}
+}
+
+func valueSchema(f model.Field) map[string]any {
+	value := typedValueSchema(f)
+	if value["type"] != "string" || value["format"] != nil {
+		value = map[string]any{
+			"description": "Typed with X-API-Version: 2, the raw string otherwise.",
+			"oneOf":       []any{value, map[string]any{"type": "string"}},
+		}
+	}
+
+	properties := map[string]any{
+		"id":         map[string]any{"type": "integer"},
+		"value":      value,
+		"field_type": map[string]any{"type": "string", "enum": []any{string(f.FieldType)}},
+	}
+
+	switch f.FieldType {
+	case model.FieldTypeAsset:
+		properties["asset"] = ref("Asset")
+	case model.FieldTypeCollection:
+		properties["collection"] = ref("CollectionRef")
+		properties["content"] = map[string]any{
+			"description": "The referenced entry, embedded when requested with include or depth.",
+			"type":        "object",
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if f.IsList {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func writeValueSchema(f model.Field) map[string]any {
+	var schema map[string]any
+	switch f.FieldType {
+	case model.FieldTypeNumber:
+		schema = map[string]any{"type": "number"}
+		if f.MinValue != nil {
+			schema["minimum"] = *f.MinValue
+		}
+		if f.MaxValue != nil {
+			schema["maximum"] = *f.MaxValue
+		}
+	case model.FieldTypeBoolean:
+		schema = map[string]any{"type": "boolean"}
+	case model.FieldTypeDate:
+		schema = map[string]any{"type": "string", "format": "date"}
+	case model.FieldTypeAsset:
+		schema = map[string]any{"type": "integer", "description": "ID of the referenced asset."}
+	case model.FieldTypeCollection:
+		schema = map[string]any{"type": "integer", "description": "ID of the referenced content entry."}
+	case model.FieldTypeSelect, model.FieldTypeMultiSelect:
+		schema = map[string]any{"type": "string", "enum": f.OptionValues()}
+	case model.FieldTypeJSON:
+		schema = map[string]any{"description": "Any JSON value, strings are read as JSON text."}
+	case model.FieldTypeGeoPoint:
+		schema = map[string]any{"oneOf": []any{ref("GeoPoint"), map[string]any{"type": "string", "example": "52.52,13.405"}}}
+	case model.FieldTypeBlocks:
+		schema = ref("Block")
+	case model.FieldTypeColor:
+		schema = map[string]any{"type": "string", "pattern": "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$"}
+	default:
+		schema = map[string]any{"type": "string"}
+		if f.MinLength != nil {
+			schema["minLength"] = *f.MinLength
+		}
+		if f.MaxLength != nil {
+			schema["maxLength"] = *f.MaxLength
+		}
+		if f.Pattern != "" {
+			schema["pattern"] = f.Pattern
+		}
+		if allowed := f.AllowedValueList(); len(allowed) > 0 {
+			schema["enum"] = allowed
+		}
+		switch f.FieldType {
+		case model.FieldTypeURL:
+			schema["format"] = "uri"
+		case model.FieldTypeEmail:
+			schema["format"] = "email"
+		case model.FieldTypeSlug:
+			if f.Pattern == "" {
+				schema["pattern"] = "^[a-z0-9]+(-[a-z0-9]+)*$"
+			}
+		}
+	}
+
+	if !f.AcceptsMany() {
+		return schema
+	}
+
+	list := map[string]any{"type": "array", "items": schema}
+	if f.MinItems != nil {
+		list["minItems"] = *f.MinItems
+	}
+	if f.MaxItems != nil {
+		list["maxItems"] = *f.MaxItems
+	}
+	return list
+}
+
+func collectionSchemas(name string, fields []model.Field) map[string]any {
+	values := map[string]any{}
+	writeValues := map[string]any{}
+	translatedValues := map[string]any{}
+	var required []any
+
+	for _, f := range fields {
+		values[f.Alias] = valueSchema(f)
+		writeValues[f.Alias] = writeValueSchema(f)
+		if f.IsRequired {
+			required = append(required, f.Alias)
+		}
+		if f.Localized {
+			translatedValues[f.Alias] = writeValueSchema(f)
+		}
+	}
+
+	write := map[string]any{"type": "object", "additionalProperties": false, "properties": writeValues}
+	if len(required) > 0 {
+		write["required"] = required
+	}
+
+	writeProperties := map[string]any{
+		"status":       ref("ContentStatus"),
+		"publish_at":   map[string]any{"type": "string", "format": "date-time"},
+		"unpublish_at": map[string]any{"type": "string", "format": "date-time"},
+		"values":       write,
+	}
+	if len(translatedValues) > 0 {
+		writeProperties["translations"] = map[string]any{
+			"type":        "object",
+			"description": "Values of localized fields keyed by locale.",
+			"additionalProperties": map[string]any{
+				"type": "object", "additionalProperties": false, "properties": translatedValues,
+			},
+		}
+	}
+
+	return map[string]any{
+		name: map[string]any{
+			"type":     "object",
+			"required": []any{"id", "created_at", "updated_at", "status", "values", "collection"},
+			"properties": map[string]any{
+				"id":           map[string]any{"type": "integer"},
+				"created_at":   map[string]any{"type": "string", "format": "date-time"},
+				"updated_at":   map[string]any{"type": "string", "format": "date-time"},
+				"status":       ref("ContentStatus"),
+				"publish_at":   map[string]any{"type": "string", "format": "date-time"},
+				"unpublish_at": map[string]any{"type": "string", "format": "date-time"},
+				"values":       map[string]any{"type": "object", "properties": values},
+				"collection":   ref("CollectionRef"),
+			},
+		},
+		name + "Write": map[string]any{
+			"type":       "object",
+			"required":   []any{"values"},
+			"properties": writeProperties,
+		},
+	}
+}
+
+func readParams() []any {
+	return []any{
+		map[string]any{"$ref": "#/components/parameters/Version"},
+		queryParam("fields", "Comma separated field aliases to return.", map[string]any{"type": "string"}),
+		queryParam("include", "Comma separated relation paths to embed.", map[string]any{"type": "string"}),
+		queryParam("depth", "Embed relations up to this depth.", map[string]any{"type": "integer", "minimum": 0, "maximum": dto.MaxIncludeDepth}),
+		queryParam("locale", "Locale of localized values, falls back to the parent locale and the default locale.", map[string]any{"type": "string"}),
+	}
+}
+
+func listParams() []any {
+	return append(readParams(),
+		queryParam("page", "Page number, starting at 1.", map[string]any{"type": "integer", "minimum": 1}),
+		queryParam("perPage", "Entries per page.", map[string]any{"type": "integer", "minimum": 1}),
+		queryParam("cursor", "Opaque cursor from pagination.next_cursor.", map[string]any{"type": "string"}),
+		queryParam("sort", "Comma separated sort keys, prefix with - for descending order.", map[string]any{"type": "string"}),
+		queryParam("match", "Combine filters with and (default) or or.", map[string]any{"type": "string", "enum": []any{"and", "or"}}),
+		map[string]any{
+			"name":        "filter",
+			"in":          "query",
+			"description": "filter[<field alias>][<operator>]=<value>",
+			"style":       "deepObject",
+			"explode":     true,
+			"schema":      map[string]any{"type": "object", "additionalProperties": true},
+		},
+	)
+}
+
+func errorResponses(codes ...string) map[string]any {
+	responses := map[string]any{"401": responseRef("Unauthorized")}
+	names := map[string]string{"400": "BadRequest", "404": "NotFound", "422": "UnprocessableEntity", "500": "InternalError"}
+	for _, code := range codes {
+		responses[code] = responseRef(names[code])
+	}
+	return responses
+}
+
+func readResponses() map[string]any {
+	responses := errorResponses("400", "404", "500")
+	responses["304"] = map[string]any{"description": "Not modified since the ETag or date sent in If-None-Match / If-Modified-Since"}
+	return responses
+}
+
+func withResponse(responses map[string]any, code, description string, schema map[string]any) map[string]any {
+	responses[code] = map[string]any{"description": description, "content": jsonContent(schema)}
+	return responses
+}
+
+func eventResponses() map[string]any {
+	responses := errorResponses("400", "500")
+	responses["200"] = map[string]any{
+		"description": "Stream of created, updated, deleted and reset events, the data of each event is a ContentEvent",
+		"content": map[string]any{
+			"text/event-stream": map[string]any{"schema": map[string]any{"type": "string"}},
+		},
+	}
+	return responses
+}
+
+func (s *openAPIService) buildSpec() (map[string]any, error) {
+	collections, _, err := s.repos.Collection.List(1, 100000)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := baseSchemas()
+	paths := map[string]any{}
+	var items []any
+
+	for _, c := range collections {
+		if c.IsComponent {
+			continue
+		}
+
+		fields, err := s.repos.Field.FindByCollectionID(c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		name := typeName(c.Alias)
+		if schemas[name] != nil || schemas[name+"Write"] != nil {
+			name = fmt.Sprintf("%s%d", name, c.ID)
+		}
+		for k, v := range collectionSchemas(name, fields) {
+			schemas[k] = v
+		}
+		items = append(items, ref(name))
+
+		tag := c.Alias
+		paths["/api/collections/"+c.Alias+"/content"] = map[string]any{
+			"get": map[string]any{
+				"tags":        []any{tag},
+				"operationId": "list" + name,
+				"summary":     "List " + c.Name + " entries",
+				"parameters":  listParams(),
+				"responses":   withResponse(readResponses(), "200", "A page of entries", envelope(map[string]any{"type": "array", "items": ref(name)}, true)),
+			},
+			"post": map[string]any{
+				"tags":        []any{tag},
+				"operationId": "create" + name,
+				"summary":     "Create a " + c.Name + " entry",
+				"parameters":  []any{map[string]any{"$ref": "#/components/parameters/Version"}},
+				"requestBody": map[string]any{"required": true, "content": jsonContent(ref(name + "Write"))},
+				"responses":   withResponse(errorResponses("400", "404", "422", "500"), "201", "The created entry", envelope(ref(name), false)),
+			},
+		}
+		paths["/api/collections/"+c.Alias+"/content/filter"] = map[string]any{
+			"get": map[string]any{
+				"tags":        []any{tag},
+				"operationId": "list" + name + "ByFieldValue",
+				"summary":     "List " + c.Name + " entries with a field value",
+				"parameters": append(listParams(),
+					queryParam("field", "Field alias to match.", map[string]any{"type": "string"}),
+					queryParam("value", "Value the field must equal.", map[string]any{"type": "string"}),
+				),
+				"responses": withResponse(readResponses(), "200", "A page of entries", envelope(map[string]any{"type": "array", "items": ref(name)}, true)),
+			},
+		}
+	}
+
+	var anyItem map[string]any
+	if len(items) > 0 {
+		anyItem = map[string]any{"oneOf": items}
+	} else {
+		anyItem = map[string]any{"type": "object"}
+	}
+
+	idParam := map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "integer"}}
+	writeBody := map[string]any{"required": true, "content": jsonContent(ref("ContentWrite"))}
+	paths["/api/content/{id}"] = map[string]any{
+		"parameters": []any{idParam},
+		"get": map[string]any{
+			"operationId": "findContent",
+			"summary":     "Find an entry by ID",
+			"parameters":  readParams(),
+			"responses":   withResponse(readResponses(), "200", "The entry", envelope(anyItem, false)),
+		},
+		"put": map[string]any{
+			"operationId": "replaceContent",
+			"summary":     "Replace all values of an entry",
+			"parameters":  []any{map[string]any{"$ref": "#/components/parameters/Version"}},
+			"requestBody": writeBody,
+			"responses":   withResponse(errorResponses("400", "404", "422", "500"), "200", "The updated entry", envelope(anyItem, false)),
+		},
+		"patch": map[string]any{
+			"operationId": "patchContent",
+			"summary":     "Update the given values of an entry",
+			"parameters":  []any{map[string]any{"$ref": "#/components/parameters/Version"}},
+			"requestBody": writeBody,
+			"responses":   withResponse(errorResponses("400", "404", "422", "500"), "200", "The updated entry", envelope(anyItem, false)),
+		},
+		"delete": map[string]any{
+			"operationId": "deleteContent",
+			"summary":     "Delete an entry",
+			"responses":   withResponse(errorResponses("400", "404", "500"), "200", "The entry was deleted", ref("ApiResponse")),
+		},
+	}
+	schemas["ContentWrite"] = map[string]any{
+		"type":     "object",
+		"required": []any{"values"},
+		"properties": map[string]any{
+			"status":       ref("ContentStatus"),
+			"publish_at":   map[string]any{"type": "string", "format": "date-time"},
+			"unpublish_at": map[string]any{"type": "string", "format": "date-time"},
+			"values":       map[string]any{"type": "object", "additionalProperties": true},
+			"translations": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "object", "additionalProperties": true}},
+		},
+	}
+
+	paths["/api/sync"] = map[string]any{
+		"get": map[string]any{
+			"operationId": "sync",
+			"summary":     "List entries changed since a sync token",
+			"parameters": append(readParams(),
+				queryParam("since", "Token from next_token of the previous response, empty for a full sync.", map[string]any{"type": "string"}),
+				queryParam("collections", "Comma separated collection aliases, all collections when empty.", map[string]any{"type": "string"}),
+				queryParam("perPage", "Changes per response.", map[string]any{"type": "integer", "minimum": 1}),
+			),
+			"responses": withResponse(errorResponses("400", "404", "500"), "200", "Changed and deleted entries", envelope(ref("SyncResponse"), false)),
+		},
+	}
+	schemas["SyncResponse"] = map[string]any{
+		"type":     "object",
+		"required": []any{"items", "deleted", "next_token", "has_more"},
+		"properties": map[string]any{
+			"items": map[string]any{"type": "array", "items": anyItem},
+			"deleted": map[string]any{"type": "array", "items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":         map[string]any{"type": "integer"},
+					"collection": ref("CollectionRef"),
+					"reason":     map[string]any{"type": "string", "enum": []any{SyncReasonDeleted, SyncReasonHidden}},
+					"deleted_at": map[string]any{"type": "string", "format": "date-time"},
+				},
+			}},
+			"next_token": map[string]any{"type": "string"},
+			"has_more":   map[string]any{"type": "boolean"},
+		},
+	}
+
+	paths["/api/search"] = map[string]any{
+		"get": map[string]any{
+			"operationId": "search",
+			"summary":     "Search the text of all entries",
+			"parameters": append(readParams(),
+				map[string]any{"name": "q", "in": "query", "required": true, "description": "Search terms, every term has to match.", "schema": map[string]any{"type": "string"}},
+				queryParam("collection", "Only search entries of this collection alias.", map[string]any{"type": "string"}),
+				queryParam("page", "Page number, starting at 1.", map[string]any{"type": "integer", "minimum": 1}),
+				queryParam("perPage", "Hits per page.", map[string]any{"type": "integer", "minimum": 1}),
+			),
+			"responses": withResponse(errorResponses("400", "404", "500"), "200", "Hits ordered by relevance", envelope(map[string]any{"type": "array", "items": ref("SearchHit")}, true)),
+		},
+	}
+	schemas["SearchHit"] = map[string]any{
+		"type":     "object",
+		"required": []any{"score", "snippet", "item"},
+		"properties": map[string]any{
+			"score":   map[string]any{"type": "number", "description": "Relevance, higher is better."},
+			"snippet": map[string]any{"type": "string", "description": "HTML escaped excerpt with matches wrapped in <mark>."},
+			"item":    anyItem,
+		},
+	}
+
+	paths["/api/events"] = map[string]any{
+		"get": map[string]any{
+			"operationId": "events",
+			"summary":     "Stream content changes as server-sent events",
+			"parameters": append(readParams(),
+				queryParam("collections", "Comma separated collection aliases, all collections when empty.", map[string]any{"type": "string"}),
+				queryParam("item", "Send the prepared entry with created and updated events.", map[string]any{"type": "boolean"}),
+				queryParam("lastEventId", "Resume after this event, same as the Last-Event-ID header.", map[string]any{"type": "integer"}),
+			),
+			"responses": eventResponses(),
+		},
+	}
+	schemas["ContentEvent"] = map[string]any{
+		"type":     "object",
+		"required": []any{"id", "type", "content_id", "collection", "timestamp"},
+		"properties": map[string]any{
+			"id":         map[string]any{"type": "integer"},
+			"type":       map[string]any{"type": "string", "enum": []any{EventCreated, EventUpdated, EventDeleted}},
+			"content_id": map[string]any{"type": "integer"},
+			"collection": map[string]any{"type": "string"},
+			"timestamp":  map[string]any{"type": "string", "format": "date-time"},
+			"item":       anyItem,
+		},
+	}
+
+	paths["/api/graphql"] = map[string]any{
+		"post": map[string]any{
+			"operationId": "graphql",
+			"summary":     "Run a GraphQL query",
+			"requestBody": map[string]any{"required": true, "content": jsonContent(map[string]any{
+				"type":     "object",
+				"required": []any{"query"},
+				"properties": map[string]any{
+					"query":         map[string]any{"type": "string"},
+					"variables":     map[string]any{"type": "object", "additionalProperties": true},
+					"operationName": map[string]any{"type": "string"},
+				},
+			})},
+			"responses": withResponse(errorResponses("400"), "200", "The GraphQL result", map[string]any{"type": "object"}),
+		},
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "nuricms content API",
+			"version": "1",
+		},
+		"security": []any{map[string]any{"ApiKey": []any{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"ApiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+			"parameters": map[string]any{
+				"Version": map[string]any{
+					"name":        "X-API-Version",
+					"in":          "header",
+					"description": "1 returns all values as strings, 2 returns typed values.",
+					"schema":      map[string]any{"type": "integer", "enum": []any{1, 2}, "default": 1},
+				},
+			},
+			"responses": map[string]any{
+				"BadRequest":          map[string]any{"description": "The request is invalid", "content": jsonContent(ref("ErrorResponse"))},
+				"NotFound":            map[string]any{"description": "The resource does not exist", "content": jsonContent(ref("ErrorResponse"))},
+				"UnprocessableEntity": map[string]any{"description": "The values are invalid", "content": jsonContent(ref("ErrorResponse"))},
+				"InternalError":       map[string]any{"description": "The request failed", "content": jsonContent(ref("ErrorResponse"))},
+				"Unauthorized":        map[string]any{"description": "The API key is missing or invalid", "content": jsonContent(ref("ErrorResponse"))},
+			},
+			"schemas": schemas,
+		},
+	}, nil
+}
+
+func baseSchemas() map[string]any {
+	return map[string]any{
+		"ContentStatus": map[string]any{
+			"type": "string",
+			"enum": []any{string(model.ContentStatusDraft), string(model.ContentStatusPublished), string(model.ContentStatusArchived)},
+		},
+		"CollectionRef": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":    map[string]any{"type": "integer"},
+				"name":  map[string]any{"type": "string"},
+				"alias": map[string]any{"type": "string"},
+			},
+		},
+		"Asset": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":   map[string]any{"type": "integer"},
+				"name": map[string]any{"type": "string"},
+				"path": map[string]any{"type": "string"},
+			},
+		},
+		"GeoPoint": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"lat": map[string]any{"type": "number", "minimum": -90, "maximum": 90},
+				"lng": map[string]any{"type": "number", "minimum": -180, "maximum": 180},
+			},
+		},
+		"Block": map[string]any{
+			"type":     "object",
+			"required": []any{"component"},
+			"properties": map[string]any{
+				"component": map[string]any{"type": "string", "description": "Alias of the component."},
+				"values": map[string]any{
+					"type":                 "object",
+					"description":          "Values keyed by the component's field aliases.",
+					"additionalProperties": true,
+				},
+			},
+		},
+		"MetaData": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"timestamp": map[string]any{"type": "string", "format": "date-time"},
+			},
+		},
+		"Pagination": map[string]any{
+			"type":     "object",
+			"required": []any{"total", "total_pages"},
+			"properties": map[string]any{
+				"page":        map[string]any{"type": "integer"},
+				"per_page":    map[string]any{"type": "integer"},
+				"total":       map[string]any{"type": "integer"},
+				"total_pages": map[string]any{"type": "integer"},
+				"next":        map[string]any{"type": "string"},
+				"prev":        map[string]any{"type": "string"},
+				"next_cursor": map[string]any{"type": "string"},
+			},
+		},
+		"ErrorDetail": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "string"},
+				"message": map[string]any{"type": "string"},
+				"fields": map[string]any{
+					"type":                 "object",
+					"additionalProperties": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+				},
+			},
+		},
+		"ApiResponse": map[string]any{
+			"type":     "object",
+			"required": []any{"success"},
+			"properties": map[string]any{
+				"success":    map[string]any{"type": "boolean"},
+				"data":       map[string]any{},
+				"error":      ref("ErrorDetail"),
+				"meta":       ref("MetaData"),
+				"pagination": ref("Pagination"),
+			},
+		},
+		"ErrorResponse": map[string]any{
+			"allOf": []any{
+				ref("ApiResponse"),
+				map[string]any{
+					"type":     "object",
+					"required": []any{"error"},
+					"properties": map[string]any{
+						"success": map[string]any{"type": "boolean", "enum": []any{false}},
+					},
+				},
+			},
+		},
+	}
+}
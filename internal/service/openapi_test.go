@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func specPath(t *testing.T, spec map[string]any, keys ...string) any {
+	t.Helper()
+	var current any = spec
+	for _, key := range keys {
+		m, ok := current.(map[string]any)
+		if !assert.True(t, ok, "%v is not an object at %q", keys, key) {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+func TestOpenAPIService_DescribesCollections(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, _, _ := seedRelations(t, db)
+	minLength := 2
+	repos.Field.Create(&model.Field{Alias: "tags", FieldType: model.FieldTypeText, CollectionID: person.CollectionID, IsList: true, IsRequired: true, MinLength: &minLength})
+	repos.Field.Create(&model.Field{Alias: "age", FieldType: model.FieldTypeNumber, CollectionID: person.CollectionID})
+
+	spec, err := service.NewOpenAPIService(repos).Spec()
+	assert.NoError(t, err)
+
+	_, err = json.Marshal(spec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.0.3", spec["openapi"])
+	for _, alias := range []string{"people", "companies", "cities"} {
+		assert.NotNil(t, specPath(t, spec, "paths", "/api/collections/"+alias+"/content", "get"))
+		assert.NotNil(t, specPath(t, spec, "paths", "/api/collections/"+alias+"/content", "post"))
+		assert.NotNil(t, specPath(t, spec, "paths", "/api/collections/"+alias+"/content/filter", "get"))
+	}
+	assert.NotNil(t, specPath(t, spec, "paths", "/api/content/{id}", "patch"))
+
+	values := specPath(t, spec, "components", "schemas", "People", "properties", "values", "properties").(map[string]any)
+	assert.Contains(t, values, "name")
+	assert.Equal(t, "array", specPath(t, values, "tags", "type"))
+	assert.Equal(t, "#/components/schemas/CollectionRef", specPath(t, values, "company", "properties", "collection", "$ref"))
+
+	write := specPath(t, spec, "components", "schemas", "PeopleWrite", "properties", "values").(map[string]any)
+	assert.Equal(t, []any{"tags"}, write["required"])
+	assert.Equal(t, "number", specPath(t, write, "properties", "age", "type"))
+	assert.Equal(t, 2, specPath(t, write, "properties", "tags", "items", "minLength"))
+	assert.Equal(t, "integer", specPath(t, write, "properties", "company", "type"))
+
+	assert.NotNil(t, specPath(t, spec, "components", "schemas", "ErrorResponse"))
+	assert.NotNil(t, specPath(t, spec, "components", "schemas", "ApiResponse"))
+	assert.NotNil(t, specPath(t, spec, "components", "securitySchemes", "ApiKey"))
+}
+
+func TestOpenAPIService_RegeneratesWhenSchemaChanges(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewOpenAPIService(repos)
+
+	first, err := s.Spec()
+	assert.NoError(t, err)
+	cached, err := s.Spec()
+	assert.NoError(t, err)
+	assert.Equal(t, first, cached)
+
+	repos.Collection.Create(&model.Collection{Name: "Tags", Alias: "tags"})
+	repos.Field.Create(&model.Field{Alias: "population", FieldType: model.FieldTypeNumber, CollectionID: city.CollectionID})
+
+	spec, err := s.Spec()
+	assert.NoError(t, err)
+	assert.NotNil(t, specPath(t, spec, "paths", "/api/collections/tags/content"))
+	assert.Contains(t, specPath(t, spec, "components", "schemas", "Cities", "properties", "values", "properties"), "population")
+}
+
+func TestOpenAPIService_SelectOptions(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewOpenAPIService(repos)
+
+	colors := &model.Field{Alias: "colors", FieldType: model.FieldTypeMultiSelect, CollectionID: city.CollectionID}
+	repos.Field.Create(colors)
+	repos.FieldOption.Create(&model.FieldOption{FieldID: colors.ID, Value: "red"})
+
+	spec, err := s.Spec()
+	assert.NoError(t, err)
+	write := specPath(t, spec, "components", "schemas", "CitiesWrite", "properties", "values", "properties").(map[string]any)
+	assert.Equal(t, "array", specPath(t, write, "colors", "type"))
+	assert.Equal(t, []string{"red"}, specPath(t, write, "colors", "items", "enum"))
+
+	repos.FieldOption.Create(&model.FieldOption{FieldID: colors.ID, Value: "blue"})
+
+	spec, err = s.Spec()
+	assert.NoError(t, err)
+	write = specPath(t, spec, "components", "schemas", "CitiesWrite", "properties", "values", "properties").(map[string]any)
+	assert.Equal(t, []string{"red", "blue"}, specPath(t, write, "colors", "items", "enum"), "option changes regenerate the spec")
+}
+
+func TestOpenAPIService_Blocks(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewOpenAPIService(repos)
+
+	hero := &model.Collection{Name: "Hero", Alias: "hero", IsComponent: true}
+	repos.Collection.Create(hero)
+	repos.Field.Create(&model.Field{Alias: "body", FieldType: model.FieldTypeBlocks, IsList: true, CollectionID: city.CollectionID})
+
+	spec, err := s.Spec()
+	assert.NoError(t, err)
+	write := specPath(t, spec, "components", "schemas", "CitiesWrite", "properties", "values", "properties").(map[string]any)
+	assert.Equal(t, "array", specPath(t, write, "body", "type"))
+	assert.Equal(t, "#/components/schemas/Block", specPath(t, write, "body", "items", "$ref"))
+
+	paths := specPath(t, spec, "paths").(map[string]any)
+	assert.NotContains(t, paths, "/api/collections/hero/content", "components have no endpoints")
+}
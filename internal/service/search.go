@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+var ErrMissingSearchQuery = errors.New("missing search query")
+
+var (
+	htmlSkipPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)>`)
+	htmlTagPattern  = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+var highlighter = strings.NewReplacer(repository.SnippetStart, "<mark>", repository.SnippetEnd, "</mark>")
+
+type SearchService interface {
+	Search(query, alias string, offset, limit int, q dto.ApiQuery) ([]dto.SearchHit, int64, error)
+	EnsureIndex() error
+}
+
+type searchService struct {
+	repos *repository.Set
+	api   ApiService
+}
+
+func NewSearchService(repos *repository.Set, api ApiService) SearchService {
+	return &searchService{repos: repos, api: api}
+}
+
+func isSearchable(t model.FieldType) bool {
+	return t == model.FieldTypeText || t == model.FieldTypeTextarea || t == model.FieldTypeRichText
+}
+
+func stripHTML(s string) string {
+	s = htmlSkipPattern.ReplaceAllString(s, " ")
+	s = htmlTagPattern.ReplaceAllString(s, " ")
+	return html.UnescapeString(s)
+}
+
+func searchDocument(contentID, collectionID uint, fields []model.Field, forms localeForms) *model.SearchDocument {
+	var parts []string
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			if !isSearchable(f.FieldType) {
+				continue
+			}
+
+			for _, v := range forms[locale][f.Alias] {
+				if f.FieldType == model.FieldTypeRichText {
+					v = stripHTML(v)
+				}
+				if v = strings.Join(strings.Fields(v), " "); v != "" {
+					parts = append(parts, v)
+				}
+			}
+		}
+	}
+
+	return &model.SearchDocument{
+		ContentID:    contentID,
+		CollectionID: collectionID,
+		Body:         strings.Join(parts, " "),
+	}
+}
+
+func indexContent(repo repository.SearchRepo, contentID, collectionID uint, fields []model.Field, forms localeForms) error {
+	return repo.Save(searchDocument(contentID, collectionID, fields, forms))
+}
+
+func highlight(snippet string) string {
+	return highlighter.Replace(html.EscapeString(snippet))
+}
+
+func (s *searchService) Search(query, alias string, offset, limit int, q dto.ApiQuery) ([]dto.SearchHit, int64, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, ErrMissingSearchQuery
+	}
+
+	var collectionID uint
+	if alias != "" {
+		collection, err := s.repos.Collection.FindByAlias(alias)
+		if err != nil {
+			return nil, 0, notFound(err, ErrCollectionNotFound)
+		}
+		collectionID = collection.ID
+	}
+
+	hits, total, err := s.repos.Search.Search(query, collectionID, offset, limit, visibilityOptions(q)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data := make([]dto.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		item, err := s.api.FindContentByID(hit.ContentID, q)
+		if errors.Is(err, ErrContentNotFound) {
+			// the index already applies visibility, an entry missing here
+			// was removed after it was matched and no longer counts
+			total--
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		data = append(data, dto.SearchHit{
+			Score:   hit.Rank,
+			Snippet: highlight(hit.Snippet),
+			Item:    item,
+		})
+	}
+
+	return data, total, nil
+}
+
+func (s *searchService) EnsureIndex() error {
+	indexed, err := s.repos.Search.Count()
+	if err != nil || indexed > 0 {
+		return err
+	}
+
+	collections, _, err := s.repos.Collection.List(1, 100000)
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		fields, err := s.repos.Field.FindByCollectionID(collection.ID)
+		if err != nil {
+			return err
+		}
+
+		contents, err := s.repos.Content.FindByCollectionID(collection.ID, 0, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, content := range contents {
+			forms := formsFromValues(fields, content.ContentValues)
+			if err := indexContent(s.repos.Search, content.ID, collection.ID, fields, forms); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
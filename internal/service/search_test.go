@@ -0,0 +1,149 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/janmarkuslanger/nuricms/testutils/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSearchable(t *testing.T, contents service.ContentService, collection *model.Collection, status model.ContentStatus, form map[string][]string) *model.Content {
+	t.Helper()
+	content, err := contents.CreateWithValues(dto.ContentWithValues{CollectionID: collection.ID, Status: status, FormData: form})
+	require.NoError(t, err)
+	return content
+}
+
+func searchedIDs(hits []dto.SearchHit) []uint {
+	ids := make([]uint, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.Item.ID)
+	}
+	return ids
+}
+
+func TestSearchService_IndexesTextFields(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	search := service.NewSearchService(repos, service.NewApiService(repos))
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"},
+		model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText},
+		model.Field{Name: "Body", Alias: "body", FieldType: model.FieldTypeRichText},
+		model.Field{Name: "Code", Alias: "code", FieldType: model.FieldTypeNumber},
+	)
+	news := testutils.SeedCollection(t, db, &model.Collection{Name: "News", Alias: "news"}, model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText})
+
+	post := createSearchable(t, contents, blog, model.ContentStatusPublished, map[string][]string{
+		"title": {"Sourdough basics"},
+		"body":  {`<p>Feed the <strong>starter</strong> daily &amp; keep it warm</p><script>var secret = 1</script>`},
+		"code":  {"4242"},
+	})
+	draft := createSearchable(t, contents, blog, model.ContentStatusDraft, map[string][]string{"title": {"Starter in the fridge"}})
+	kits := createSearchable(t, contents, news, model.ContentStatusPublished, map[string][]string{"title": {"Starter kits sold out"}})
+
+	hits, total, err := search.Search("starter", "", 0, 10, dto.ApiQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.ElementsMatch(t, []uint{post.ID, kits.ID}, searchedIDs(hits))
+
+	hits, _, err = search.Search("starter", "", 0, 10, dto.ApiQuery{Preview: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{post.ID, draft.ID, kits.ID}, searchedIDs(hits))
+
+	hits, _, err = search.Search("starter warm", "blog", 0, 10, dto.ApiQuery{})
+	require.NoError(t, err)
+	if assert.Len(t, hits, 1) {
+		assert.Equal(t, post.ID, hits[0].Item.ID)
+		assert.Contains(t, hits[0].Snippet, "<mark>starter</mark>")
+		assert.Contains(t, hits[0].Snippet, "&amp;")
+		assert.NotContains(t, hits[0].Snippet, "<strong>")
+	}
+
+	for _, query := range []string{"strong", "secret", "4242"} {
+		_, total, err = search.Search(query, "", 0, 10, dto.ApiQuery{Preview: true})
+		require.NoError(t, err)
+		assert.Zero(t, total, query)
+	}
+}
+
+func TestSearchService_FollowsEditsAndDeletes(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	search := service.NewSearchService(repos, service.NewApiService(repos))
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText})
+
+	post := createSearchable(t, contents, blog, model.ContentStatusPublished, map[string][]string{"title": {"Old title"}})
+
+	_, err := contents.EditWithValues(dto.ContentWithValues{
+		CollectionID: blog.ID,
+		ContentID:    post.ID,
+		FormData:     map[string][]string{"title": {"Fresh title"}},
+	})
+	require.NoError(t, err)
+
+	_, total, _ := search.Search("old", "", 0, 10, dto.ApiQuery{})
+	assert.Zero(t, total)
+	_, total, _ = search.Search("fresh", "", 0, 10, dto.ApiQuery{})
+	assert.Equal(t, int64(1), total)
+
+	require.NoError(t, contents.DeleteByID(post.ID))
+	_, total, _ = search.Search("fresh", "", 0, 10, dto.ApiQuery{})
+	assert.Zero(t, total)
+}
+
+func TestSearchService_EnsureIndex(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	search := service.NewSearchService(repos, service.NewApiService(repos))
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText})
+
+	post := createSearchable(t, contents, blog, model.ContentStatusPublished, map[string][]string{"title": {"Indexed later"}})
+	require.NoError(t, repos.Search.DeleteByContentID(post.ID))
+
+	_, total, _ := search.Search("indexed", "", 0, 10, dto.ApiQuery{})
+	assert.Zero(t, total)
+
+	require.NoError(t, search.EnsureIndex())
+	hits, _, err := search.Search("indexed", "", 0, 10, dto.ApiQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{post.ID}, searchedIDs(hits))
+}
+
+func TestSearchService_Errors(t *testing.T) {
+	repos := repository.NewSet(testutils.SetupTestDB(t))
+	search := service.NewSearchService(repos, service.NewApiService(repos))
+
+	_, _, err := search.Search("  ", "", 0, 10, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrMissingSearchQuery)
+
+	_, _, err = search.Search("x", "nope", 0, 10, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+}
+
+func TestSearchService_DropsVanishedHitsFromTotal(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	contents := service.NewContentService(repos, db)
+	search := service.NewSearchService(repos, service.NewApiService(repos))
+	blog := testutils.SeedCollection(t, db, &model.Collection{Name: "Blog", Alias: "blog"}, model.Field{Name: "Title", Alias: "title", FieldType: model.FieldTypeText})
+	post := createSearchable(t, contents, blog, model.ContentStatusPublished, map[string][]string{"title": {"Sourdough basics"}})
+
+	index := new(mockrepo.MockSearchRepo)
+	index.On("Search", "sourdough", uint(0), 0, 10).
+		Return([]repository.SearchHit{{ContentID: post.ID}, {ContentID: post.ID + 100}}, int64(2), nil)
+	repos.Search = index
+
+	hits, total, err := search.Search("sourdough", "", 0, 10, dto.ApiQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []uint{post.ID}, searchedIDs(hits))
+}
@@ -14,24 +14,45 @@ type Set struct {
 	FieldOption  FieldOptionService
 	Content      ContentService
 	ContentValue ContentValueService
+	Revision     ContentRevisionService
 	Asset        AssetService
 	User         UserService
 	Apikey       ApikeyService
 	Webhook      WebhookService
 	Api          ApiService
+	GraphQL      GraphQLService
+	OpenAPI      OpenAPIService
+	Sync         SyncService
+	Events       EventService
+	Search       SearchService
+	Locales      Locales
 }
 
-func NewSet(r *repository.Set, hr *plugin.HookRegistry, db *gorm.DB, env *env.Env, fs fs.FileOps) (*Set, error) {
+func NewSet(r *repository.Set, hr *plugin.HookRegistry, db *gorm.DB, env *env.Env, fs fs.FileOps, locales []string) (*Set, error) {
+	l, err := NewLocales(locales)
+	if err != nil {
+		return nil, err
+	}
+
+	api := NewApiService(r)
+
 	return &Set{
 		Collection:   NewCollectionService(r),
 		Field:        NewFieldService(r),
 		FieldOption:  NewFieldOptionService(r),
 		Content:      NewContentService(r, db),
 		ContentValue: NewContentValueService(r, hr),
+		Revision:     NewContentRevisionService(r),
 		Asset:        NewAssetService(r, fs),
 		User:         NewUserService(r, []byte(env.Secret)),
 		Apikey:       NewApikeyService(r),
 		Webhook:      NewWebhookService(r),
-		Api:          NewApiService(r),
+		Api:          api,
+		GraphQL:      NewGraphQLService(r, api),
+		OpenAPI:      NewOpenAPIService(r),
+		Sync:         NewSyncService(r),
+		Events:       NewEventService(r, 0),
+		Search:       NewSearchService(r, api),
+		Locales:      l,
 	}, nil
 }
@@ -22,15 +22,20 @@ func TestNewSet_Success(t *testing.T) {
 
 	mfo := &mockservices.MockFileOps{}
 
-	s, err := NewSet(repos, hr, testDB, &env, mfo)
+	s, err := NewSet(repos, hr, testDB, &env, mfo, []string{"en", "de"})
 	assert.NoError(t, err)
+	assert.Equal(t, "en", s.Locales.Default())
 	assert.NotNil(t, s.Collection)
 	assert.NotNil(t, s.Field)
 	assert.NotNil(t, s.Content)
 	assert.NotNil(t, s.ContentValue)
+	assert.NotNil(t, s.Revision)
 	assert.NotNil(t, s.Asset)
 	assert.NotNil(t, s.User)
 	assert.NotNil(t, s.Apikey)
 	assert.NotNil(t, s.Webhook)
 	assert.NotNil(t, s.Api)
+
+	_, err = NewSet(repos, hr, testDB, &env, mfo, []string{"en", "not a locale"})
+	assert.ErrorIs(t, err, ErrInvalidLocale)
 }
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+func parseSort(raw string, fields []model.Field) ([]repository.SortKey, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	byAlias := make(map[string]model.Field, len(fields))
+	for _, f := range fields {
+		byAlias[f.Alias] = f
+	}
+
+	var keys []repository.SortKey
+	for _, part := range strings.Split(raw, ",") {
+		name := strings.TrimSpace(part)
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "-"), "+")
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty sort key", ErrInvalidSort)
+		}
+
+		if _, ok := repository.SystemSortColumns[name]; ok {
+			keys = append(keys, repository.SortKey{System: name, Desc: desc})
+			continue
+		}
+
+		field, ok := byAlias[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown sort key %q", ErrInvalidSort, name)
+		}
+		keys = append(keys, repository.SortKey{Field: &field, Desc: desc})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+)
+
+var ErrInvalidSyncToken = errors.New("invalid sync token")
+
+const (
+	SyncReasonDeleted = "deleted"
+	SyncReasonHidden  = "hidden"
+)
+
+// syncLag keeps the end of a sync window behind the clock, so that rows stamped
+// by transactions still running at that moment are in the next window and not
+// lost between two windows.
+const syncLag = 2 * time.Second
+
+// syncToken carries the window [since, until) and, while paging through it,
+// the last content ID handed out. A window closes syncLag before the request,
+// the next one starts where it ended.
+type syncToken struct {
+	since time.Time
+	until time.Time
+	after uint
+}
+
+func encodeSyncToken(t syncToken) string {
+	var since, until int64
+	if !t.since.IsZero() {
+		since = t.since.UnixNano()
+	}
+	if !t.until.IsZero() {
+		until = t.until.UnixNano()
+	}
+	raw := fmt.Sprintf("sync:%d:%d:%d", since, until, t.after)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSyncToken(token string) (syncToken, error) {
+	var t syncToken
+	if token == "" {
+		return t, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return t, ErrInvalidSyncToken
+	}
+
+	var since, until int64
+	if _, err := fmt.Sscanf(string(raw), "sync:%d:%d:%d", &since, &until, &t.after); err != nil || since < 0 || until < 0 || since+until == 0 {
+		return t, ErrInvalidSyncToken
+	}
+
+	if since > 0 {
+		t.since = time.Unix(0, since).UTC()
+	}
+	if until > 0 {
+		t.until = time.Unix(0, until).UTC()
+	}
+	return t, nil
+}
+
+type SyncService interface {
+	Changes(token string, aliases []string, limit int, q dto.ApiQuery) (dto.SyncResponse, error)
+}
+
+type syncService struct {
+	repos *repository.Set
+	now   func() time.Time
+	lag   time.Duration
+}
+
+func NewSyncService(repos *repository.Set) SyncService {
+	return &syncService{repos: repos, now: time.Now, lag: syncLag}
+}
+
+func (s *syncService) collectionIDs(aliases []string) ([]uint, error) {
+	ids := make([]uint, 0, len(aliases))
+	for _, alias := range aliases {
+		collection, err := s.repos.Collection.FindByAlias(alias)
+		if err != nil {
+			return nil, notFound(err, ErrCollectionNotFound)
+		}
+		ids = append(ids, collection.ID)
+	}
+	return ids, nil
+}
+
+func (s *syncService) Changes(token string, aliases []string, limit int, q dto.ApiQuery) (dto.SyncResponse, error) {
+	resp := dto.SyncResponse{
+		Items:   []dto.ContentItemResponse{},
+		Deleted: []dto.SyncTombstone{},
+	}
+
+	t, err := decodeSyncToken(token)
+	if err != nil {
+		return resp, err
+	}
+
+	collectionIDs, err := s.collectionIDs(aliases)
+	if err != nil {
+		return resp, err
+	}
+
+	if t.until.IsZero() {
+		t.until = s.now().UTC().Add(-s.lag)
+		if t.until.Before(t.since) {
+			t.until = t.since
+		}
+	}
+
+	var fetch int
+	if limit > 0 {
+		fetch = limit + 1
+	}
+
+	content, err := s.repos.Content.FindChangedBetween(t.since, t.until, collectionIDs, t.after, fetch, projectionOptions(q)...)
+	if err != nil {
+		return resp, err
+	}
+
+	if limit > 0 && len(content) > limit {
+		content = content[:limit]
+		resp.HasMore = true
+	}
+
+	resolver := newContentResolver(s.repos, q)
+	for _, ce := range content {
+		tombstone := dto.SyncTombstone{
+			ID: ce.ID,
+			Collection: dto.CollectionResponse{
+				ID:    ce.CollectionID,
+				Name:  ce.Collection.Name,
+				Alias: ce.Collection.Alias,
+			},
+		}
+
+		switch {
+		case ce.DeletedAt.Valid:
+			tombstone.Reason = SyncReasonDeleted
+			tombstone.DeletedAt = ce.DeletedAt.Time
+			resp.Deleted = append(resp.Deleted, tombstone)
+		case !q.Preview && !ce.IsVisibleAt(t.until):
+			if !t.since.IsZero() {
+				tombstone.Reason = SyncReasonHidden
+				tombstone.DeletedAt = t.until
+				resp.Deleted = append(resp.Deleted, tombstone)
+			}
+		default:
+			if q.Fields == nil {
+				resolver.remember(&ce)
+			}
+			resp.Items = append(resp.Items, resolver.prepare(&ce, "", 0, nil))
+		}
+	}
+
+	if resp.HasMore {
+		t.after = content[len(content)-1].ID
+		resp.NextToken = encodeSyncToken(t)
+	} else {
+		resp.NextToken = encodeSyncToken(syncToken{since: t.until})
+	}
+
+	return resp, nil
+}
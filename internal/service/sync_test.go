@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janmarkuslanger/nuricms/internal/dto"
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/service"
+	"github.com/janmarkuslanger/nuricms/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func syncIDs(resp dto.SyncResponse) (items []uint, deleted map[uint]string) {
+	deleted = map[uint]string{}
+	for _, item := range resp.Items {
+		items = append(items, item.ID)
+	}
+	for _, d := range resp.Deleted {
+		deleted[d.ID] = d.Reason
+	}
+	return items, deleted
+}
+
+func TestSyncService_FullAndIncrementalSync(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	person, company, city := seedRelations(t, db)
+	s := service.NewSyncServiceWithLag(repos, 0)
+
+	full, err := s.Changes("", nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	items, deleted := syncIDs(full)
+	assert.ElementsMatch(t, []uint{person.ID, company.ID, city.ID}, items)
+	assert.Empty(t, deleted)
+	assert.False(t, full.HasMore)
+	assert.NotEmpty(t, full.NextToken)
+
+	empty, err := s.Changes(full.NextToken, nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Items)
+	assert.Empty(t, empty.Deleted)
+
+	time.Sleep(5 * time.Millisecond)
+	cityFields, _ := repos.Field.FindByCollectionID(city.CollectionID)
+	repos.ContentValue.Create(&model.ContentValue{ContentID: city.ID, FieldID: cityFields[0].ID, Value: "Berlin"})
+	repos.Content.DeleteByID(company.ID)
+	repos.Content.UpdateStatus(person.ID, model.ContentStatusDraft)
+	added := &model.Content{CollectionID: city.CollectionID, Status: model.ContentStatusPublished}
+	repos.Content.Create(added)
+
+	delta, err := s.Changes(empty.NextToken, nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	items, deleted = syncIDs(delta)
+	assert.ElementsMatch(t, []uint{city.ID, added.ID}, items)
+	assert.Equal(t, map[uint]string{company.ID: service.SyncReasonDeleted, person.ID: service.SyncReasonHidden}, deleted)
+
+	again, err := s.Changes(delta.NextToken, nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, again.Items)
+	assert.Empty(t, again.Deleted)
+}
+
+func TestSyncService_ScheduledPublishingShowsUp(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewSyncServiceWithLag(repos, 0)
+
+	publishAt := time.Now().Add(20 * time.Millisecond)
+	scheduled := &model.Content{CollectionID: city.CollectionID, Status: model.ContentStatusDraft, PublishAt: &publishAt}
+	repos.Content.Create(scheduled)
+
+	full, err := s.Changes("", []string{"cities"}, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	items, _ := syncIDs(full)
+	assert.Equal(t, []uint{city.ID}, items)
+
+	time.Sleep(30 * time.Millisecond)
+	delta, err := s.Changes(full.NextToken, []string{"cities"}, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	items, _ = syncIDs(delta)
+	assert.Equal(t, []uint{scheduled.ID}, items)
+}
+
+func TestSyncService_PagesWithinOneWindow(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	_, _, city := seedRelations(t, db)
+	s := service.NewSyncServiceWithLag(repos, 0)
+
+	first, err := s.Changes("", nil, 2, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	assert.Len(t, first.Items, 2)
+
+	repos.Content.Create(&model.Content{CollectionID: city.CollectionID, Status: model.ContentStatusPublished})
+
+	second, err := s.Changes(first.NextToken, nil, 2, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.False(t, second.HasMore)
+	assert.Len(t, second.Items, 1, "content created after the window started belongs to the next window")
+
+	next, err := s.Changes(second.NextToken, nil, 2, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, next.Items, 1)
+}
+
+func TestSyncService_Errors(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	seedRelations(t, db)
+	s := service.NewSyncServiceWithLag(repos, 0)
+
+	_, err := s.Changes("not-a-token", nil, 0, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrInvalidSyncToken)
+
+	_, err = s.Changes("", []string{"nope"}, 0, dto.ApiQuery{})
+	assert.ErrorIs(t, err, service.ErrCollectionNotFound)
+}
+
+func TestSyncService_DeltaLeavesOutReplacedValues(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	content := service.NewContentService(repos, db)
+	s := service.NewSyncServiceWithLag(repos, 0)
+
+	col := &model.Collection{Name: "Cities", Alias: "cities"}
+	repos.Collection.Create(col)
+	repos.Field.Create(&model.Field{Name: "Name", Alias: "name", FieldType: model.FieldTypeText, CollectionID: col.ID, Localized: true})
+	repos.Field.Create(&model.Field{Name: "Tags", Alias: "tags", FieldType: model.FieldTypeText, CollectionID: col.ID, IsList: true})
+
+	city, err := content.CreateWithValues(dto.ContentWithValues{
+		CollectionID: col.ID,
+		Status:       model.ContentStatusPublished,
+		FormData:     map[string][]string{"name": {"Hamburg"}, "tags": {"port", "north"}},
+		Translations: map[string]map[string][]string{"de": {"name": {"Hamburg (de)"}}},
+	})
+	assert.NoError(t, err)
+
+	full, err := s.Changes("", nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	_, err = content.EditWithValues(dto.ContentWithValues{
+		ContentID:    city.ID,
+		CollectionID: col.ID,
+		Status:       model.ContentStatusPublished,
+		FormData:     map[string][]string{"name": {"Berlin"}, "tags": {"capital"}},
+		Translations: map[string]map[string][]string{"de": {}},
+	})
+	assert.NoError(t, err)
+
+	delta, err := s.Changes(full.NextToken, nil, 0, dto.ApiQuery{Locales: []string{"de", ""}})
+	assert.NoError(t, err)
+	if !assert.Len(t, delta.Items, 1) {
+		return
+	}
+
+	values := delta.Items[0].Values
+	assert.Equal(t, "Berlin", values["name"].(dto.ContentValueResponse).Value, "the deleted translation is not picked")
+	tags := values["tags"].([]any)
+	if assert.Len(t, tags, 1, "replaced list values are left out") {
+		assert.Equal(t, "capital", tags[0].(dto.ContentValueResponse).Value)
+	}
+}
+
+func TestSyncService_WindowLagsBehindTheClock(t *testing.T) {
+	db := testutils.SetupTestDB(t)
+	repos := repository.NewSet(db)
+	content := service.NewContentService(repos, db)
+	s := service.NewSyncServiceWithLag(repos, 50*time.Millisecond)
+
+	col := &model.Collection{Name: "Cities", Alias: "cities"}
+	repos.Collection.Create(col)
+	repos.Field.Create(&model.Field{Name: "Name", Alias: "name", FieldType: model.FieldTypeText, CollectionID: col.ID})
+
+	_, err := content.CreateWithValues(dto.ContentWithValues{
+		CollectionID: col.ID,
+		Status:       model.ContentStatusPublished,
+		FormData:     map[string][]string{"name": {"Hamburg"}},
+	})
+	assert.NoError(t, err)
+
+	first, err := s.Changes("", nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, first.Items, "a change within the lag waits for the next window")
+
+	time.Sleep(60 * time.Millisecond)
+	next, err := s.Changes(first.NextToken, nil, 0, dto.ApiQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, next.Items, 1)
+}
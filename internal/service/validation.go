@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/utils"
+)
+
+type ValidationErrors map[string][]string
+
+func (e ValidationErrors) Error() string {
+	aliases := make([]string, 0, len(e))
+	for alias := range e {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	parts := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		parts = append(parts, alias+": "+strings.Join(e[alias], ", "))
+	}
+
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+	var verrs ValidationErrors
+	ok := errors.As(err, &verrs)
+	return verrs, ok
+}
+
+func validateFormData(fields []model.Field, formData map[string][]string) error {
+	verrs := make(ValidationErrors)
+	for _, f := range fields {
+		if errs := f.Validate(formData[f.Alias]); len(errs) > 0 {
+			verrs[f.Alias] = errs
+		}
+	}
+
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	return nil
+}
+
+// validateUnique checks the values of unique fields against the other entries
+// of the collection, per locale. Run it inside the saving transaction.
+func validateUnique(repo repository.ContentValueRepo, contentID uint, fields []model.Field, forms localeForms) error {
+	verrs := make(ValidationErrors)
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			if !f.Unique() {
+				continue
+			}
+
+			for _, v := range forms[locale][f.Alias] {
+				if strings.TrimSpace(v) == "" {
+					continue
+				}
+
+				taken, err := repo.ValueExists(f.ID, locale, v, contentID)
+				if err != nil {
+					return err
+				}
+
+				if taken {
+					key := f.Alias
+					if locale != "" {
+						key = TranslationKey(f.Alias, locale)
+					}
+					verrs[key] = []string{"must be unique, another entry already uses " + strconv.Quote(v)}
+					break
+				}
+			}
+		}
+	}
+
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	return nil
+}
+
+// validateReferences checks that Collection fields restricted to target
+// collections only reference entries of those collections.
+func validateReferences(repo repository.ContentRepo, fields []model.Field, forms localeForms) error {
+	verrs := make(ValidationErrors)
+	for _, locale := range forms.locales() {
+		for _, f := range fieldsFor(fields, locale) {
+			if f.FieldType != model.FieldTypeCollection || f.TargetCollections == "" {
+				continue
+			}
+
+			var ids []uint
+			for _, v := range forms[locale][f.Alias] {
+				if strings.TrimSpace(v) == "" {
+					continue
+				}
+				id, _ := utils.StringToUint(v)
+				ids = append(ids, id)
+			}
+			if len(ids) == 0 {
+				continue
+			}
+
+			collections, err := repo.CollectionIDsByIDs(ids)
+			if err != nil {
+				return err
+			}
+
+			for _, v := range forms[locale][f.Alias] {
+				if strings.TrimSpace(v) == "" {
+					continue
+				}
+
+				id, _ := utils.StringToUint(v)
+				if collectionID, ok := collections[id]; ok && f.AllowsTarget(collectionID) {
+					continue
+				}
+
+				key := f.Alias
+				if locale != "" {
+					key = TranslationKey(f.Alias, locale)
+				}
+				verrs[key] = append(verrs[key], "entry "+strconv.Quote(v)+" is not in the allowed collections")
+			}
+		}
+	}
+
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	return nil
+}
@@ -13,15 +13,17 @@ import (
 	"github.com/janmarkuslanger/nuricms/internal/modules/user"
 	"github.com/janmarkuslanger/nuricms/internal/modules/webhook"
 	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/scheduler"
 	"github.com/janmarkuslanger/nuricms/internal/server"
 	"github.com/janmarkuslanger/nuricms/internal/service"
 	"github.com/janmarkuslanger/nuricms/pkg/config"
 )
 
 type App struct {
-	Server   *server.Server
-	Services *service.Set
-	Config   *config.Config
+	Server    *server.Server
+	Services  *service.Set
+	Config    *config.Config
+	Scheduler *scheduler.Scheduler
 }
 
 func SetupApp(opts config.Config, envs env.EnvSource) (*App, error) {
@@ -40,12 +42,16 @@ func SetupApp(opts config.Config, envs env.EnvSource) (*App, error) {
 
 	fs := service.OsFileOps{}
 
-	services, err := service.NewSet(repos, hooks, db, env, fs)
+	services, err := service.NewSet(repos, hooks, db, env, fs, conf.Locales)
 	if err != nil {
 		return nil, err
 	}
 	InitAdminUser(services.User)
 
+	if err := services.Search.EnsureIndex(); err != nil {
+		return nil, err
+	}
+
 	s := server.NewServer()
 	ctrl := []server.Controller{
 		collection.NewController(services),
@@ -55,11 +61,14 @@ func SetupApp(opts config.Config, envs env.EnvSource) (*App, error) {
 		asset.NewController(services),
 		user.NewController(services),
 		home.NewController(services),
-		api.NewController(services),
+		api.NewController(services, conf.MaxPerPage),
 		apikey.NewController(services),
 		webhook.NewController(services),
 	}
 	InitController(ctrl, s)
 
-	return &App{Server: s, Services: services, Config: &conf}, nil
+	sch := scheduler.New(services, conf.SchedulerInterval)
+	sch.Start()
+
+	return &App{Server: s, Services: services, Config: &conf, Scheduler: sch}, nil
 }
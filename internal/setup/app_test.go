@@ -30,5 +30,7 @@ func TestSetupApp(t *testing.T) {
 	require.NotNil(t, app)
 	require.NotNil(t, app.Server)
 	require.NotNil(t, app.Services)
+	require.NotNil(t, app.Scheduler)
+	app.Scheduler.Stop()
 	require.Equal(t, "7777", app.Config.Port)
 }
@@ -27,9 +27,11 @@ func MigrateModels(db *gorm.DB) {
 		&model.FieldOption{},
 		&model.Content{},
 		&model.ContentValue{},
+		&model.ContentRevision{},
 		&model.Asset{},
 		&model.User{},
 		&model.Apikey{},
 		&model.Webhook{},
+		&model.SearchDocument{},
 	)
 }
@@ -1,6 +1,8 @@
 package setup
 
 import (
+	"time"
+
 	"github.com/janmarkuslanger/nuricms/pkg/config"
 	"gorm.io/driver/sqlite"
 )
@@ -15,5 +17,13 @@ func SetDefaultConfig(conf config.Config) config.Config {
 		conf.Dialector = &dl
 	}
 
+	if conf.SchedulerInterval <= 0 {
+		conf.SchedulerInterval = time.Minute
+	}
+
+	if conf.MaxPerPage <= 0 {
+		conf.MaxPerPage = 100
+	}
+
 	return conf
 }
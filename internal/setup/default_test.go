@@ -2,6 +2,7 @@ package setup_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/janmarkuslanger/nuricms/internal/setup"
 	"github.com/janmarkuslanger/nuricms/pkg/config"
@@ -16,4 +17,6 @@ func TestSetDefaultConfig_EmptyConfig(t *testing.T) {
 	assert.Equal(t, conf.Port, "8080")
 	var hooks []plugin.HookPlugin
 	assert.Equal(t, conf.HookPlugins, hooks)
+	assert.Equal(t, time.Minute, conf.SchedulerInterval)
+	assert.Equal(t, 100, conf.MaxPerPage)
 }
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/janmarkuslanger/nuricms/internal/server"
+)
+
+func RenderJSON(ctx server.Context, data any, statusCode int) {
+	ctx.Writer.Header().Set("Content-Type", "application/json")
+	ctx.Writer.WriteHeader(statusCode)
+	_ = json.NewEncoder(ctx.Writer).Encode(data)
+}
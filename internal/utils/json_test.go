@@ -0,0 +1,22 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/server"
+	"github.com/janmarkuslanger/nuricms/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx := server.Context{Request: httptest.NewRequest("GET", "/", nil), Writer: w}
+
+	utils.RenderJSON(ctx, map[string]any{"items": []int{1, 2}}, http.StatusOK)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"items":[1,2]}`, w.Body.String())
+}
@@ -6,13 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	APIVersionLegacy = 1
+	APIVersionTyped  = 2
+)
+
 type ApiClient struct {
 	BaseURL    string
 	ApiKey     string
+	APIVersion int
 	HTTPClient *http.Client
 }
 
@@ -36,8 +43,32 @@ type MetaData struct {
 }
 
 type Pagination struct {
-	PerPage int `json:"per_page"`
-	Page    int `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Page       int    `json:"page"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Next       string `json:"next"`
+	Prev       string `json:"prev"`
+	NextCursor string `json:"next_cursor"`
+}
+
+type ErrorDetail struct {
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Fields  map[string][]string `json:"fields,omitempty"`
+}
+
+type ApiError struct {
+	StatusCode int
+	Status     string
+	Detail     ErrorDetail
+}
+
+func (e *ApiError) Error() string {
+	if e.Detail.Code == "" {
+		return fmt.Sprintf("API request failed: %s", e.Status)
+	}
+	return fmt.Sprintf("API request failed: %s: %s (%s)", e.Status, e.Detail.Message, e.Detail.Code)
 }
 
 type CollectionInfo struct {
@@ -61,6 +92,9 @@ func (c *ApiClient) get(path string, target any) error {
 	}
 	req.Header.Set("X-API-Key", c.ApiKey)
 	req.Header.Set("Accept", "application/json")
+	if c.APIVersion > 0 {
+		req.Header.Set("X-API-Version", strconv.Itoa(c.APIVersion))
+	}
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -69,7 +103,14 @@ func (c *ApiClient) get(path string, target any) error {
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed: %s", res.Status)
+		apiErr := &ApiError{StatusCode: res.StatusCode, Status: res.Status}
+		var body struct {
+			Error *ErrorDetail `json:"error"`
+		}
+		if json.NewDecoder(res.Body).Decode(&body) == nil && body.Error != nil {
+			apiErr.Detail = *body.Error
+		}
+		return apiErr
 	}
 
 	return json.NewDecoder(res.Body).Decode(target)
@@ -95,6 +136,21 @@ func (c *ApiClient) FindContentByCollectionAlias(alias string, page, perPage int
 	return resp.Data, resp.Pagination, nil
 }
 
+func (c *ApiClient) FindContentByCollectionAliasAfter(alias, cursor string, perPage int) ([]ContentItem, *Pagination, error) {
+	query := url.Values{}
+	query.Set("perPage", strconv.Itoa(perPage))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	path := fmt.Sprintf("/api/collections/%s/content?%s", url.PathEscape(alias), query.Encode())
+	var resp ApiResponse[[]ContentItem]
+	if err := c.get(path, &resp); err != nil {
+		return nil, nil, err
+	}
+	return resp.Data, resp.Pagination, nil
+}
+
 func (c *ApiClient) FindContentByCollectionAndFieldValue(alias, field, value string, page, perPage int) ([]ContentItem, *Pagination, error) {
 	path := fmt.Sprintf("/api/collections/%s/content/filter?field=%s&value=%s&page=%d&perPage=%d",
 		url.PathEscape(alias), url.QueryEscape(field), url.QueryEscape(value), page, perPage)
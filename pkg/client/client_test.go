@@ -65,6 +65,27 @@ func TestFindContentByID_Non200(t *testing.T) {
 	}
 }
 
+func TestFindContentByID_ApiError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/content/7", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"error":{"code":"content_not_found","message":"content not found"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	api := client.New(srv.URL, "k")
+	_, err := api.FindContentByID(7)
+
+	var apiErr *client.ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected ApiError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Detail.Code != "content_not_found" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+}
+
 func TestFindContentByID_BadJSON(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/content/1", func(w http.ResponseWriter, r *http.Request) {
@@ -234,3 +255,72 @@ func TestHeadersAreSet(t *testing.T) {
 		t.Fatalf("unexpected headers: X-API-Key=%q Accept=%q", gotAPIKey, gotAccept)
 	}
 }
+
+func TestAPIVersionHeader(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/content/1", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-API-Version"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"success": true, "data": {"id": 1, "values": {"count": 42}}}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	api := client.New(srv.URL, "secret")
+	if _, err := api.FindContentByID(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	api.APIVersion = client.APIVersionTyped
+	item, err := api.FindContentByID(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got[0] != "" || got[1] != "2" {
+		t.Fatalf("unexpected version headers: %v", got)
+	}
+	if item.Values["count"] != float64(42) {
+		t.Fatalf("expected typed number, got %#v", item.Values["count"])
+	}
+}
+
+func TestFindContentByCollectionAliasAfter(t *testing.T) {
+	var cursors []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/collections/blog/content", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		cursors = append(cursors, q.Get("cursor"))
+		if q.Get("perPage") != "2" || q.Has("page") {
+			t.Fatalf("unexpected query: %v", q)
+		}
+		if q.Get("cursor") == "" {
+			io.WriteString(w, `{"success": true, "data": [{"id":1},{"id":2}], "pagination":{"per_page":2,"total":3,"total_pages":2,"next_cursor":"abc"}}`)
+			return
+		}
+		io.WriteString(w, `{"success": true, "data": [{"id":3}], "pagination":{"per_page":2,"total":3,"total_pages":2}}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	api := client.New(srv.URL, "k")
+	items, pag, err := api.FindContentByCollectionAliasAfter("blog", "", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || pag.NextCursor != "abc" || pag.Total != 3 || pag.TotalPages != 2 {
+		t.Fatalf("unexpected first page: %v %#v", items, pag)
+	}
+
+	items, pag, err = api.FindContentByCollectionAliasAfter("blog", pag.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || pag.NextCursor != "" {
+		t.Fatalf("unexpected last page: %v %#v", items, pag)
+	}
+	if len(cursors) != 2 || cursors[1] != "abc" {
+		t.Fatalf("unexpected cursors: %v", cursors)
+	}
+}
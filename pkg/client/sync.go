@@ -0,0 +1,68 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Tombstone struct {
+	ID         uint           `json:"id"`
+	Collection CollectionInfo `json:"collection"`
+	Reason     string         `json:"reason"`
+	DeletedAt  time.Time      `json:"deleted_at"`
+}
+
+type SyncPage struct {
+	Items     []ContentItem `json:"items"`
+	Deleted   []Tombstone   `json:"deleted"`
+	NextToken string        `json:"next_token"`
+	HasMore   bool          `json:"has_more"`
+}
+
+func (c *ApiClient) SyncPage(token string, collections []string, perPage int) (*SyncPage, error) {
+	query := url.Values{}
+	if token != "" {
+		query.Set("since", token)
+	}
+	if len(collections) > 0 {
+		query.Set("collections", strings.Join(collections, ","))
+	}
+	if perPage > 0 {
+		query.Set("perPage", strconv.Itoa(perPage))
+	}
+
+	path := "/api/sync"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp ApiResponse[*SyncPage]
+	if err := c.get(path, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty sync response")
+	}
+	return resp.Data, nil
+}
+
+func (c *ApiClient) Sync(token string, collections []string, apply func(*SyncPage) error) (string, error) {
+	for {
+		page, err := c.SyncPage(token, collections, 0)
+		if err != nil {
+			return token, err
+		}
+
+		if err := apply(page); err != nil {
+			return token, err
+		}
+
+		token = page.NextToken
+		if !page.HasMore {
+			return token, nil
+		}
+	}
+}
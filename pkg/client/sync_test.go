@@ -0,0 +1,85 @@
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/pkg/client"
+)
+
+func TestSync_LoopsUntilDone(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"success":true,"data":{"items":[{"id":1}],"deleted":[],"next_token":"t1","has_more":true}}`,
+		"t1": `{"success":true,"data":{"items":[],"deleted":[{"id":2,"reason":"deleted"}],"next_token":"t2","has_more":false}}`,
+	}
+
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/sync" || r.URL.Query().Get("collections") != "blog,news" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		since := r.URL.Query().Get("since")
+		seen = append(seen, since)
+		fmt.Fprint(w, pages[since])
+	}))
+	defer srv.Close()
+
+	var items, deleted int
+	token, err := client.New(srv.URL, "k").Sync("", []string{"blog", "news"}, func(p *client.SyncPage) error {
+		items += len(p.Items)
+		deleted += len(p.Deleted)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "t2" || items != 1 || deleted != 1 {
+		t.Fatalf("unexpected result: token=%q items=%d deleted=%d", token, items, deleted)
+	}
+	if len(seen) != 2 || seen[1] != "t1" {
+		t.Fatalf("unexpected requests: %v", seen)
+	}
+}
+
+func TestSync_ApplyErrorKeepsLastToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("since") == "start" {
+			fmt.Fprint(w, `{"success":true,"data":{"items":[],"deleted":[],"next_token":"t1","has_more":true}}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"items":[{"id":3}],"deleted":[],"next_token":"t2","has_more":false}}`)
+	}))
+	defer srv.Close()
+
+	calls := 0
+	token, err := client.New(srv.URL, "k").Sync("start", nil, func(p *client.SyncPage) error {
+		calls++
+		if calls == 2 {
+			return errors.New("store down")
+		}
+		return nil
+	})
+
+	if err == nil || token != "t1" {
+		t.Fatalf("expected error with token t1, got %q, %v", token, err)
+	}
+}
+
+func TestSync_RequestError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success":false,"error":{"code":"invalid_token","message":"invalid sync token"}}`)
+	}))
+	defer srv.Close()
+
+	token, err := client.New(srv.URL, "k").Sync("broken", nil, func(p *client.SyncPage) error { return nil })
+
+	var apiErr *client.ApiError
+	if !errors.As(err, &apiErr) || apiErr.Detail.Code != "invalid_token" || token != "broken" {
+		t.Fatalf("unexpected result: %q, %v", token, err)
+	}
+}
@@ -1,12 +1,19 @@
 package config
 
 import (
+	"time"
+
 	"github.com/janmarkuslanger/nuricms/pkg/plugin"
 	"gorm.io/gorm"
 )
 
 type Config struct {
-	Port        string
-	HookPlugins []plugin.HookPlugin
-	Dialector   *gorm.Dialector
+	Port              string
+	HookPlugins       []plugin.HookPlugin
+	Dialector         *gorm.Dialector
+	SchedulerInterval time.Duration
+	MaxPerPage        int
+	// Locales lists the content locales like "en", "de" or "de-AT", the
+	// first one is the default locale.
+	Locales []string
 }
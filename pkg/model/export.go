@@ -5,3 +5,4 @@ import "github.com/janmarkuslanger/nuricms/internal/model"
 type Content = model.Content
 type ContentValue = model.ContentValue
 type Collection = model.Collection
+type ContentStatus = model.ContentStatus
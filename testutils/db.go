@@ -21,10 +21,12 @@ func CreateTestDB() (*gorm.DB, error) {
 		&model.FieldOption{},
 		&model.Content{},
 		&model.ContentValue{},
+		&model.ContentRevision{},
 		&model.Asset{},
 		&model.Webhook{},
 		&model.Apikey{},
 		&model.User{},
+		&model.SearchDocument{},
 	)
 	if err != nil {
 		return nil, err
@@ -41,7 +43,9 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 
 	t.Cleanup(func() {
 		models := []interface{}{
+			&model.SearchDocument{},
 			&model.ContentValue{},
+			&model.ContentRevision{},
 			&model.Content{},
 			&model.Asset{},
 			&model.Webhook{},
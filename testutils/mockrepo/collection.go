@@ -0,0 +1,55 @@
+package mockrepo
+
+import (
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockCollectionRepo struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepo) Create(c *model.Collection) error {
+	return m.Called(c).Error(0)
+}
+
+func (m *MockCollectionRepo) Save(c *model.Collection) error {
+	return m.Called(c).Error(0)
+}
+
+func (m *MockCollectionRepo) Delete(c *model.Collection) error {
+	return m.Called(c).Error(0)
+}
+
+func (m *MockCollectionRepo) FindByID(id uint, opts ...base.QueryOption) (*model.Collection, error) {
+	args := m.Called(id)
+	if val := args.Get(0); val != nil {
+		return val.(*model.Collection), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCollectionRepo) List(page, pageSize int, opts ...base.QueryOption) ([]model.Collection, int64, error) {
+	args := m.Called(page, pageSize)
+	return args.Get(0).([]model.Collection), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCollectionRepo) FindByAlias(alias string) (*model.Collection, error) {
+	args := m.Called(alias)
+	if val := args.Get(0); val != nil {
+		return val.(*model.Collection), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCollectionRepo) FindComponents() ([]model.Collection, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepo) WithTx(tx *gorm.DB) repository.CollectionRepo {
+	return m
+}
@@ -1,6 +1,8 @@
 package mockrepo
 
 import (
+	"time"
+
 	"github.com/janmarkuslanger/nuricms/internal/repository"
 	"github.com/janmarkuslanger/nuricms/internal/repository/base"
 	"github.com/janmarkuslanger/nuricms/pkg/model"
@@ -47,12 +49,45 @@ func (m *MockContentRepo) DeleteByID(id uint) error {
 	return args.Error(0)
 }
 
-func (m *MockContentRepo) FindByCollectionID(collectionID uint, offset, limit int) ([]model.Content, error) {
+func (m *MockContentRepo) UpdateStatus(id uint, status model.ContentStatus) error {
+	return m.Called(id, status).Error(0)
+}
+
+func (m *MockContentRepo) UpdateSchedule(id uint, publishAt, unpublishAt *time.Time) error {
+	return m.Called(id, publishAt, unpublishAt).Error(0)
+}
+
+func (m *MockContentRepo) FindDueForPublish(now time.Time) ([]model.Content, error) {
+	args := m.Called(now)
+	return args.Get(0).([]model.Content), args.Error(1)
+}
+
+func (m *MockContentRepo) FindDueForUnpublish(now time.Time) ([]model.Content, error) {
+	args := m.Called(now)
+	return args.Get(0).([]model.Content), args.Error(1)
+}
+
+func (m *MockContentRepo) FindByCollectionID(collectionID uint, offset, limit int, opts ...base.QueryOption) ([]model.Content, error) {
 	args := m.Called(collectionID, offset, limit)
 	return args.Get(0).([]model.Content), args.Error(1)
 }
 
-func (m *MockContentRepo) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int) ([]model.Content, int64, error) {
+func (m *MockContentRepo) CountByCollectionID(collectionID uint, opts ...base.QueryOption) (int64, error) {
+	args := m.Called(collectionID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockContentRepo) LastModifiedByCollectionID(collectionID uint, now time.Time) (time.Time, error) {
+	args := m.Called(collectionID, now)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockContentRepo) FindChangedBetween(since, until time.Time, collectionIDs []uint, afterID uint, limit int, opts ...base.QueryOption) ([]model.Content, error) {
+	args := m.Called(since, until, collectionIDs, afterID, limit)
+	return args.Get(0).([]model.Content), args.Error(1)
+}
+
+func (m *MockContentRepo) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int, opts ...base.QueryOption) ([]model.Content, int64, error) {
 	args := m.Called(collectionID, page, pageSize)
 	return args.Get(0).([]model.Content), args.Get(1).(int64), args.Error(2)
 }
@@ -62,7 +97,12 @@ func (m *MockContentRepo) ListWithDisplayContentValue() ([]model.Content, error)
 	return args.Get(0).([]model.Content), args.Error(1)
 }
 
-func (m *MockContentRepo) FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int) ([]model.Content, int, error) {
+func (m *MockContentRepo) CollectionIDsByIDs(ids []uint) (map[uint]uint, error) {
+	args := m.Called(ids)
+	return args.Get(0).(map[uint]uint), args.Error(1)
+}
+
+func (m *MockContentRepo) FindByCollectionAndFieldValue(collectionID uint, fieldAlias, value string, offset, limit int, opts ...base.QueryOption) ([]model.Content, int, error) {
 	args := m.Called(collectionID, fieldAlias, value, offset, limit)
 	return args.Get(0).([]model.Content), args.Int(1), args.Error(2)
 }
@@ -0,0 +1,35 @@
+package mockrepo
+
+import (
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockContentRevisionRepo struct {
+	mock.Mock
+}
+
+func (m *MockContentRevisionRepo) Create(entity *model.ContentRevision) error {
+	return m.Called(entity).Error(0)
+}
+
+func (m *MockContentRevisionRepo) FindByID(id uint, opts ...base.QueryOption) (*model.ContentRevision, error) {
+	args := m.Called(id)
+	if val := args.Get(0); val != nil {
+		return val.(*model.ContentRevision), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockContentRevisionRepo) FindByContentID(contentID uint) ([]model.ContentRevision, error) {
+	args := m.Called(contentID)
+	return args.Get(0).([]model.ContentRevision), args.Error(1)
+}
+
+func (m *MockContentRevisionRepo) WithTx(tx *gorm.DB) repository.ContentRevisionRepo {
+	m.Called(tx)
+	return m
+}
@@ -42,6 +42,16 @@ func (m *MockContentValueRepo) FindByContentID(cID uint) ([]model.ContentValue,
 	return args.Get(0).([]model.ContentValue), args.Error(1)
 }
 
+func (m *MockContentValueRepo) ValueExists(fieldID uint, locale, value string, excludeContentID uint) (bool, error) {
+	args := m.Called(fieldID, locale, value, excludeContentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockContentValueRepo) HasDuplicateValues(fieldID uint) (bool, error) {
+	args := m.Called(fieldID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockContentValueRepo) WithTx(tx *gorm.DB) repository.ContentValueRepo {
 	m.Called(tx)
 	return m
@@ -0,0 +1,12 @@
+package mockrepo
+
+import "github.com/stretchr/testify/mock"
+
+type MockSchemaRepo struct {
+	mock.Mock
+}
+
+func (m *MockSchemaRepo) Fingerprint() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
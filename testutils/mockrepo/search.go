@@ -0,0 +1,43 @@
+package mockrepo
+
+import (
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"github.com/janmarkuslanger/nuricms/internal/repository"
+	"github.com/janmarkuslanger/nuricms/internal/repository/base"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockSearchRepo struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepo) Save(doc *model.SearchDocument) error {
+	return m.Called(doc).Error(0)
+}
+
+func (m *MockSearchRepo) DeleteByContentID(contentID uint) error {
+	return m.Called(contentID).Error(0)
+}
+
+func (m *MockSearchRepo) Count() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSearchRepo) Search(query string, collectionID uint, offset, limit int, opts ...base.QueryOption) ([]repository.SearchHit, int64, error) {
+	args := m.Called(query, collectionID, offset, limit)
+	return args.Get(0).([]repository.SearchHit), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSearchRepo) Matching(query string) base.QueryOption {
+	return m.Called(query).Get(0).(base.QueryOption)
+}
+
+func (m *MockSearchRepo) FullText() bool {
+	return m.Called().Bool(0)
+}
+
+func (m *MockSearchRepo) WithTx(tx *gorm.DB) repository.SearchRepo {
+	return m.Called(tx).Get(0).(repository.SearchRepo)
+}
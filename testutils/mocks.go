@@ -1,6 +1,9 @@
 package testutils
 
 import (
+	"time"
+
+	"github.com/graphql-go/graphql"
 	"github.com/janmarkuslanger/nuricms/internal/dto"
 	"github.com/janmarkuslanger/nuricms/internal/fs"
 	"github.com/janmarkuslanger/nuricms/internal/model"
@@ -84,6 +87,11 @@ func (m *MockCollectionService) FindByID(id uint) (*model.Collection, error) {
 	}
 	return nil, args.Error(1)
 }
+func (m *MockCollectionService) FindComponents() ([]model.Collection, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Collection), args.Error(1)
+}
+
 func (m *MockCollectionService) List(page, pageSize int) ([]model.Collection, int64, error) {
 	args := m.Called(page, pageSize)
 	return args.Get(0).([]model.Collection), args.Get(1).(int64), args.Error(2)
@@ -96,6 +104,16 @@ func (m *MockAssetService) List(page, pageSize int) ([]model.Asset, int64, error
 	return args.Get(0).([]model.Asset), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockAssetService) FindByIDs(ids []uint) ([]model.Asset, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]model.Asset), args.Error(1)
+}
+
+func (m *MockAssetService) Search(query string, page, pageSize int) ([]model.Asset, int64, error) {
+	args := m.Called(query, page, pageSize)
+	return args.Get(0).([]model.Asset), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockAssetService) DeleteByID(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -247,8 +265,18 @@ func (m *MockContentService) FindContentsWithDisplayContentValue() ([]model.Cont
 	return args.Get(0).([]model.Content), args.Error(1)
 }
 
-func (m *MockContentService) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int) ([]model.Content, int64, error) {
-	args := m.Called(collectionID, page, pageSize)
+func (m *MockContentService) FindReferences(ids []uint) ([]model.Content, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]model.Content), args.Error(1)
+}
+
+func (m *MockContentService) SearchReferences(query string, collectionIDs []uint, page, pageSize int) ([]model.Content, int64, error) {
+	args := m.Called(query, collectionIDs, page, pageSize)
+	return args.Get(0).([]model.Content), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockContentService) FindDisplayValueByCollectionID(collectionID uint, page, pageSize int, sort, query string) ([]model.Content, int64, error) {
+	args := m.Called(collectionID, page, pageSize, sort, query)
 	return args.Get(0).([]model.Content), args.Get(1).(int64), args.Error(2)
 }
 
@@ -278,30 +306,151 @@ func (m *MockContentService) Create(c *model.Content) (*model.Content, error) {
 	return nil, args.Error(1)
 }
 
+func (m *MockContentService) UpdateStatus(id uint, status model.ContentStatus) (*model.Content, error) {
+	args := m.Called(id, status)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*model.Content), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockContentService) RestoreRevision(contentID uint, revisionID uint, userID uint) (*model.Content, error) {
+	args := m.Called(contentID, revisionID, userID)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*model.Content), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockContentService) ApplySchedule(now time.Time) ([]dto.StatusChange, error) {
+	args := m.Called(now)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]dto.StatusChange), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockContentService) MissingTranslations(collectionID uint, defaultLocale string, locales []string) ([]dto.MissingTranslation, error) {
+	args := m.Called(collectionID, defaultLocale, locales)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]dto.MissingTranslation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockContentRevisionService struct {
+	mock.Mock
+}
+
+func (m *MockContentRevisionService) History(contentID uint) ([]dto.RevisionEntry, error) {
+	args := m.Called(contentID)
+	if obj := args.Get(0); obj != nil {
+		return obj.([]dto.RevisionEntry), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 type MockApiService struct {
 	mock.Mock
 }
 
-func (m *MockApiService) FindContentByCollectionAlias(alias string, offset int, perPage int) ([]dto.ContentItemResponse, error) {
-	args := m.Called(alias, offset, perPage)
-	return args.Get(0).([]dto.ContentItemResponse), args.Error(1)
+func (m *MockApiService) FindContentByCollectionAlias(alias string, offset int, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error) {
+	args := m.Called(alias, offset, perPage, q)
+	return args.Get(0).([]dto.ContentItemResponse), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockApiService) FindContentByID(id uint) (dto.ContentItemResponse, error) {
-	args := m.Called(id)
+func (m *MockApiService) FindContentByID(id uint, q dto.ApiQuery) (dto.ContentItemResponse, error) {
+	args := m.Called(id, q)
 	return args.Get(0).(dto.ContentItemResponse), args.Error(1)
 }
 
-func (m *MockApiService) FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int) ([]dto.ContentItemResponse, error) {
-	args := m.Called(alias, fieldAlias, value, offset, perPage)
-	return args.Get(0).([]dto.ContentItemResponse), args.Error(1)
+func (m *MockApiService) FindContentByCollectionAndFieldValue(alias, fieldAlias, value string, offset, perPage int, q dto.ApiQuery) ([]dto.ContentItemResponse, int64, error) {
+	args := m.Called(alias, fieldAlias, value, offset, perPage, q)
+	return args.Get(0).([]dto.ContentItemResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockApiService) CollectionLastModified(alias string) (time.Time, error) {
+	args := m.Called(alias)
+	return args.Get(0).(time.Time), args.Error(1)
 }
 
-func (m *MockApiService) PrepareContent(content *model.Content) (dto.ContentItemResponse, error) {
-	args := m.Called(content)
+func (m *MockApiService) PrepareContent(content *model.Content, q dto.ApiQuery) (dto.ContentItemResponse, error) {
+	args := m.Called(content, q)
 	return args.Get(0).(dto.ContentItemResponse), args.Error(1)
 }
 
+type MockGraphQLService struct {
+	mock.Mock
+}
+
+func (m *MockGraphQLService) Schema() (*graphql.Schema, error) {
+	args := m.Called()
+	schema, _ := args.Get(0).(*graphql.Schema)
+	return schema, args.Error(1)
+}
+
+func (m *MockGraphQLService) Execute(req dto.GraphQLRequest, maxPerPage int) *graphql.Result {
+	args := m.Called(req, maxPerPage)
+	return args.Get(0).(*graphql.Result)
+}
+
+type MockOpenAPIService struct {
+	mock.Mock
+}
+
+func (m *MockOpenAPIService) Spec() (map[string]any, error) {
+	args := m.Called()
+	spec, _ := args.Get(0).(map[string]any)
+	return spec, args.Error(1)
+}
+
+type MockSyncService struct {
+	mock.Mock
+}
+
+func (m *MockSyncService) Changes(token string, aliases []string, limit int, q dto.ApiQuery) (dto.SyncResponse, error) {
+	args := m.Called(token, aliases, limit, q)
+	return args.Get(0).(dto.SyncResponse), args.Error(1)
+}
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Search(query, alias string, offset, limit int, q dto.ApiQuery) ([]dto.SearchHit, int64, error) {
+	args := m.Called(query, alias, offset, limit, q)
+	var hits []dto.SearchHit
+	if v := args.Get(0); v != nil {
+		hits = v.([]dto.SearchHit)
+	}
+	return hits, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSearchService) EnsureIndex() error {
+	return m.Called().Error(0)
+}
+
+type MockEventService struct {
+	mock.Mock
+}
+
+func (m *MockEventService) Publish(eventType string, contentID, collectionID uint) {
+	m.Called(eventType, contentID, collectionID)
+}
+
+func (m *MockEventService) Subscribe(collections []string, lastEventID uint64) (<-chan dto.ContentEvent, []dto.ContentEvent, bool) {
+	args := m.Called(collections, lastEventID)
+	var missed []dto.ContentEvent
+	if v := args.Get(1); v != nil {
+		missed = v.([]dto.ContentEvent)
+	}
+	return args.Get(0).(chan dto.ContentEvent), missed, args.Bool(2)
+}
+
+func (m *MockEventService) Unsubscribe(events <-chan dto.ContentEvent) {
+	m.Called(events)
+}
+
 type MockApikeyService struct {
 	mock.Mock
 }
@@ -316,6 +465,14 @@ func (m *MockApikeyService) Create(data dto.ApikeyData) (*model.Apikey, error) {
 	return args.Get(0).(*model.Apikey), args.Error(1)
 }
 
+func (m *MockApikeyService) UpdateByID(id uint, data dto.ApikeyData) (*model.Apikey, error) {
+	args := m.Called(id, data)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*model.Apikey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockApikeyService) FindByID(id uint) (*model.Apikey, error) {
 	args := m.Called(id)
 	return args.Get(0).(*model.Apikey), args.Error(1)
@@ -330,3 +487,11 @@ func (m *MockApikeyService) Validate(token string) error {
 	args := m.Called(token)
 	return args.Error(0)
 }
+
+func (m *MockApikeyService) FindByToken(token string) (*model.Apikey, error) {
+	args := m.Called(token)
+	if obj := args.Get(0); obj != nil {
+		return obj.(*model.Apikey), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,73 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/janmarkuslanger/nuricms/internal/model"
+	"gorm.io/gorm"
+)
+
+// SeedCollection creates the collection with the given fields. The created
+// fields are set on the collection, so entries can be seeded by alias.
+func SeedCollection(t *testing.T, db *gorm.DB, collection *model.Collection, fields ...model.Field) *model.Collection {
+	t.Helper()
+
+	if err := db.Create(collection).Error; err != nil {
+		t.Fatalf("failed to seed collection %q: %v", collection.Alias, err)
+	}
+
+	collection.Fields = nil
+	for _, f := range fields {
+		f.CollectionID = collection.ID
+		if err := db.Create(&f).Error; err != nil {
+			t.Fatalf("failed to seed field %q: %v", f.Alias, err)
+		}
+		collection.Fields = append(collection.Fields, f)
+	}
+
+	return collection
+}
+
+// SeedField returns the seeded field of the collection with the alias.
+func SeedField(t *testing.T, collection *model.Collection, alias string) model.Field {
+	t.Helper()
+
+	for _, f := range collection.Fields {
+		if f.Alias == alias {
+			return f
+		}
+	}
+
+	t.Fatalf("collection %q has no field %q", collection.Alias, alias)
+	return model.Field{}
+}
+
+// SeedContent creates an entry of the collection with the values by field
+// alias. The values are stored as they are, without validation.
+func SeedContent(t *testing.T, db *gorm.DB, collection *model.Collection, status model.ContentStatus, values map[string][]string) *model.Content {
+	t.Helper()
+
+	content := &model.Content{CollectionID: collection.ID, Status: status}
+	if err := db.Create(content).Error; err != nil {
+		t.Fatalf("failed to seed content: %v", err)
+	}
+
+	SeedValues(t, db, collection, content, values)
+	return content
+}
+
+// SeedValues adds values by field alias to an entry, list values keep their
+// order.
+func SeedValues(t *testing.T, db *gorm.DB, collection *model.Collection, content *model.Content, values map[string][]string) {
+	t.Helper()
+
+	for alias, list := range values {
+		field := SeedField(t, collection, alias)
+		for i, v := range list {
+			cv := model.ContentValue{ContentID: content.ID, FieldID: field.ID, Value: v, SortIndex: i + 1}
+			if err := db.Create(&cv).Error; err != nil {
+				t.Fatalf("failed to seed value %q: %v", alias, err)
+			}
+		}
+	}
+}